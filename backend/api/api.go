@@ -6,16 +6,29 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for BarcodeSymbology.
+const (
+	CODE128 BarcodeSymbology = "CODE128"
+	CODE39  BarcodeSymbology = "CODE39"
+	EAN13   BarcodeSymbology = "EAN13"
+	UPCA    BarcodeSymbology = "UPCA"
+)
+
 // Defines values for DeviceState.
 const (
 	BUSY         DeviceState = "BUSY"
@@ -23,27 +36,256 @@ const (
 	DISCONNECTED DeviceState = "DISCONNECTED"
 	OUTOFPAPER   DeviceState = "OUT_OF_PAPER"
 	READY        DeviceState = "READY"
+	RECONNECTING DeviceState = "RECONNECTING"
+)
+
+// Defines values for DitherAlgorithm.
+const (
+	ATKINSON          DitherAlgorithm = "ATKINSON"
+	BAYER             DitherAlgorithm = "BAYER"
+	BURKES            DitherAlgorithm = "BURKES"
+	FLOYDSTEINBERG    DitherAlgorithm = "FLOYD_STEINBERG"
+	JARVISJUDICENINKE DitherAlgorithm = "JARVIS_JUDICE_NINKE"
+	SIERRA            DitherAlgorithm = "SIERRA"
+	STUCKI            DitherAlgorithm = "STUCKI"
+	THRESHOLD         DitherAlgorithm = "THRESHOLD"
+)
+
+// Defines values for ErrorCorrectionLevel.
+const (
+	H ErrorCorrectionLevel = "H"
+	L ErrorCorrectionLevel = "L"
+	M ErrorCorrectionLevel = "M"
+	Q ErrorCorrectionLevel = "Q"
+)
+
+// Defines values for HistorySource.
+const (
+	IMAGE    HistorySource = "IMAGE"
+	TEMPLATE HistorySource = "TEMPLATE"
+)
+
+// Defines values for JobStatus.
+const (
+	CANCELLED JobStatus = "CANCELLED"
+	DONE      JobStatus = "DONE"
+	FAILED    JobStatus = "FAILED"
+	PRINTING  JobStatus = "PRINTING"
+	QUEUED    JobStatus = "QUEUED"
+)
+
+// Defines values for Justify.
+const (
+	JustifyCENTRE Justify = "CENTRE"
+	JustifyLEFT   Justify = "LEFT"
+	JustifyRIGHT  Justify = "RIGHT"
+)
+
+// Defines values for LaserIntensity.
+const (
+	HIGH   LaserIntensity = "HIGH"
+	LOW    LaserIntensity = "LOW"
+	MEDIUM LaserIntensity = "MEDIUM"
+)
+
+// Defines values for MatrixCodeSymbology.
+const (
+	DATAMATRIX MatrixCodeSymbology = "DATAMATRIX"
+	QR         MatrixCodeSymbology = "QR"
+)
+
+// Defines values for ParameterType.
+const (
+	BOOLEAN ParameterType = "BOOLEAN"
+	DATE    ParameterType = "DATE"
+	DECIMAL ParameterType = "DECIMAL"
+	ENUM    ParameterType = "ENUM"
+	INTEGER ParameterType = "INTEGER"
+	STRING  ParameterType = "STRING"
+)
+
+// Defines values for PrinterEventType.
+const (
+	BATTERYCHANGED PrinterEventType = "BATTERY_CHANGED"
+	FIRMWAREINFO   PrinterEventType = "FIRMWARE_INFO"
+	JOBCHANGED     PrinterEventType = "JOB_CHANGED"
+	PAPERCHANGED   PrinterEventType = "PAPER_CHANGED"
+	PRINTFAILED    PrinterEventType = "PRINT_FAILED"
+	PRINTFINISHED  PrinterEventType = "PRINT_FINISHED"
+	PRINTSTARTED   PrinterEventType = "PRINT_STARTED"
+	STATECHANGED   PrinterEventType = "STATE_CHANGED"
+)
+
+// Defines values for ShapeKind.
+const (
+	ELLIPSE     ShapeKind = "ELLIPSE"
+	LINE        ShapeKind = "LINE"
+	RECT        ShapeKind = "RECT"
+	ROUNDEDRECT ShapeKind = "ROUNDED_RECT"
+)
+
+// Defines values for TextAlign.
+const (
+	TextAlignCENTRE  TextAlign = "CENTRE"
+	TextAlignJUSTIFY TextAlign = "JUSTIFY"
+	TextAlignLEFT    TextAlign = "LEFT"
+	TextAlignRIGHT   TextAlign = "RIGHT"
 )
 
+// Defines values for VerticalAlign.
+const (
+	BOTTOM VerticalAlign = "BOTTOM"
+	MIDDLE VerticalAlign = "MIDDLE"
+	TOP    VerticalAlign = "TOP"
+)
+
+// BarcodeSymbology defines model for BarcodeSymbology.
+type BarcodeSymbology string
+
+// BatchProgress defines model for BatchProgress.
+type BatchProgress struct {
+	Cancelled int        `json:"cancelled"`
+	Done      int        `json:"done"`
+	Failed    int        `json:"failed"`
+	Jobs      []PrintJob `json:"jobs"`
+	Printing  int        `json:"printing"`
+	Queued    int        `json:"queued"`
+	Total     int        `json:"total"`
+	Uuid      Uuid       `json:"uuid"`
+}
+
+// BatchRowError defines model for BatchRowError.
+type BatchRowError struct {
+	Errors []string `json:"errors"`
+
+	// Row Number of the row, counting from 1 and not counting the CSV header
+	Row int `json:"row"`
+}
+
 // DeviceInfo defines model for DeviceInfo.
 type DeviceInfo struct {
 	// BatteryLevel Battery level of device as a percentage
 	BatteryLevel int `json:"batteryLevel"`
 
+	// DotsPerLine Width of the print head in dots, which is the widest label the printer can print
+	DotsPerLine int `json:"dotsPerLine"`
+	Dpi         int `json:"dpi"`
+
 	// FirmwareVersion Device specific firmware version string
-	FirmwareVersion string      `json:"firmwareVersion"`
-	State           DeviceState `json:"state"`
+	FirmwareVersion string `json:"firmwareVersion"`
+
+	// Model The model the printer was identified as, which decides the size of its print head
+	Model string `json:"model"`
+
+	// NextReconnectAt When the next attempt to reconnect will be made
+	NextReconnectAt *time.Time `json:"nextReconnectAt,omitempty"`
+
+	// ReconnectAttempts Number of failed attempts to reconnect since the connection to the printer was lost
+	ReconnectAttempts *int        `json:"reconnectAttempts,omitempty"`
+	State             DeviceState `json:"state"`
 }
 
 // DeviceState defines model for DeviceState.
 type DeviceState string
 
+// DitherAlgorithm THRESHOLD suits logos and text, ordered (BAYER) and error diffusion algorithms suit photos
+type DitherAlgorithm string
+
+// DitherOptions How an image is turned black and white for printing
+type DitherOptions struct {
+	// Algorithm THRESHOLD suits logos and text, ordered (BAYER) and error diffusion algorithms suit photos
+	Algorithm *DitherAlgorithm `json:"algorithm,omitempty"`
+
+	// Brightness Added to each pixel's grey level, from -1 (all black) to 1 (all white)
+	Brightness *float32 `json:"brightness,omitempty"`
+
+	// Contrast From -1 (flat grey) to 1 (maximum contrast)
+	Contrast *float32 `json:"contrast,omitempty"`
+
+	// Gamma Each pixel's grey level (0 to 1) is raised to this power, so values below 1 lighten the image
+	Gamma  *float32 `json:"gamma,omitempty"`
+	Invert *bool    `json:"invert,omitempty"`
+}
+
+// ErrorCorrectionLevel Error correction level of a QR code. Data Matrix codes have a fixed level of error correction, so this is ignored for them
+type ErrorCorrectionLevel string
+
 // Font defines model for Font.
 type Font struct {
-	Name string `json:"name"`
-	Uuid Uuid   `json:"uuid"`
+	// Builtin Whether the font comes with phogoprint, rather than being uploaded
+	Builtin bool `json:"builtin"`
+
+	// Family Family name from the font file
+	Family string `json:"family"`
+	Name   string `json:"name"`
+
+	// Style Style (subfamily) name from the font file
+	Style string `json:"style"`
+	Uuid  Uuid   `json:"uuid"`
+}
+
+// FontInUse defines model for FontInUse.
+type FontInUse struct {
+	Reason string `json:"reason"`
+
+	// Templates UUIDs of the templates using the font
+	Templates []Uuid `json:"templates"`
+}
+
+// HistoryEntry defines model for HistoryEntry.
+type HistoryEntry struct {
+	Height  int  `json:"height"`
+	JobUuid Uuid `json:"jobUuid"`
+
+	// Options How the printer prints a label. Any options left out use the template's defaults, or the printer's defaults when printing an image directly
+	Options         PrintOptions      `json:"options"`
+	Outcome         JobStatus         `json:"outcome"`
+	ParameterValues *[]ParameterValue `json:"parameterValues,omitempty"`
+	PrintedAt       time.Time         `json:"printedAt"`
+
+	// Reason Why printing failed or was cancelled
+	Reason           *string       `json:"reason,omitempty"`
+	ReprintOf        *Uuid         `json:"reprintOf,omitempty"`
+	Source           HistorySource `json:"source"`
+	TemplateRevision *int          `json:"templateRevision,omitempty"`
+	TemplateUuid     *Uuid         `json:"templateUuid,omitempty"`
+	Uuid             Uuid          `json:"uuid"`
+
+	// Width Width of the printed bitmap in dots
+	Width int `json:"width"`
 }
 
+// HistorySource defines model for HistorySource.
+type HistorySource string
+
+// InvalidParameters defines model for InvalidParameters.
+type InvalidParameters struct {
+	// ParameterErrors What's wrong with each parameter, if the problem is with the parameter values
+	ParameterErrors *[]ParameterError `json:"parameterErrors,omitempty"`
+	Reason          string            `json:"reason"`
+}
+
+// JobStatus defines model for JobStatus.
+type JobStatus string
+
+// Justify Where labels narrower than the print head are placed on the paper
+type Justify string
+
+// LaserIntensity How dark the printer prints
+type LaserIntensity string
+
+// MatrixCodeSymbology defines model for MatrixCodeSymbology.
+type MatrixCodeSymbology string
+
+// ParameterError defines model for ParameterError.
+type ParameterError struct {
+	Message   string `json:"message"`
+	Parameter string `json:"parameter"`
+}
+
+// ParameterType What kind of value a parameter takes. Dates are given as YYYY-MM-DD, and booleans as true/false, yes/no or 1/0
+type ParameterType string
+
 // ParameterValue defines model for ParameterValue.
 type ParameterValue struct {
 	ParameterName string `json:"parameterName"`
@@ -56,26 +298,138 @@ type Position struct {
 	Y int `json:"y"`
 }
 
+// PreviewTemplateRequest defines model for PreviewTemplateRequest.
+type PreviewTemplateRequest struct {
+	ParameterValues []ParameterValue `json:"parameterValues"`
+	Template        Template         `json:"template"`
+}
+
+// PrintBatch defines model for PrintBatch.
+type PrintBatch struct {
+	DryRun bool `json:"dryRun"`
+
+	// Jobs One job for each row, in the order they'll be printed
+	Jobs     *[]PrintJob `json:"jobs,omitempty"`
+	RowCount int         `json:"rowCount"`
+	Uuid     *Uuid       `json:"uuid,omitempty"`
+}
+
+// PrintJob defines model for PrintJob.
+type PrintJob struct {
+	BatchUuid  *Uuid      `json:"batchUuid,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	// Options How the printer prints a label. Any options left out use the template's defaults, or the printer's defaults when printing an image directly
+	Options *PrintOptions `json:"options,omitempty"`
+
+	// Reason Why the job failed or was cancelled
+	Reason    *string    `json:"reason,omitempty"`
+	ReprintOf *Uuid      `json:"reprintOf,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	Status    JobStatus  `json:"status"`
+
+	// TemplateRevision The revision of the template which was printed
+	TemplateRevision *int  `json:"templateRevision,omitempty"`
+	TemplateUuid     *Uuid `json:"templateUuid,omitempty"`
+	Uuid             Uuid  `json:"uuid"`
+}
+
+// PrintOptions How the printer prints a label. Any options left out use the template's defaults, or the printer's defaults when printing an image directly
+type PrintOptions struct {
+	Copies *int `json:"copies,omitempty"`
+
+	// FeedLines Number of blank lines of paper fed through after the label
+	FeedLines *int `json:"feedLines,omitempty"`
+
+	// Intensity How dark the printer prints
+	Intensity *LaserIntensity `json:"intensity,omitempty"`
+
+	// Justify Where labels narrower than the print head are placed on the paper
+	Justify *Justify `json:"justify,omitempty"`
+}
+
+// PrintTemplateBatchRequest defines model for PrintTemplateBatchRequest.
+type PrintTemplateBatchRequest struct {
+	// Options How the printer prints a label. Any options left out use the template's defaults, or the printer's defaults when printing an image directly
+	Options *PrintOptions      `json:"options,omitempty"`
+	Rows    [][]ParameterValue `json:"rows"`
+}
+
 // PrintTemplateRequest defines model for PrintTemplateRequest.
 type PrintTemplateRequest struct {
+	// Options How the printer prints a label. Any options left out use the template's defaults, or the printer's defaults when printing an image directly
+	Options         *PrintOptions    `json:"options,omitempty"`
 	ParameterValues []ParameterValue `json:"parameterValues"`
 }
 
+// PrinterEvent Something which happened to the printer. Only the fields relevant to the type of event are set
+type PrinterEvent struct {
+	BatteryLevel *int `json:"batteryLevel,omitempty"`
+
+	// Copies Number of copies being printed, for print events
+	Copies *int `json:"copies,omitempty"`
+
+	// Error Why printing or a job failed
+	Error           *string          `json:"error,omitempty"`
+	FirmwareVersion *string          `json:"firmwareVersion,omitempty"`
+	JobStatus       *JobStatus       `json:"jobStatus,omitempty"`
+	JobUuid         *Uuid            `json:"jobUuid,omitempty"`
+	PaperLoaded     *bool            `json:"paperLoaded,omitempty"`
+	State           *DeviceState     `json:"state,omitempty"`
+	Time            time.Time        `json:"time"`
+	Type            PrinterEventType `json:"type"`
+}
+
+// PrinterEventType defines model for PrinterEventType.
+type PrinterEventType string
+
+// ShapeKind defines model for ShapeKind.
+type ShapeKind string
+
 // Template defines model for Template.
 type Template struct {
-	Images     *[]TemplateImage     `json:"images,omitempty"`
-	Landscape  bool                 `json:"landscape"`
-	MaxSize    int                  `json:"maxSize"`
-	MinSize    int                  `json:"minSize"`
-	Name       string               `json:"name"`
-	Parameters *[]TemplateParameter `json:"parameters,omitempty"`
-	Texts      *[]TemplateText      `json:"texts,omitempty"`
-	Uuid       Uuid                 `json:"uuid"`
+	Barcodes    *[]TemplateBarcode    `json:"barcodes,omitempty"`
+	Images      *[]TemplateImage      `json:"images,omitempty"`
+	Landscape   bool                  `json:"landscape"`
+	MatrixCodes *[]TemplateMatrixCode `json:"matrixCodes,omitempty"`
+	MaxSize     int                   `json:"maxSize"`
+	MinSize     int                   `json:"minSize"`
+	Name        string                `json:"name"`
+	Parameters  *[]TemplateParameter  `json:"parameters,omitempty"`
+
+	// PrintOptions How the printer prints a label. Any options left out use the template's defaults, or the printer's defaults when printing an image directly
+	PrintOptions *PrintOptions `json:"printOptions,omitempty"`
+
+	// Revision The template's latest revision, which goes up by one each time it's saved. Ignored when saving
+	Revision *int             `json:"revision,omitempty"`
+	Shapes   *[]TemplateShape `json:"shapes,omitempty"`
+	Texts    *[]TemplateText  `json:"texts,omitempty"`
+	Uuid     Uuid             `json:"uuid"`
+}
+
+// TemplateBarcode defines model for TemplateBarcode.
+type TemplateBarcode struct {
+	// Data The data to encode, which can contain expressions like text does. EAN-13 and UPC-A data can leave out the check digit, which is then calculated
+	Data string `json:"data"`
+
+	// Height Height in pixels of the bars, not including the human readable text
+	Height int `json:"height"`
+
+	// ModuleWidth Width in pixels of the narrowest bar
+	ModuleWidth *int     `json:"moduleWidth,omitempty"`
+	Position    Position `json:"position"`
+
+	// ShowText Whether to print the encoded data as text under the bars
+	ShowText  *bool            `json:"showText,omitempty"`
+	Symbology BarcodeSymbology `json:"symbology"`
 }
 
 // TemplateImage defines model for TemplateImage.
 type TemplateImage struct {
-	Height int `json:"height"`
+	// Dither How an image is turned black and white for printing
+	Dither *DitherOptions `json:"dither,omitempty"`
+	Height int            `json:"height"`
 
 	// Image base64 image data
 	Image    string   `json:"image"`
@@ -83,393 +437,5648 @@ type TemplateImage struct {
 	Width    int      `json:"width"`
 }
 
+// TemplateMatrixCode A 2D code, i.e. a QR code or Data Matrix code
+type TemplateMatrixCode struct {
+	// Data The data to encode, which can contain expressions like text does
+	Data string `json:"data"`
+
+	// ErrorCorrection Error correction level of a QR code. Data Matrix codes have a fixed level of error correction, so this is ignored for them
+	ErrorCorrection *ErrorCorrectionLevel `json:"errorCorrection,omitempty"`
+
+	// ModuleSize Width and height in pixels of each module (square) of the code
+	ModuleSize *int     `json:"moduleSize,omitempty"`
+	Position   Position `json:"position"`
+
+	// QuietZone Width in modules of the blank margin around the code. Defaults to 4 for QR codes and 1 for Data Matrix codes
+	QuietZone *int                `json:"quietZone,omitempty"`
+	Symbology MatrixCodeSymbology `json:"symbology"`
+}
+
 // TemplateParameter defines model for TemplateParameter.
 type TemplateParameter struct {
-	MaxLength *int   `json:"maxLength,omitempty"`
-	Name      string `json:"name"`
+	// Choices The values an enum parameter can take
+	Choices *[]string `json:"choices,omitempty"`
+
+	// DateLayout How dates are printed, using the tokens YYYY, YY, MMMM, MMM, MM, M, DD, D, dddd and ddd. Dates are printed as YYYY-MM-DD if empty
+	DateLayout *string `json:"dateLayout,omitempty"`
+
+	// Default Value used for an optional parameter when none is given
+	Default *string `json:"default,omitempty"`
+
+	// Max Largest value allowed for an integer, decimal or date parameter, written as a value of that type
+	Max *string `json:"max,omitempty"`
+
+	// MaxLength Maximum number of characters in the value, or 0 for no limit
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Min Smallest value allowed for an integer, decimal or date parameter, written as a value of that type
+	Min  *string `json:"min,omitempty"`
+	Name string  `json:"name"`
+
+	// Padding Integers are padded with zeroes to at least this many digits
+	Padding *int `json:"padding,omitempty"`
+
+	// Pattern Regular expression the whole value has to match
+	Pattern *string `json:"pattern,omitempty"`
+
+	// Required Whether a value has to be given. Optional parameters left empty use the default value
+	Required *bool `json:"required,omitempty"`
+
+	// Type What kind of value a parameter takes. Dates are given as YYYY-MM-DD, and booleans as true/false, yes/no or 1/0
+	Type *ParameterType `json:"type,omitempty"`
+}
+
+// TemplateRevision defines model for TemplateRevision.
+type TemplateRevision struct {
+	Author    *string   `json:"author,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	Note      *string   `json:"note,omitempty"`
+	Revision  int       `json:"revision"`
+	Template  *Template `json:"template,omitempty"`
+}
+
+// TemplateShape A line, box or ellipse, e.g. a separator or a border. Shapes are drawn as solid black pixels at the printer's resolution, under any text, barcodes and codes
+type TemplateShape struct {
+	// CornerRadius Radius in pixels of the corners of a rounded rectangle
+	CornerRadius *int `json:"cornerRadius,omitempty"`
+
+	// Dash Lengths in pixels of alternating dashes and gaps along the outline. Empty for a solid outline, and an odd number of lengths is repeated
+	Dash *[]int `json:"dash,omitempty"`
+
+	// Fill Whether to fill the inside of the shape. Lines can't be filled
+	Fill     *bool     `json:"fill,omitempty"`
+	Height   int       `json:"height"`
+	Kind     ShapeKind `json:"kind"`
+	Position Position  `json:"position"`
+
+	// StrokeWidth Thickness in pixels of the line or outline, which is drawn inside the shape's box. 0 for a filled shape with no outline
+	StrokeWidth *int `json:"strokeWidth,omitempty"`
+
+	// Width Width of the shape's box. Lines are drawn from the position to the position plus the width and height, which can be negative or 0
+	Width int `json:"width"`
 }
 
 // TemplateText defines model for TemplateText.
 type TemplateText struct {
-	FontSize int  `json:"fontSize"`
-	FontUuid Uuid `json:"fontUuid"`
+	// Align How lines of text are aligned within the text's width, or within the widest line if it has no width. Justified text is spread out to fill the width, except for the last line
+	Align *TextAlign `json:"align,omitempty"`
+
+	// AutoFit Shrinks the font a point at a time, down to minFontSize, until the text fits within its width and height
+	AutoFit  *bool `json:"autoFit,omitempty"`
+	FontSize int   `json:"fontSize"`
+	FontUuid Uuid  `json:"fontUuid"`
 
 	// Height The max allowed height of the text. This is required if the template is landscape (landscape = `true`)
-	Height   *int     `json:"height,omitempty"`
-	Position Position `json:"position"`
-	Text     string   `json:"text"`
+	Height *int `json:"height,omitempty"`
+
+	// LineHeight Multiplies the font's natural line height
+	LineHeight *float32 `json:"lineHeight,omitempty"`
+
+	// MaxLines Text which wraps onto more lines than this is cut short with an ellipsis. 0 for no limit
+	MaxLines *int `json:"maxLines,omitempty"`
+
+	// MinFontSize The smallest font size auto-fitting text can shrink to
+	MinFontSize *int     `json:"minFontSize,omitempty"`
+	Position    Position `json:"position"`
+
+	// Text The text to print, which can contain expressions in braces. `{address}` inserts a parameter, and filters can be applied like `{address | upper | truncate:20}`. The filters are upper, lower, trim, truncate:n, pad:n (or pad:n:"0" to pad with zeroes), date:"DD/MM/YYYY" and default:"fallback". `{if address}...{else}...{end}` prints text conditionally, and conditions can compare values with == and !=. The built-ins `now`, `copy` and `copies` give the time and which copy is being printed. `{{` and `}}` print literal braces. Mistakes in expressions are reported when the template is saved
+	Text string `json:"text"`
+
+	// VerticalAlign How text is aligned within the text's height
+	VerticalAlign *VerticalAlign `json:"verticalAlign,omitempty"`
 
 	// Width The max allowed width of the text. This is required if the template is portrait (landscape = `false`)
 	Width *int `json:"width,omitempty"`
 }
 
+// TextAlign How lines of text are aligned within the text's width, or within the widest line if it has no width. Justified text is spread out to fill the width, except for the last line
+type TextAlign string
+
 // Uuid defines model for Uuid.
 type Uuid = string
 
+// VerticalAlign How text is aligned within the text's height
+type VerticalAlign string
+
+// Revision defines model for Revision.
+type Revision = int
+
+// RevisionAuthor defines model for RevisionAuthor.
+type RevisionAuthor = string
+
+// RevisionNote defines model for RevisionNote.
+type RevisionNote = string
+
+// UploadFontParams defines parameters for UploadFont.
+type UploadFontParams struct {
+	// Name What to call the font. Defaults to the full name in the font file
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+}
+
+// RenameFontJSONBody defines parameters for RenameFont.
+type RenameFontJSONBody struct {
+	Name string `json:"name"`
+}
+
+// ListHistoryParams defines parameters for ListHistory.
+type ListHistoryParams struct {
+	// Source Only labels printed from templates, or images printed directly
+	Source       *HistorySource `form:"source,omitempty" json:"source,omitempty"`
+	TemplateUuid *Uuid          `form:"templateUuid,omitempty" json:"templateUuid,omitempty"`
+	Outcome      *JobStatus     `form:"outcome,omitempty" json:"outcome,omitempty"`
+
+	// From Only labels printed at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only labels printed before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit The most entries to list
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetHistoryThumbnailParams defines parameters for GetHistoryThumbnail.
+type GetHistoryThumbnailParams struct {
+	// Width Width to scale the bitmap down to. Bitmaps narrower than this are left as they are
+	Width *int `form:"width,omitempty" json:"width,omitempty"`
+}
+
 // PrintImageJSONBody defines parameters for PrintImage.
 type PrintImageJSONBody struct {
 	ContentType string             `json:"contentType"`
 	Data        openapi_types.File `json:"data"`
+
+	// Dither How an image is turned black and white for printing
+	Dither *DitherOptions `json:"dither,omitempty"`
+
+	// Options How the printer prints a label. Any options left out use the template's defaults, or the printer's defaults when printing an image directly
+	Options *PrintOptions `json:"options,omitempty"`
+}
+
+// RenameTemplateJSONBody defines parameters for RenameTemplate.
+type RenameTemplateJSONBody struct {
+	Name string `json:"name"`
+}
+
+// CreateOrUpdateTemplateParams defines parameters for CreateOrUpdateTemplate.
+type CreateOrUpdateTemplateParams struct {
+	// Author Who's saving the template, which is kept with the revision
+	Author *RevisionAuthor `form:"author,omitempty" json:"author,omitempty"`
+
+	// Note Why the template is being saved, which is kept with the revision
+	Note *RevisionNote `form:"note,omitempty" json:"note,omitempty"`
+}
+
+// PrintTemplateBatchParams defines parameters for PrintTemplateBatch.
+type PrintTemplateBatchParams struct {
+	// DryRun Only check the rows, without printing anything
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+
+	// Copies Number of copies to print of each row. Overrides the copies in the print options
+	Copies *int `form:"copies,omitempty" json:"copies,omitempty"`
+}
+
+// DuplicateTemplateJSONBody defines parameters for DuplicateTemplate.
+type DuplicateTemplateJSONBody struct {
+	// Name Name of the copy. Defaults to the template's name followed by "(copy)"
+	Name *string `json:"name,omitempty"`
+	Uuid *Uuid   `json:"uuid,omitempty"`
+}
+
+// RestoreTemplateRevisionParams defines parameters for RestoreTemplateRevision.
+type RestoreTemplateRevisionParams struct {
+	// Author Who's saving the template, which is kept with the revision
+	Author *RevisionAuthor `form:"author,omitempty" json:"author,omitempty"`
+
+	// Note Why the template is being saved, which is kept with the revision
+	Note *RevisionNote `form:"note,omitempty" json:"note,omitempty"`
 }
 
+// RenameFontJSONRequestBody defines body for RenameFont for application/json ContentType.
+type RenameFontJSONRequestBody RenameFontJSONBody
+
 // PrintImageJSONRequestBody defines body for PrintImage for application/json ContentType.
 type PrintImageJSONRequestBody PrintImageJSONBody
 
+// PreviewUnsavedTemplateJSONRequestBody defines body for PreviewUnsavedTemplate for application/json ContentType.
+type PreviewUnsavedTemplateJSONRequestBody = PreviewTemplateRequest
+
+// RenameTemplateJSONRequestBody defines body for RenameTemplate for application/json ContentType.
+type RenameTemplateJSONRequestBody RenameTemplateJSONBody
+
 // CreateOrUpdateTemplateJSONRequestBody defines body for CreateOrUpdateTemplate for application/json ContentType.
 type CreateOrUpdateTemplateJSONRequestBody = Template
 
+// PrintTemplateBatchJSONRequestBody defines body for PrintTemplateBatch for application/json ContentType.
+type PrintTemplateBatchJSONRequestBody = PrintTemplateBatchRequest
+
+// DuplicateTemplateJSONRequestBody defines body for DuplicateTemplate for application/json ContentType.
+type DuplicateTemplateJSONRequestBody DuplicateTemplateJSONBody
+
+// PreviewTemplateJSONRequestBody defines body for PreviewTemplate for application/json ContentType.
+type PreviewTemplateJSONRequestBody = PrintTemplateRequest
+
 // PrintTemplateJSONRequestBody defines body for PrintTemplate for application/json ContentType.
 type PrintTemplateJSONRequestBody = PrintTemplateRequest
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// List fonts
-	// (GET /font)
-	ListFont(w http.ResponseWriter, r *http.Request)
-	// Print an image directly
-	// (POST /printer)
-	PrintImage(w http.ResponseWriter, r *http.Request)
-	// Get device info
-	// (GET /printer/info)
-	GetPrinterInfo(w http.ResponseWriter, r *http.Request)
-	// List templates
-	// (GET /template)
-	ListTemplate(w http.ResponseWriter, r *http.Request)
-	// Get a single template
-	// (GET /template/{uuid})
-	GetTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
-	// Create a new template, or update an existing one
-	// (PUT /template/{uuid})
-	CreateOrUpdateTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
-	// Print a template
-	// (POST /template/{uuid}/print)
-	PrintTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
-}
+// PreviewTemplateRevisionJSONRequestBody defines body for PreviewTemplateRevision for application/json ContentType.
+type PreviewTemplateRevisionJSONRequestBody = PrintTemplateRequest
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
 
-// ListFont operation middleware
-func (siw *ServerInterfaceWrapper) ListFont(w http.ResponseWriter, r *http.Request) {
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListFont(w, r)
-	}))
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
 	}
+}
 
-	handler.ServeHTTP(w, r)
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
 }
 
-// PrintImage operation middleware
-func (siw *ServerInterfaceWrapper) PrintImage(w http.ResponseWriter, r *http.Request) {
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetBatch request
+	GetBatch(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PrintImage(w, r)
-	}))
+	// ListFont request
+	ListFont(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// UploadFontWithBody request with any body
+	UploadFontWithBody(ctx context.Context, params *UploadFontParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler.ServeHTTP(w, r)
-}
+	// DeleteFont request
+	DeleteFont(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-// GetPrinterInfo operation middleware
-func (siw *ServerInterfaceWrapper) GetPrinterInfo(w http.ResponseWriter, r *http.Request) {
+	// RenameFontWithBody request with any body
+	RenameFontWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPrinterInfo(w, r)
-	}))
+	RenameFont(ctx context.Context, uuid Uuid, body RenameFontJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// ListHistory request
+	ListHistory(ctx context.Context, params *ListHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler.ServeHTTP(w, r)
-}
+	// GetHistory request
+	GetHistory(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-// ListTemplate operation middleware
-func (siw *ServerInterfaceWrapper) ListTemplate(w http.ResponseWriter, r *http.Request) {
+	// Reprint request
+	Reprint(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTemplate(w, r)
-	}))
+	// GetHistoryThumbnail request
+	GetHistoryThumbnail(ctx context.Context, uuid Uuid, params *GetHistoryThumbnailParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// ListJob request
+	ListJob(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler.ServeHTTP(w, r)
-}
+	// GetJob request
+	GetJob(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-// GetTemplate operation middleware
-func (siw *ServerInterfaceWrapper) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	// CancelJob request
+	CancelJob(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	var err error
+	// PrintImageWithBody request with any body
+	PrintImageWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	// ------------- Path parameter "uuid" -------------
-	var uuid Uuid
+	PrintImage(ctx context.Context, body PrintImageJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
-		return
-	}
+	// GetPrinterEvents request
+	GetPrinterEvents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTemplate(w, r, uuid)
-	}))
+	// GetPrinterInfo request
+	GetPrinterInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// ListTemplate request
+	ListTemplate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler.ServeHTTP(w, r)
-}
+	// PreviewUnsavedTemplateWithBody request with any body
+	PreviewUnsavedTemplateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-// CreateOrUpdateTemplate operation middleware
-func (siw *ServerInterfaceWrapper) CreateOrUpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	PreviewUnsavedTemplate(ctx context.Context, body PreviewUnsavedTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	var err error
+	// DeleteTemplate request
+	DeleteTemplate(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	// ------------- Path parameter "uuid" -------------
-	var uuid Uuid
+	// GetTemplate request
+	GetTemplate(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
-		return
-	}
+	// RenameTemplateWithBody request with any body
+	RenameTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateOrUpdateTemplate(w, r, uuid)
-	}))
+	RenameTemplate(ctx context.Context, uuid Uuid, body RenameTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// CreateOrUpdateTemplateWithBody request with any body
+	CreateOrUpdateTemplateWithBody(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler.ServeHTTP(w, r)
-}
+	CreateOrUpdateTemplate(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, body CreateOrUpdateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-// PrintTemplate operation middleware
-func (siw *ServerInterfaceWrapper) PrintTemplate(w http.ResponseWriter, r *http.Request) {
+	// PrintTemplateBatchWithBody request with any body
+	PrintTemplateBatchWithBody(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	var err error
+	PrintTemplateBatch(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, body PrintTemplateBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	// ------------- Path parameter "uuid" -------------
-	var uuid Uuid
+	// DuplicateTemplateWithBody request with any body
+	DuplicateTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
-		return
-	}
+	DuplicateTemplate(ctx context.Context, uuid Uuid, body DuplicateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PrintTemplate(w, r, uuid)
-	}))
+	// PreviewTemplateWithBody request with any body
+	PreviewTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	PreviewTemplate(ctx context.Context, uuid Uuid, body PreviewTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	handler.ServeHTTP(w, r)
-}
+	// PrintTemplateWithBody request with any body
+	PrintTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+	PrintTemplate(ctx context.Context, uuid Uuid, body PrintTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	// ListTemplateRevisions request
+	ListTemplateRevisions(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// GetTemplateRevision request
+	GetTemplateRevision(ctx context.Context, uuid Uuid, revision Revision, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	// PreviewTemplateRevisionWithBody request with any body
+	PreviewTemplateRevisionWithBody(ctx context.Context, uuid Uuid, revision Revision, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	PreviewTemplateRevision(ctx context.Context, uuid Uuid, revision Revision, body PreviewTemplateRevisionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+	// RestoreTemplateRevision request
+	RestoreTemplateRevision(ctx context.Context, uuid Uuid, revision Revision, params *RestoreTemplateRevisionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-type RequiredParamError struct {
-	ParamName string
+func (c *Client) GetBatch(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBatchRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+func (c *Client) ListFont(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListFontRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+func (c *Client) UploadFontWithBody(ctx context.Context, params *UploadFontParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadFontRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+func (c *Client) DeleteFont(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFontRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+func (c *Client) RenameFontWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameFontRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+func (c *Client) RenameFont(ctx context.Context, uuid Uuid, body RenameFontJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameFontRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+func (c *Client) ListHistory(ctx context.Context, params *ListHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListHistoryRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+func (c *Client) GetHistory(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHistoryRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+func (c *Client) Reprint(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprintRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+func (c *Client) GetHistoryThumbnail(ctx context.Context, uuid Uuid, params *GetHistoryThumbnailParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHistoryThumbnailRequest(c.Server, uuid, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
+func (c *Client) ListJob(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListJobRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
+func (c *Client) GetJob(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJobRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+func (c *Client) CancelJob(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelJobRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
+func (c *Client) PrintImageWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrintImageRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+func (c *Client) PrintImage(ctx context.Context, body PrintImageJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrintImageRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
-
-	if m == nil {
-		m = http.NewServeMux()
+func (c *Client) GetPrinterEvents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPrinterEventsRequest(c.Server)
+	if err != nil {
+		return nil, err
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+func (c *Client) GetPrinterInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPrinterInfoRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	m.HandleFunc("GET "+options.BaseURL+"/font", wrapper.ListFont)
-	m.HandleFunc("POST "+options.BaseURL+"/printer", wrapper.PrintImage)
-	m.HandleFunc("GET "+options.BaseURL+"/printer/info", wrapper.GetPrinterInfo)
-	m.HandleFunc("GET "+options.BaseURL+"/template", wrapper.ListTemplate)
-	m.HandleFunc("GET "+options.BaseURL+"/template/{uuid}", wrapper.GetTemplate)
-	m.HandleFunc("PUT "+options.BaseURL+"/template/{uuid}", wrapper.CreateOrUpdateTemplate)
-	m.HandleFunc("POST "+options.BaseURL+"/template/{uuid}/print", wrapper.PrintTemplate)
+func (c *Client) ListTemplate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTemplateRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return m
+func (c *Client) PreviewUnsavedTemplateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewUnsavedTemplateRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type ListFontRequestObject struct {
+func (c *Client) PreviewUnsavedTemplate(ctx context.Context, body PreviewUnsavedTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewUnsavedTemplateRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type ListFontResponseObject interface {
-	VisitListFontResponse(w http.ResponseWriter) error
+func (c *Client) DeleteTemplate(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTemplateRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type ListFont200JSONResponse []Font
+func (c *Client) GetTemplate(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTemplateRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-func (response ListFont200JSONResponse) VisitListFontResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (c *Client) RenameTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameTemplateRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (c *Client) RenameTemplate(ctx context.Context, uuid Uuid, body RenameTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameTemplateRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PrintImageRequestObject struct {
-	Body *PrintImageJSONRequestBody
+func (c *Client) CreateOrUpdateTemplateWithBody(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrUpdateTemplateRequestWithBody(c.Server, uuid, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PrintImageResponseObject interface {
-	VisitPrintImageResponse(w http.ResponseWriter) error
+func (c *Client) CreateOrUpdateTemplate(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, body CreateOrUpdateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrUpdateTemplateRequest(c.Server, uuid, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PrintImage202Response struct {
+func (c *Client) PrintTemplateBatchWithBody(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrintTemplateBatchRequestWithBody(c.Server, uuid, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (response PrintImage202Response) VisitPrintImageResponse(w http.ResponseWriter) error {
-	w.WriteHeader(202)
-	return nil
+func (c *Client) PrintTemplateBatch(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, body PrintTemplateBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrintTemplateBatchRequest(c.Server, uuid, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PrintImage422Response struct {
+func (c *Client) DuplicateTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDuplicateTemplateRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (response PrintImage422Response) VisitPrintImageResponse(w http.ResponseWriter) error {
-	w.WriteHeader(422)
-	return nil
+func (c *Client) DuplicateTemplate(ctx context.Context, uuid Uuid, body DuplicateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDuplicateTemplateRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PrintImage503Response struct {
+func (c *Client) PreviewTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewTemplateRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (response PrintImage503Response) VisitPrintImageResponse(w http.ResponseWriter) error {
-	w.WriteHeader(503)
-	return nil
+func (c *Client) PreviewTemplate(ctx context.Context, uuid Uuid, body PreviewTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewTemplateRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetPrinterInfoRequestObject struct {
+func (c *Client) PrintTemplateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrintTemplateRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetPrinterInfoResponseObject interface {
-	VisitGetPrinterInfoResponse(w http.ResponseWriter) error
+func (c *Client) PrintTemplate(ctx context.Context, uuid Uuid, body PrintTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrintTemplateRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTemplateRevisions(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTemplateRevisionsRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTemplateRevision(ctx context.Context, uuid Uuid, revision Revision, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTemplateRevisionRequest(c.Server, uuid, revision)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PreviewTemplateRevisionWithBody(ctx context.Context, uuid Uuid, revision Revision, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewTemplateRevisionRequestWithBody(c.Server, uuid, revision, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PreviewTemplateRevision(ctx context.Context, uuid Uuid, revision Revision, body PreviewTemplateRevisionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewTemplateRevisionRequest(c.Server, uuid, revision, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RestoreTemplateRevision(ctx context.Context, uuid Uuid, revision Revision, params *RestoreTemplateRevisionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreTemplateRevisionRequest(c.Server, uuid, revision, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetBatchRequest generates requests for GetBatch
+func NewGetBatchRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/batch/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListFontRequest generates requests for ListFont
+func NewListFontRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/font")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUploadFontRequestWithBody generates requests for UploadFont with any type of body
+func NewUploadFontRequestWithBody(server string, params *UploadFontParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/font")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Name != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "name", runtime.ParamLocationQuery, *params.Name); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteFontRequest generates requests for DeleteFont
+func NewDeleteFontRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/font/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRenameFontRequest calls the generic RenameFont builder with application/json body
+func NewRenameFontRequest(server string, uuid Uuid, body RenameFontJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRenameFontRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewRenameFontRequestWithBody generates requests for RenameFont with any type of body
+func NewRenameFontRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/font/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListHistoryRequest generates requests for ListHistory
+func NewListHistoryRequest(server string, params *ListHistoryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Source != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "source", runtime.ParamLocationQuery, *params.Source); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TemplateUuid != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "templateUuid", runtime.ParamLocationQuery, *params.TemplateUuid); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Outcome != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "outcome", runtime.ParamLocationQuery, *params.Outcome); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHistoryRequest generates requests for GetHistory
+func NewGetHistoryRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/history/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReprintRequest generates requests for Reprint
+func NewReprintRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/history/%s/reprint", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHistoryThumbnailRequest generates requests for GetHistoryThumbnail
+func NewGetHistoryThumbnailRequest(server string, uuid Uuid, params *GetHistoryThumbnailParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/history/%s/thumbnail", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Width != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "width", runtime.ParamLocationQuery, *params.Width); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListJobRequest generates requests for ListJob
+func NewListJobRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/job")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetJobRequest generates requests for GetJob
+func NewGetJobRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/job/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCancelJobRequest generates requests for CancelJob
+func NewCancelJobRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/job/%s/cancel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPrintImageRequest calls the generic PrintImage builder with application/json body
+func NewPrintImageRequest(server string, body PrintImageJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPrintImageRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPrintImageRequestWithBody generates requests for PrintImage with any type of body
+func NewPrintImageRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/printer")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPrinterEventsRequest generates requests for GetPrinterEvents
+func NewGetPrinterEventsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/printer/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPrinterInfoRequest generates requests for GetPrinterInfo
+func NewGetPrinterInfoRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/printer/info")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTemplateRequest generates requests for ListTemplate
+func NewListTemplateRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPreviewUnsavedTemplateRequest calls the generic PreviewUnsavedTemplate builder with application/json body
+func NewPreviewUnsavedTemplateRequest(server string, body PreviewUnsavedTemplateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPreviewUnsavedTemplateRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPreviewUnsavedTemplateRequestWithBody generates requests for PreviewUnsavedTemplate with any type of body
+func NewPreviewUnsavedTemplateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/preview")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTemplateRequest generates requests for DeleteTemplate
+func NewDeleteTemplateRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTemplateRequest generates requests for GetTemplate
+func NewGetTemplateRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRenameTemplateRequest calls the generic RenameTemplate builder with application/json body
+func NewRenameTemplateRequest(server string, uuid Uuid, body RenameTemplateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRenameTemplateRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewRenameTemplateRequestWithBody generates requests for RenameTemplate with any type of body
+func NewRenameTemplateRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateOrUpdateTemplateRequest calls the generic CreateOrUpdateTemplate builder with application/json body
+func NewCreateOrUpdateTemplateRequest(server string, uuid Uuid, params *CreateOrUpdateTemplateParams, body CreateOrUpdateTemplateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateOrUpdateTemplateRequestWithBody(server, uuid, params, "application/json", bodyReader)
+}
+
+// NewCreateOrUpdateTemplateRequestWithBody generates requests for CreateOrUpdateTemplate with any type of body
+func NewCreateOrUpdateTemplateRequestWithBody(server string, uuid Uuid, params *CreateOrUpdateTemplateParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Author != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Note != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "note", runtime.ParamLocationQuery, *params.Note); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPrintTemplateBatchRequest calls the generic PrintTemplateBatch builder with application/json body
+func NewPrintTemplateBatchRequest(server string, uuid Uuid, params *PrintTemplateBatchParams, body PrintTemplateBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPrintTemplateBatchRequestWithBody(server, uuid, params, "application/json", bodyReader)
+}
+
+// NewPrintTemplateBatchRequestWithBody generates requests for PrintTemplateBatch with any type of body
+func NewPrintTemplateBatchRequestWithBody(server string, uuid Uuid, params *PrintTemplateBatchParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/batch", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.DryRun != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dryRun", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Copies != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "copies", runtime.ParamLocationQuery, *params.Copies); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDuplicateTemplateRequest calls the generic DuplicateTemplate builder with application/json body
+func NewDuplicateTemplateRequest(server string, uuid Uuid, body DuplicateTemplateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDuplicateTemplateRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewDuplicateTemplateRequestWithBody generates requests for DuplicateTemplate with any type of body
+func NewDuplicateTemplateRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/duplicate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPreviewTemplateRequest calls the generic PreviewTemplate builder with application/json body
+func NewPreviewTemplateRequest(server string, uuid Uuid, body PreviewTemplateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPreviewTemplateRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewPreviewTemplateRequestWithBody generates requests for PreviewTemplate with any type of body
+func NewPreviewTemplateRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/preview", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPrintTemplateRequest calls the generic PrintTemplate builder with application/json body
+func NewPrintTemplateRequest(server string, uuid Uuid, body PrintTemplateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPrintTemplateRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewPrintTemplateRequestWithBody generates requests for PrintTemplate with any type of body
+func NewPrintTemplateRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/print", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListTemplateRevisionsRequest generates requests for ListTemplateRevisions
+func NewListTemplateRevisionsRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/revision", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTemplateRevisionRequest generates requests for GetTemplateRevision
+func NewGetTemplateRevisionRequest(server string, uuid Uuid, revision Revision) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "revision", runtime.ParamLocationPath, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/revision/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPreviewTemplateRevisionRequest calls the generic PreviewTemplateRevision builder with application/json body
+func NewPreviewTemplateRevisionRequest(server string, uuid Uuid, revision Revision, body PreviewTemplateRevisionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPreviewTemplateRevisionRequestWithBody(server, uuid, revision, "application/json", bodyReader)
+}
+
+// NewPreviewTemplateRevisionRequestWithBody generates requests for PreviewTemplateRevision with any type of body
+func NewPreviewTemplateRevisionRequestWithBody(server string, uuid Uuid, revision Revision, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "revision", runtime.ParamLocationPath, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/revision/%s/preview", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRestoreTemplateRevisionRequest generates requests for RestoreTemplateRevision
+func NewRestoreTemplateRevisionRequest(server string, uuid Uuid, revision Revision, params *RestoreTemplateRevisionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "revision", runtime.ParamLocationPath, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/template/%s/revision/%s/restore", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Author != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Note != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "note", runtime.ParamLocationQuery, *params.Note); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetBatchWithResponse request
+	GetBatchWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetBatchResponse, error)
+
+	// ListFontWithResponse request
+	ListFontWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListFontResponse, error)
+
+	// UploadFontWithBodyWithResponse request with any body
+	UploadFontWithBodyWithResponse(ctx context.Context, params *UploadFontParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadFontResponse, error)
+
+	// DeleteFontWithResponse request
+	DeleteFontWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*DeleteFontResponse, error)
+
+	// RenameFontWithBodyWithResponse request with any body
+	RenameFontWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameFontResponse, error)
+
+	RenameFontWithResponse(ctx context.Context, uuid Uuid, body RenameFontJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameFontResponse, error)
+
+	// ListHistoryWithResponse request
+	ListHistoryWithResponse(ctx context.Context, params *ListHistoryParams, reqEditors ...RequestEditorFn) (*ListHistoryResponse, error)
+
+	// GetHistoryWithResponse request
+	GetHistoryWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetHistoryResponse, error)
+
+	// ReprintWithResponse request
+	ReprintWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*ReprintResponse, error)
+
+	// GetHistoryThumbnailWithResponse request
+	GetHistoryThumbnailWithResponse(ctx context.Context, uuid Uuid, params *GetHistoryThumbnailParams, reqEditors ...RequestEditorFn) (*GetHistoryThumbnailResponse, error)
+
+	// ListJobWithResponse request
+	ListJobWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListJobResponse, error)
+
+	// GetJobWithResponse request
+	GetJobWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetJobResponse, error)
+
+	// CancelJobWithResponse request
+	CancelJobWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*CancelJobResponse, error)
+
+	// PrintImageWithBodyWithResponse request with any body
+	PrintImageWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrintImageResponse, error)
+
+	PrintImageWithResponse(ctx context.Context, body PrintImageJSONRequestBody, reqEditors ...RequestEditorFn) (*PrintImageResponse, error)
+
+	// GetPrinterEventsWithResponse request
+	GetPrinterEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPrinterEventsResponse, error)
+
+	// GetPrinterInfoWithResponse request
+	GetPrinterInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPrinterInfoResponse, error)
+
+	// ListTemplateWithResponse request
+	ListTemplateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTemplateResponse, error)
+
+	// PreviewUnsavedTemplateWithBodyWithResponse request with any body
+	PreviewUnsavedTemplateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewUnsavedTemplateResponse, error)
+
+	PreviewUnsavedTemplateWithResponse(ctx context.Context, body PreviewUnsavedTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewUnsavedTemplateResponse, error)
+
+	// DeleteTemplateWithResponse request
+	DeleteTemplateWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*DeleteTemplateResponse, error)
+
+	// GetTemplateWithResponse request
+	GetTemplateWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTemplateResponse, error)
+
+	// RenameTemplateWithBodyWithResponse request with any body
+	RenameTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameTemplateResponse, error)
+
+	RenameTemplateWithResponse(ctx context.Context, uuid Uuid, body RenameTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameTemplateResponse, error)
+
+	// CreateOrUpdateTemplateWithBodyWithResponse request with any body
+	CreateOrUpdateTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrUpdateTemplateResponse, error)
+
+	CreateOrUpdateTemplateWithResponse(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, body CreateOrUpdateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrUpdateTemplateResponse, error)
+
+	// PrintTemplateBatchWithBodyWithResponse request with any body
+	PrintTemplateBatchWithBodyWithResponse(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrintTemplateBatchResponse, error)
+
+	PrintTemplateBatchWithResponse(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, body PrintTemplateBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PrintTemplateBatchResponse, error)
+
+	// DuplicateTemplateWithBodyWithResponse request with any body
+	DuplicateTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DuplicateTemplateResponse, error)
+
+	DuplicateTemplateWithResponse(ctx context.Context, uuid Uuid, body DuplicateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*DuplicateTemplateResponse, error)
+
+	// PreviewTemplateWithBodyWithResponse request with any body
+	PreviewTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewTemplateResponse, error)
+
+	PreviewTemplateWithResponse(ctx context.Context, uuid Uuid, body PreviewTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewTemplateResponse, error)
+
+	// PrintTemplateWithBodyWithResponse request with any body
+	PrintTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrintTemplateResponse, error)
+
+	PrintTemplateWithResponse(ctx context.Context, uuid Uuid, body PrintTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PrintTemplateResponse, error)
+
+	// ListTemplateRevisionsWithResponse request
+	ListTemplateRevisionsWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*ListTemplateRevisionsResponse, error)
+
+	// GetTemplateRevisionWithResponse request
+	GetTemplateRevisionWithResponse(ctx context.Context, uuid Uuid, revision Revision, reqEditors ...RequestEditorFn) (*GetTemplateRevisionResponse, error)
+
+	// PreviewTemplateRevisionWithBodyWithResponse request with any body
+	PreviewTemplateRevisionWithBodyWithResponse(ctx context.Context, uuid Uuid, revision Revision, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewTemplateRevisionResponse, error)
+
+	PreviewTemplateRevisionWithResponse(ctx context.Context, uuid Uuid, revision Revision, body PreviewTemplateRevisionJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewTemplateRevisionResponse, error)
+
+	// RestoreTemplateRevisionWithResponse request
+	RestoreTemplateRevisionWithResponse(ctx context.Context, uuid Uuid, revision Revision, params *RestoreTemplateRevisionParams, reqEditors ...RequestEditorFn) (*RestoreTemplateRevisionResponse, error)
+}
+
+type GetBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BatchProgress
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListFontResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Font
+}
+
+// Status returns HTTPResponse.Status
+func (r ListFontResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListFontResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadFontResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Font
+	JSON422      *struct {
+		Reason string `json:"reason"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadFontResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadFontResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFontResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON409      *FontInUse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFontResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFontResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RenameFontResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Font
+}
+
+// Status returns HTTPResponse.Status
+func (r RenameFontResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RenameFontResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]HistoryEntry
+}
+
+// Status returns HTTPResponse.Status
+func (r ListHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HistoryEntry
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReprintResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *PrintJob
+}
+
+// Status returns HTTPResponse.Status
+func (r ReprintResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReprintResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHistoryThumbnailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHistoryThumbnailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHistoryThumbnailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PrintJob
+}
+
+// Status returns HTTPResponse.Status
+func (r ListJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PrintJob
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PrintJob
+	JSON409      *PrintJob
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PrintImageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *PrintJob
+}
+
+// Status returns HTTPResponse.Status
+func (r PrintImageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PrintImageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPrinterEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPrinterEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPrinterEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPrinterInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeviceInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPrinterInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPrinterInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Template
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PreviewUnsavedTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON422      *InvalidParameters
+}
+
+// Status returns HTTPResponse.Status
+func (r PreviewUnsavedTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PreviewUnsavedTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Template
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RenameTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Template
+}
+
+// Status returns HTTPResponse.Status
+func (r RenameTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RenameTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateOrUpdateTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Uuid
+	JSON201      *Uuid
+	JSON400      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateOrUpdateTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateOrUpdateTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PrintTemplateBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PrintBatch
+	JSON202      *PrintBatch
+	JSON400      *struct {
+		Reason string `json:"reason"`
+	}
+	JSON422 *struct {
+		Rows []BatchRowError `json:"rows"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PrintTemplateBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PrintTemplateBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DuplicateTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Template
+}
+
+// Status returns HTTPResponse.Status
+func (r DuplicateTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DuplicateTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PreviewTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON422      *InvalidParameters
+}
+
+// Status returns HTTPResponse.Status
+func (r PreviewTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PreviewTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PrintTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *PrintJob
+	JSON422      *InvalidParameters
+}
+
+// Status returns HTTPResponse.Status
+func (r PrintTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PrintTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTemplateRevisionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]TemplateRevision
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTemplateRevisionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTemplateRevisionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTemplateRevisionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TemplateRevision
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTemplateRevisionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTemplateRevisionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PreviewTemplateRevisionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON422      *InvalidParameters
+}
+
+// Status returns HTTPResponse.Status
+func (r PreviewTemplateRevisionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PreviewTemplateRevisionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RestoreTemplateRevisionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Template
+	JSON409      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r RestoreTemplateRevisionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestoreTemplateRevisionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetBatchWithResponse request returning *GetBatchResponse
+func (c *ClientWithResponses) GetBatchWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetBatchResponse, error) {
+	rsp, err := c.GetBatch(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBatchResponse(rsp)
+}
+
+// ListFontWithResponse request returning *ListFontResponse
+func (c *ClientWithResponses) ListFontWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListFontResponse, error) {
+	rsp, err := c.ListFont(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListFontResponse(rsp)
+}
+
+// UploadFontWithBodyWithResponse request with arbitrary body returning *UploadFontResponse
+func (c *ClientWithResponses) UploadFontWithBodyWithResponse(ctx context.Context, params *UploadFontParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadFontResponse, error) {
+	rsp, err := c.UploadFontWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadFontResponse(rsp)
+}
+
+// DeleteFontWithResponse request returning *DeleteFontResponse
+func (c *ClientWithResponses) DeleteFontWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*DeleteFontResponse, error) {
+	rsp, err := c.DeleteFont(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFontResponse(rsp)
+}
+
+// RenameFontWithBodyWithResponse request with arbitrary body returning *RenameFontResponse
+func (c *ClientWithResponses) RenameFontWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameFontResponse, error) {
+	rsp, err := c.RenameFontWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameFontResponse(rsp)
+}
+
+func (c *ClientWithResponses) RenameFontWithResponse(ctx context.Context, uuid Uuid, body RenameFontJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameFontResponse, error) {
+	rsp, err := c.RenameFont(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameFontResponse(rsp)
+}
+
+// ListHistoryWithResponse request returning *ListHistoryResponse
+func (c *ClientWithResponses) ListHistoryWithResponse(ctx context.Context, params *ListHistoryParams, reqEditors ...RequestEditorFn) (*ListHistoryResponse, error) {
+	rsp, err := c.ListHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListHistoryResponse(rsp)
+}
+
+// GetHistoryWithResponse request returning *GetHistoryResponse
+func (c *ClientWithResponses) GetHistoryWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetHistoryResponse, error) {
+	rsp, err := c.GetHistory(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHistoryResponse(rsp)
+}
+
+// ReprintWithResponse request returning *ReprintResponse
+func (c *ClientWithResponses) ReprintWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*ReprintResponse, error) {
+	rsp, err := c.Reprint(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprintResponse(rsp)
+}
+
+// GetHistoryThumbnailWithResponse request returning *GetHistoryThumbnailResponse
+func (c *ClientWithResponses) GetHistoryThumbnailWithResponse(ctx context.Context, uuid Uuid, params *GetHistoryThumbnailParams, reqEditors ...RequestEditorFn) (*GetHistoryThumbnailResponse, error) {
+	rsp, err := c.GetHistoryThumbnail(ctx, uuid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHistoryThumbnailResponse(rsp)
+}
+
+// ListJobWithResponse request returning *ListJobResponse
+func (c *ClientWithResponses) ListJobWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListJobResponse, error) {
+	rsp, err := c.ListJob(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListJobResponse(rsp)
+}
+
+// GetJobWithResponse request returning *GetJobResponse
+func (c *ClientWithResponses) GetJobWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetJobResponse, error) {
+	rsp, err := c.GetJob(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJobResponse(rsp)
+}
+
+// CancelJobWithResponse request returning *CancelJobResponse
+func (c *ClientWithResponses) CancelJobWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*CancelJobResponse, error) {
+	rsp, err := c.CancelJob(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelJobResponse(rsp)
+}
+
+// PrintImageWithBodyWithResponse request with arbitrary body returning *PrintImageResponse
+func (c *ClientWithResponses) PrintImageWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrintImageResponse, error) {
+	rsp, err := c.PrintImageWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrintImageResponse(rsp)
+}
+
+func (c *ClientWithResponses) PrintImageWithResponse(ctx context.Context, body PrintImageJSONRequestBody, reqEditors ...RequestEditorFn) (*PrintImageResponse, error) {
+	rsp, err := c.PrintImage(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrintImageResponse(rsp)
+}
+
+// GetPrinterEventsWithResponse request returning *GetPrinterEventsResponse
+func (c *ClientWithResponses) GetPrinterEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPrinterEventsResponse, error) {
+	rsp, err := c.GetPrinterEvents(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPrinterEventsResponse(rsp)
+}
+
+// GetPrinterInfoWithResponse request returning *GetPrinterInfoResponse
+func (c *ClientWithResponses) GetPrinterInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPrinterInfoResponse, error) {
+	rsp, err := c.GetPrinterInfo(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPrinterInfoResponse(rsp)
+}
+
+// ListTemplateWithResponse request returning *ListTemplateResponse
+func (c *ClientWithResponses) ListTemplateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTemplateResponse, error) {
+	rsp, err := c.ListTemplate(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTemplateResponse(rsp)
+}
+
+// PreviewUnsavedTemplateWithBodyWithResponse request with arbitrary body returning *PreviewUnsavedTemplateResponse
+func (c *ClientWithResponses) PreviewUnsavedTemplateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewUnsavedTemplateResponse, error) {
+	rsp, err := c.PreviewUnsavedTemplateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewUnsavedTemplateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PreviewUnsavedTemplateWithResponse(ctx context.Context, body PreviewUnsavedTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewUnsavedTemplateResponse, error) {
+	rsp, err := c.PreviewUnsavedTemplate(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewUnsavedTemplateResponse(rsp)
+}
+
+// DeleteTemplateWithResponse request returning *DeleteTemplateResponse
+func (c *ClientWithResponses) DeleteTemplateWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*DeleteTemplateResponse, error) {
+	rsp, err := c.DeleteTemplate(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTemplateResponse(rsp)
+}
+
+// GetTemplateWithResponse request returning *GetTemplateResponse
+func (c *ClientWithResponses) GetTemplateWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTemplateResponse, error) {
+	rsp, err := c.GetTemplate(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTemplateResponse(rsp)
+}
+
+// RenameTemplateWithBodyWithResponse request with arbitrary body returning *RenameTemplateResponse
+func (c *ClientWithResponses) RenameTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameTemplateResponse, error) {
+	rsp, err := c.RenameTemplateWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameTemplateResponse(rsp)
+}
+
+func (c *ClientWithResponses) RenameTemplateWithResponse(ctx context.Context, uuid Uuid, body RenameTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameTemplateResponse, error) {
+	rsp, err := c.RenameTemplate(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameTemplateResponse(rsp)
+}
+
+// CreateOrUpdateTemplateWithBodyWithResponse request with arbitrary body returning *CreateOrUpdateTemplateResponse
+func (c *ClientWithResponses) CreateOrUpdateTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrUpdateTemplateResponse, error) {
+	rsp, err := c.CreateOrUpdateTemplateWithBody(ctx, uuid, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrUpdateTemplateResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateOrUpdateTemplateWithResponse(ctx context.Context, uuid Uuid, params *CreateOrUpdateTemplateParams, body CreateOrUpdateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrUpdateTemplateResponse, error) {
+	rsp, err := c.CreateOrUpdateTemplate(ctx, uuid, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrUpdateTemplateResponse(rsp)
+}
+
+// PrintTemplateBatchWithBodyWithResponse request with arbitrary body returning *PrintTemplateBatchResponse
+func (c *ClientWithResponses) PrintTemplateBatchWithBodyWithResponse(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrintTemplateBatchResponse, error) {
+	rsp, err := c.PrintTemplateBatchWithBody(ctx, uuid, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrintTemplateBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PrintTemplateBatchWithResponse(ctx context.Context, uuid Uuid, params *PrintTemplateBatchParams, body PrintTemplateBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PrintTemplateBatchResponse, error) {
+	rsp, err := c.PrintTemplateBatch(ctx, uuid, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrintTemplateBatchResponse(rsp)
+}
+
+// DuplicateTemplateWithBodyWithResponse request with arbitrary body returning *DuplicateTemplateResponse
+func (c *ClientWithResponses) DuplicateTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DuplicateTemplateResponse, error) {
+	rsp, err := c.DuplicateTemplateWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDuplicateTemplateResponse(rsp)
+}
+
+func (c *ClientWithResponses) DuplicateTemplateWithResponse(ctx context.Context, uuid Uuid, body DuplicateTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*DuplicateTemplateResponse, error) {
+	rsp, err := c.DuplicateTemplate(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDuplicateTemplateResponse(rsp)
+}
+
+// PreviewTemplateWithBodyWithResponse request with arbitrary body returning *PreviewTemplateResponse
+func (c *ClientWithResponses) PreviewTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewTemplateResponse, error) {
+	rsp, err := c.PreviewTemplateWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewTemplateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PreviewTemplateWithResponse(ctx context.Context, uuid Uuid, body PreviewTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewTemplateResponse, error) {
+	rsp, err := c.PreviewTemplate(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewTemplateResponse(rsp)
+}
+
+// PrintTemplateWithBodyWithResponse request with arbitrary body returning *PrintTemplateResponse
+func (c *ClientWithResponses) PrintTemplateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrintTemplateResponse, error) {
+	rsp, err := c.PrintTemplateWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrintTemplateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PrintTemplateWithResponse(ctx context.Context, uuid Uuid, body PrintTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PrintTemplateResponse, error) {
+	rsp, err := c.PrintTemplate(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrintTemplateResponse(rsp)
+}
+
+// ListTemplateRevisionsWithResponse request returning *ListTemplateRevisionsResponse
+func (c *ClientWithResponses) ListTemplateRevisionsWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*ListTemplateRevisionsResponse, error) {
+	rsp, err := c.ListTemplateRevisions(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTemplateRevisionsResponse(rsp)
+}
+
+// GetTemplateRevisionWithResponse request returning *GetTemplateRevisionResponse
+func (c *ClientWithResponses) GetTemplateRevisionWithResponse(ctx context.Context, uuid Uuid, revision Revision, reqEditors ...RequestEditorFn) (*GetTemplateRevisionResponse, error) {
+	rsp, err := c.GetTemplateRevision(ctx, uuid, revision, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTemplateRevisionResponse(rsp)
+}
+
+// PreviewTemplateRevisionWithBodyWithResponse request with arbitrary body returning *PreviewTemplateRevisionResponse
+func (c *ClientWithResponses) PreviewTemplateRevisionWithBodyWithResponse(ctx context.Context, uuid Uuid, revision Revision, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewTemplateRevisionResponse, error) {
+	rsp, err := c.PreviewTemplateRevisionWithBody(ctx, uuid, revision, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewTemplateRevisionResponse(rsp)
+}
+
+func (c *ClientWithResponses) PreviewTemplateRevisionWithResponse(ctx context.Context, uuid Uuid, revision Revision, body PreviewTemplateRevisionJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewTemplateRevisionResponse, error) {
+	rsp, err := c.PreviewTemplateRevision(ctx, uuid, revision, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewTemplateRevisionResponse(rsp)
+}
+
+// RestoreTemplateRevisionWithResponse request returning *RestoreTemplateRevisionResponse
+func (c *ClientWithResponses) RestoreTemplateRevisionWithResponse(ctx context.Context, uuid Uuid, revision Revision, params *RestoreTemplateRevisionParams, reqEditors ...RequestEditorFn) (*RestoreTemplateRevisionResponse, error) {
+	rsp, err := c.RestoreTemplateRevision(ctx, uuid, revision, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreTemplateRevisionResponse(rsp)
+}
+
+// ParseGetBatchResponse parses an HTTP response from a GetBatchWithResponse call
+func ParseGetBatchResponse(rsp *http.Response) (*GetBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BatchProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListFontResponse parses an HTTP response from a ListFontWithResponse call
+func ParseListFontResponse(rsp *http.Response) (*ListFontResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListFontResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Font
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadFontResponse parses an HTTP response from a UploadFontWithResponse call
+func ParseUploadFontResponse(rsp *http.Response) (*UploadFontResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadFontResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Font
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFontResponse parses an HTTP response from a DeleteFontWithResponse call
+func ParseDeleteFontResponse(rsp *http.Response) (*DeleteFontResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFontResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest FontInUse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRenameFontResponse parses an HTTP response from a RenameFontWithResponse call
+func ParseRenameFontResponse(rsp *http.Response) (*RenameFontResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RenameFontResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Font
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListHistoryResponse parses an HTTP response from a ListHistoryWithResponse call
+func ParseListHistoryResponse(rsp *http.Response) (*ListHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []HistoryEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHistoryResponse parses an HTTP response from a GetHistoryWithResponse call
+func ParseGetHistoryResponse(rsp *http.Response) (*GetHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HistoryEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReprintResponse parses an HTTP response from a ReprintWithResponse call
+func ParseReprintResponse(rsp *http.Response) (*ReprintResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReprintResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PrintJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHistoryThumbnailResponse parses an HTTP response from a GetHistoryThumbnailWithResponse call
+func ParseGetHistoryThumbnailResponse(rsp *http.Response) (*GetHistoryThumbnailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHistoryThumbnailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListJobResponse parses an HTTP response from a ListJobWithResponse call
+func ParseListJobResponse(rsp *http.Response) (*ListJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PrintJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetJobResponse parses an HTTP response from a GetJobWithResponse call
+func ParseGetJobResponse(rsp *http.Response) (*GetJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PrintJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelJobResponse parses an HTTP response from a CancelJobWithResponse call
+func ParseCancelJobResponse(rsp *http.Response) (*CancelJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PrintJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest PrintJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePrintImageResponse parses an HTTP response from a PrintImageWithResponse call
+func ParsePrintImageResponse(rsp *http.Response) (*PrintImageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PrintImageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PrintJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPrinterEventsResponse parses an HTTP response from a GetPrinterEventsWithResponse call
+func ParseGetPrinterEventsResponse(rsp *http.Response) (*GetPrinterEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPrinterEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetPrinterInfoResponse parses an HTTP response from a GetPrinterInfoWithResponse call
+func ParseGetPrinterInfoResponse(rsp *http.Response) (*GetPrinterInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPrinterInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeviceInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTemplateResponse parses an HTTP response from a ListTemplateWithResponse call
+func ParseListTemplateResponse(rsp *http.Response) (*ListTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Template
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePreviewUnsavedTemplateResponse parses an HTTP response from a PreviewUnsavedTemplateWithResponse call
+func ParsePreviewUnsavedTemplateResponse(rsp *http.Response) (*PreviewUnsavedTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PreviewUnsavedTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest InvalidParameters
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTemplateResponse parses an HTTP response from a DeleteTemplateWithResponse call
+func ParseDeleteTemplateResponse(rsp *http.Response) (*DeleteTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetTemplateResponse parses an HTTP response from a GetTemplateWithResponse call
+func ParseGetTemplateResponse(rsp *http.Response) (*GetTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Template
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRenameTemplateResponse parses an HTTP response from a RenameTemplateWithResponse call
+func ParseRenameTemplateResponse(rsp *http.Response) (*RenameTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RenameTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Template
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateOrUpdateTemplateResponse parses an HTTP response from a CreateOrUpdateTemplateWithResponse call
+func ParseCreateOrUpdateTemplateResponse(rsp *http.Response) (*CreateOrUpdateTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateOrUpdateTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Uuid
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Uuid
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePrintTemplateBatchResponse parses an HTTP response from a PrintTemplateBatchWithResponse call
+func ParsePrintTemplateBatchResponse(rsp *http.Response) (*PrintTemplateBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PrintTemplateBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PrintBatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PrintBatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest struct {
+			Rows []BatchRowError `json:"rows"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDuplicateTemplateResponse parses an HTTP response from a DuplicateTemplateWithResponse call
+func ParseDuplicateTemplateResponse(rsp *http.Response) (*DuplicateTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DuplicateTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Template
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePreviewTemplateResponse parses an HTTP response from a PreviewTemplateWithResponse call
+func ParsePreviewTemplateResponse(rsp *http.Response) (*PreviewTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PreviewTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest InvalidParameters
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePrintTemplateResponse parses an HTTP response from a PrintTemplateWithResponse call
+func ParsePrintTemplateResponse(rsp *http.Response) (*PrintTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PrintTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PrintJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest InvalidParameters
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTemplateRevisionsResponse parses an HTTP response from a ListTemplateRevisionsWithResponse call
+func ParseListTemplateRevisionsResponse(rsp *http.Response) (*ListTemplateRevisionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTemplateRevisionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []TemplateRevision
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTemplateRevisionResponse parses an HTTP response from a GetTemplateRevisionWithResponse call
+func ParseGetTemplateRevisionResponse(rsp *http.Response) (*GetTemplateRevisionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTemplateRevisionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TemplateRevision
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePreviewTemplateRevisionResponse parses an HTTP response from a PreviewTemplateRevisionWithResponse call
+func ParsePreviewTemplateRevisionResponse(rsp *http.Response) (*PreviewTemplateRevisionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PreviewTemplateRevisionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest InvalidParameters
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRestoreTemplateRevisionResponse parses an HTTP response from a RestoreTemplateRevisionWithResponse call
+func ParseRestoreTemplateRevisionResponse(rsp *http.Response) (*RestoreTemplateRevisionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreTemplateRevisionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Template
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the progress of a batch of print jobs
+	// (GET /batch/{uuid})
+	GetBatch(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// List fonts
+	// (GET /font)
+	ListFont(w http.ResponseWriter, r *http.Request)
+	// Upload a TrueType or OpenType font
+	// (POST /font)
+	UploadFont(w http.ResponseWriter, r *http.Request, params UploadFontParams)
+	// Delete an uploaded font
+	// (DELETE /font/{uuid})
+	DeleteFont(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Rename a font
+	// (PATCH /font/{uuid})
+	RenameFont(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// List the labels which have been printed, newest first
+	// (GET /history)
+	ListHistory(w http.ResponseWriter, r *http.Request, params ListHistoryParams)
+	// Get an entry from the print history
+	// (GET /history/{uuid})
+	GetHistory(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Print a label again exactly as it was printed
+	// (POST /history/{uuid}/reprint)
+	Reprint(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Get a PNG of the bitmap which was printed
+	// (GET /history/{uuid}/thumbnail)
+	GetHistoryThumbnail(w http.ResponseWriter, r *http.Request, uuid Uuid, params GetHistoryThumbnailParams)
+	// List print jobs
+	// (GET /job)
+	ListJob(w http.ResponseWriter, r *http.Request)
+	// Get the status of a print job
+	// (GET /job/{uuid})
+	GetJob(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Cancel a print job
+	// (POST /job/{uuid}/cancel)
+	CancelJob(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Print an image directly
+	// (POST /printer)
+	PrintImage(w http.ResponseWriter, r *http.Request)
+	// Stream of printer events
+	// (GET /printer/events)
+	GetPrinterEvents(w http.ResponseWriter, r *http.Request)
+	// Get device info
+	// (GET /printer/info)
+	GetPrinterInfo(w http.ResponseWriter, r *http.Request)
+	// List templates
+	// (GET /template)
+	ListTemplate(w http.ResponseWriter, r *http.Request)
+	// Preview a template which hasn't been saved, exactly as it would be printed
+	// (POST /template/preview)
+	PreviewUnsavedTemplate(w http.ResponseWriter, r *http.Request)
+	// Delete a template
+	// (DELETE /template/{uuid})
+	DeleteTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Get a single template
+	// (GET /template/{uuid})
+	GetTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Rename a template
+	// (PATCH /template/{uuid})
+	RenameTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Create a new template, or update an existing one
+	// (PUT /template/{uuid})
+	CreateOrUpdateTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid, params CreateOrUpdateTemplateParams)
+	// Print a template once for each row of a CSV file or JSON array
+	// (POST /template/{uuid}/batch)
+	PrintTemplateBatch(w http.ResponseWriter, r *http.Request, uuid Uuid, params PrintTemplateBatchParams)
+	// Copy a template and everything in it under a new UUID
+	// (POST /template/{uuid}/duplicate)
+	DuplicateTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Preview a template exactly as it would be printed
+	// (POST /template/{uuid}/preview)
+	PreviewTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Print a template
+	// (POST /template/{uuid}/print)
+	PrintTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// List a template's revisions, newest first
+	// (GET /template/{uuid}/revision)
+	ListTemplateRevisions(w http.ResponseWriter, r *http.Request, uuid Uuid)
+	// Get a template as it was at one of its revisions
+	// (GET /template/{uuid}/revision/{revision})
+	GetTemplateRevision(w http.ResponseWriter, r *http.Request, uuid Uuid, revision Revision)
+	// Preview a template as it was at one of its revisions
+	// (POST /template/{uuid}/revision/{revision}/preview)
+	PreviewTemplateRevision(w http.ResponseWriter, r *http.Request, uuid Uuid, revision Revision)
+	// Make an earlier revision of a template the current one
+	// (POST /template/{uuid}/revision/{revision}/restore)
+	RestoreTemplateRevision(w http.ResponseWriter, r *http.Request, uuid Uuid, revision Revision, params RestoreTemplateRevisionParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetBatch operation middleware
+func (siw *ServerInterfaceWrapper) GetBatch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBatch(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListFont operation middleware
+func (siw *ServerInterfaceWrapper) ListFont(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFont(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadFont operation middleware
+func (siw *ServerInterfaceWrapper) UploadFont(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UploadFontParams
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadFont(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteFont operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFont(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFont(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameFont operation middleware
+func (siw *ServerInterfaceWrapper) RenameFont(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameFont(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListHistory operation middleware
+func (siw *ServerInterfaceWrapper) ListHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListHistoryParams
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", r.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "templateUuid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "templateUuid", r.URL.Query(), &params.TemplateUuid)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateUuid", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "outcome" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outcome", r.URL.Query(), &params.Outcome)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outcome", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListHistory(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHistory(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Reprint operation middleware
+func (siw *ServerInterfaceWrapper) Reprint(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Reprint(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHistoryThumbnail operation middleware
+func (siw *ServerInterfaceWrapper) GetHistoryThumbnail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHistoryThumbnailParams
+
+	// ------------- Optional query parameter "width" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "width", r.URL.Query(), &params.Width)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "width", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHistoryThumbnail(w, r, uuid, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListJob operation middleware
+func (siw *ServerInterfaceWrapper) ListJob(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListJob(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJob operation middleware
+func (siw *ServerInterfaceWrapper) GetJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJob(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelJob(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PrintImage operation middleware
+func (siw *ServerInterfaceWrapper) PrintImage(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PrintImage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPrinterEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetPrinterEvents(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPrinterEvents(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPrinterInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetPrinterInfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPrinterInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTemplate operation middleware
+func (siw *ServerInterfaceWrapper) ListTemplate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTemplate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewUnsavedTemplate operation middleware
+func (siw *ServerInterfaceWrapper) PreviewUnsavedTemplate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewUnsavedTemplate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTemplate(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTemplate operation middleware
+func (siw *ServerInterfaceWrapper) GetTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTemplate(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameTemplate operation middleware
+func (siw *ServerInterfaceWrapper) RenameTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameTemplate(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateOrUpdateTemplate operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrUpdateTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateOrUpdateTemplateParams
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "note" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "note", r.URL.Query(), &params.Note)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "note", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateOrUpdateTemplate(w, r, uuid, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PrintTemplateBatch operation middleware
+func (siw *ServerInterfaceWrapper) PrintTemplateBatch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PrintTemplateBatchParams
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "copies" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "copies", r.URL.Query(), &params.Copies)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "copies", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PrintTemplateBatch(w, r, uuid, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DuplicateTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DuplicateTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DuplicateTemplate(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewTemplate operation middleware
+func (siw *ServerInterfaceWrapper) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewTemplate(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PrintTemplate operation middleware
+func (siw *ServerInterfaceWrapper) PrintTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PrintTemplate(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTemplateRevisions operation middleware
+func (siw *ServerInterfaceWrapper) ListTemplateRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTemplateRevisions(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTemplateRevision operation middleware
+func (siw *ServerInterfaceWrapper) GetTemplateRevision(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision Revision
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", r.PathValue("revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTemplateRevision(w, r, uuid, revision)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewTemplateRevision operation middleware
+func (siw *ServerInterfaceWrapper) PreviewTemplateRevision(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision Revision
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", r.PathValue("revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewTemplateRevision(w, r, uuid, revision)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreTemplateRevision operation middleware
+func (siw *ServerInterfaceWrapper) RestoreTemplateRevision(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision Revision
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", r.PathValue("revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RestoreTemplateRevisionParams
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "note" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "note", r.URL.Query(), &params.Note)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "note", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreTemplateRevision(w, r, uuid, revision, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/batch/{uuid}", wrapper.GetBatch)
+	m.HandleFunc("GET "+options.BaseURL+"/font", wrapper.ListFont)
+	m.HandleFunc("POST "+options.BaseURL+"/font", wrapper.UploadFont)
+	m.HandleFunc("DELETE "+options.BaseURL+"/font/{uuid}", wrapper.DeleteFont)
+	m.HandleFunc("PATCH "+options.BaseURL+"/font/{uuid}", wrapper.RenameFont)
+	m.HandleFunc("GET "+options.BaseURL+"/history", wrapper.ListHistory)
+	m.HandleFunc("GET "+options.BaseURL+"/history/{uuid}", wrapper.GetHistory)
+	m.HandleFunc("POST "+options.BaseURL+"/history/{uuid}/reprint", wrapper.Reprint)
+	m.HandleFunc("GET "+options.BaseURL+"/history/{uuid}/thumbnail", wrapper.GetHistoryThumbnail)
+	m.HandleFunc("GET "+options.BaseURL+"/job", wrapper.ListJob)
+	m.HandleFunc("GET "+options.BaseURL+"/job/{uuid}", wrapper.GetJob)
+	m.HandleFunc("POST "+options.BaseURL+"/job/{uuid}/cancel", wrapper.CancelJob)
+	m.HandleFunc("POST "+options.BaseURL+"/printer", wrapper.PrintImage)
+	m.HandleFunc("GET "+options.BaseURL+"/printer/events", wrapper.GetPrinterEvents)
+	m.HandleFunc("GET "+options.BaseURL+"/printer/info", wrapper.GetPrinterInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/template", wrapper.ListTemplate)
+	m.HandleFunc("POST "+options.BaseURL+"/template/preview", wrapper.PreviewUnsavedTemplate)
+	m.HandleFunc("DELETE "+options.BaseURL+"/template/{uuid}", wrapper.DeleteTemplate)
+	m.HandleFunc("GET "+options.BaseURL+"/template/{uuid}", wrapper.GetTemplate)
+	m.HandleFunc("PATCH "+options.BaseURL+"/template/{uuid}", wrapper.RenameTemplate)
+	m.HandleFunc("PUT "+options.BaseURL+"/template/{uuid}", wrapper.CreateOrUpdateTemplate)
+	m.HandleFunc("POST "+options.BaseURL+"/template/{uuid}/batch", wrapper.PrintTemplateBatch)
+	m.HandleFunc("POST "+options.BaseURL+"/template/{uuid}/duplicate", wrapper.DuplicateTemplate)
+	m.HandleFunc("POST "+options.BaseURL+"/template/{uuid}/preview", wrapper.PreviewTemplate)
+	m.HandleFunc("POST "+options.BaseURL+"/template/{uuid}/print", wrapper.PrintTemplate)
+	m.HandleFunc("GET "+options.BaseURL+"/template/{uuid}/revision", wrapper.ListTemplateRevisions)
+	m.HandleFunc("GET "+options.BaseURL+"/template/{uuid}/revision/{revision}", wrapper.GetTemplateRevision)
+	m.HandleFunc("POST "+options.BaseURL+"/template/{uuid}/revision/{revision}/preview", wrapper.PreviewTemplateRevision)
+	m.HandleFunc("POST "+options.BaseURL+"/template/{uuid}/revision/{revision}/restore", wrapper.RestoreTemplateRevision)
+
+	return m
+}
+
+type GetBatchRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type GetBatchResponseObject interface {
+	VisitGetBatchResponse(w http.ResponseWriter) error
+}
+
+type GetBatch200JSONResponse BatchProgress
+
+func (response GetBatch200JSONResponse) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatch400Response struct {
+}
+
+func (response GetBatch400Response) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetBatch404Response struct {
+}
+
+func (response GetBatch404Response) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListFontRequestObject struct {
+}
+
+type ListFontResponseObject interface {
+	VisitListFontResponse(w http.ResponseWriter) error
+}
+
+type ListFont200JSONResponse []Font
+
+func (response ListFont200JSONResponse) VisitListFontResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadFontRequestObject struct {
+	Params UploadFontParams
+	Body   io.Reader
+}
+
+type UploadFontResponseObject interface {
+	VisitUploadFontResponse(w http.ResponseWriter) error
+}
+
+type UploadFont201JSONResponse Font
+
+func (response UploadFont201JSONResponse) VisitUploadFontResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadFont422JSONResponse struct {
+	Reason string `json:"reason"`
+}
+
+func (response UploadFont422JSONResponse) VisitUploadFontResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFontRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type DeleteFontResponseObject interface {
+	VisitDeleteFontResponse(w http.ResponseWriter) error
+}
+
+type DeleteFont204Response struct {
+}
+
+func (response DeleteFont204Response) VisitDeleteFontResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFont400Response struct {
+}
+
+func (response DeleteFont400Response) VisitDeleteFontResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteFont404Response struct {
+}
+
+func (response DeleteFont404Response) VisitDeleteFontResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DeleteFont409JSONResponse FontInUse
+
+func (response DeleteFont409JSONResponse) VisitDeleteFontResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameFontRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *RenameFontJSONRequestBody
+}
+
+type RenameFontResponseObject interface {
+	VisitRenameFontResponse(w http.ResponseWriter) error
+}
+
+type RenameFont200JSONResponse Font
+
+func (response RenameFont200JSONResponse) VisitRenameFontResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameFont400Response struct {
+}
+
+func (response RenameFont400Response) VisitRenameFontResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type RenameFont404Response struct {
+}
+
+func (response RenameFont404Response) VisitRenameFontResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListHistoryRequestObject struct {
+	Params ListHistoryParams
+}
+
+type ListHistoryResponseObject interface {
+	VisitListHistoryResponse(w http.ResponseWriter) error
+}
+
+type ListHistory200JSONResponse []HistoryEntry
+
+func (response ListHistory200JSONResponse) VisitListHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListHistory400Response struct {
+}
+
+func (response ListHistory400Response) VisitListHistoryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetHistoryRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type GetHistoryResponseObject interface {
+	VisitGetHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetHistory200JSONResponse HistoryEntry
+
+func (response GetHistory200JSONResponse) VisitGetHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHistory400Response struct {
+}
+
+func (response GetHistory400Response) VisitGetHistoryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetHistory404Response struct {
+}
+
+func (response GetHistory404Response) VisitGetHistoryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ReprintRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type ReprintResponseObject interface {
+	VisitReprintResponse(w http.ResponseWriter) error
+}
+
+type Reprint202JSONResponse PrintJob
+
+func (response Reprint202JSONResponse) VisitReprintResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Reprint400Response struct {
+}
+
+func (response Reprint400Response) VisitReprintResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type Reprint404Response struct {
+}
+
+func (response Reprint404Response) VisitReprintResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetHistoryThumbnailRequestObject struct {
+	Uuid   Uuid `json:"uuid"`
+	Params GetHistoryThumbnailParams
+}
+
+type GetHistoryThumbnailResponseObject interface {
+	VisitGetHistoryThumbnailResponse(w http.ResponseWriter) error
+}
+
+type GetHistoryThumbnail200ImagepngResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetHistoryThumbnail200ImagepngResponse) VisitGetHistoryThumbnailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/png")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetHistoryThumbnail400Response struct {
+}
+
+func (response GetHistoryThumbnail400Response) VisitGetHistoryThumbnailResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetHistoryThumbnail404Response struct {
+}
+
+func (response GetHistoryThumbnail404Response) VisitGetHistoryThumbnailResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListJobRequestObject struct {
+}
+
+type ListJobResponseObject interface {
+	VisitListJobResponse(w http.ResponseWriter) error
+}
+
+type ListJob200JSONResponse []PrintJob
+
+func (response ListJob200JSONResponse) VisitListJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJobRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type GetJobResponseObject interface {
+	VisitGetJobResponse(w http.ResponseWriter) error
+}
+
+type GetJob200JSONResponse PrintJob
+
+func (response GetJob200JSONResponse) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJob400Response struct {
+}
+
+func (response GetJob400Response) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetJob404Response struct {
+}
+
+func (response GetJob404Response) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CancelJobRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type CancelJobResponseObject interface {
+	VisitCancelJobResponse(w http.ResponseWriter) error
+}
+
+type CancelJob200JSONResponse PrintJob
+
+func (response CancelJob200JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelJob400Response struct {
+}
+
+func (response CancelJob400Response) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type CancelJob404Response struct {
+}
+
+func (response CancelJob404Response) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CancelJob409JSONResponse PrintJob
+
+func (response CancelJob409JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrintImageRequestObject struct {
+	Body *PrintImageJSONRequestBody
+}
+
+type PrintImageResponseObject interface {
+	VisitPrintImageResponse(w http.ResponseWriter) error
+}
+
+type PrintImage202JSONResponse PrintJob
+
+func (response PrintImage202JSONResponse) VisitPrintImageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrintImage422Response struct {
+}
+
+func (response PrintImage422Response) VisitPrintImageResponse(w http.ResponseWriter) error {
+	w.WriteHeader(422)
+	return nil
+}
+
+type GetPrinterEventsRequestObject struct {
+}
+
+type GetPrinterEventsResponseObject interface {
+	VisitGetPrinterEventsResponse(w http.ResponseWriter) error
+}
+
+type GetPrinterEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetPrinterEvents200TexteventStreamResponse) VisitGetPrinterEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetPrinterInfoRequestObject struct {
+}
+
+type GetPrinterInfoResponseObject interface {
+	VisitGetPrinterInfoResponse(w http.ResponseWriter) error
 }
 
 type GetPrinterInfo200JSONResponse DeviceInfo
@@ -478,31 +6087,99 @@ func (response GetPrinterInfo200JSONResponse) VisitGetPrinterInfoResponse(w http
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPrinterInfo503Response struct {
+}
+
+func (response GetPrinterInfo503Response) VisitGetPrinterInfoResponse(w http.ResponseWriter) error {
+	w.WriteHeader(503)
+	return nil
+}
+
+type ListTemplateRequestObject struct {
+}
+
+type ListTemplateResponseObject interface {
+	VisitListTemplateResponse(w http.ResponseWriter) error
+}
+
+type ListTemplate200JSONResponse []Template
+
+func (response ListTemplate200JSONResponse) VisitListTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewUnsavedTemplateRequestObject struct {
+	Body *PreviewUnsavedTemplateJSONRequestBody
+}
+
+type PreviewUnsavedTemplateResponseObject interface {
+	VisitPreviewUnsavedTemplateResponse(w http.ResponseWriter) error
+}
+
+type PreviewUnsavedTemplate200ImagepngResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response PreviewUnsavedTemplate200ImagepngResponse) VisitPreviewUnsavedTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/png")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PreviewUnsavedTemplate422JSONResponse InvalidParameters
+
+func (response PreviewUnsavedTemplate422JSONResponse) VisitPreviewUnsavedTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTemplateRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type DeleteTemplateResponseObject interface {
+	VisitDeleteTemplateResponse(w http.ResponseWriter) error
 }
 
-type GetPrinterInfo503Response struct {
+type DeleteTemplate204Response struct {
 }
 
-func (response GetPrinterInfo503Response) VisitGetPrinterInfoResponse(w http.ResponseWriter) error {
-	w.WriteHeader(503)
+func (response DeleteTemplate204Response) VisitDeleteTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
 	return nil
 }
 
-type ListTemplateRequestObject struct {
+type DeleteTemplate400Response struct {
 }
 
-type ListTemplateResponseObject interface {
-	VisitListTemplateResponse(w http.ResponseWriter) error
+func (response DeleteTemplate400Response) VisitDeleteTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
 }
 
-type ListTemplate200JSONResponse []Template
-
-func (response ListTemplate200JSONResponse) VisitListTemplateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type DeleteTemplate404Response struct {
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (response DeleteTemplate404Response) VisitDeleteTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
 }
 
 type GetTemplateRequestObject struct {
@@ -538,9 +6215,44 @@ func (response GetTemplate404Response) VisitGetTemplateResponse(w http.ResponseW
 	return nil
 }
 
-type CreateOrUpdateTemplateRequestObject struct {
+type RenameTemplateRequestObject struct {
 	Uuid Uuid `json:"uuid"`
-	Body *CreateOrUpdateTemplateJSONRequestBody
+	Body *RenameTemplateJSONRequestBody
+}
+
+type RenameTemplateResponseObject interface {
+	VisitRenameTemplateResponse(w http.ResponseWriter) error
+}
+
+type RenameTemplate200JSONResponse Template
+
+func (response RenameTemplate200JSONResponse) VisitRenameTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTemplate400Response struct {
+}
+
+func (response RenameTemplate400Response) VisitRenameTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type RenameTemplate404Response struct {
+}
+
+func (response RenameTemplate404Response) VisitRenameTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CreateOrUpdateTemplateRequestObject struct {
+	Uuid   Uuid `json:"uuid"`
+	Params CreateOrUpdateTemplateParams
+	Body   *CreateOrUpdateTemplateJSONRequestBody
 }
 
 type CreateOrUpdateTemplateResponseObject interface {
@@ -571,92 +6283,468 @@ func (response CreateOrUpdateTemplate400JSONResponse) VisitCreateOrUpdateTemplat
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrUpdateTemplate404Response struct {
+}
+
+func (response CreateOrUpdateTemplate404Response) VisitCreateOrUpdateTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PrintTemplateBatchRequestObject struct {
+	Uuid     Uuid `json:"uuid"`
+	Params   PrintTemplateBatchParams
+	JSONBody *PrintTemplateBatchJSONRequestBody
+	Body     io.Reader
+}
+
+type PrintTemplateBatchResponseObject interface {
+	VisitPrintTemplateBatchResponse(w http.ResponseWriter) error
+}
+
+type PrintTemplateBatch200JSONResponse PrintBatch
+
+func (response PrintTemplateBatch200JSONResponse) VisitPrintTemplateBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrintTemplateBatch202JSONResponse PrintBatch
+
+func (response PrintTemplateBatch202JSONResponse) VisitPrintTemplateBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrintTemplateBatch400JSONResponse struct {
+	Reason string `json:"reason"`
+}
+
+func (response PrintTemplateBatch400JSONResponse) VisitPrintTemplateBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrintTemplateBatch404Response struct {
+}
+
+func (response PrintTemplateBatch404Response) VisitPrintTemplateBatchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PrintTemplateBatch422JSONResponse struct {
+	Rows []BatchRowError `json:"rows"`
+}
+
+func (response PrintTemplateBatch422JSONResponse) VisitPrintTemplateBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DuplicateTemplateRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *DuplicateTemplateJSONRequestBody
+}
+
+type DuplicateTemplateResponseObject interface {
+	VisitDuplicateTemplateResponse(w http.ResponseWriter) error
+}
+
+type DuplicateTemplate201JSONResponse Template
+
+func (response DuplicateTemplate201JSONResponse) VisitDuplicateTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DuplicateTemplate400Response struct {
+}
+
+func (response DuplicateTemplate400Response) VisitDuplicateTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DuplicateTemplate404Response struct {
+}
+
+func (response DuplicateTemplate404Response) VisitDuplicateTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DuplicateTemplate409Response struct {
+}
+
+func (response DuplicateTemplate409Response) VisitDuplicateTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PreviewTemplateRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *PreviewTemplateJSONRequestBody
+}
+
+type PreviewTemplateResponseObject interface {
+	VisitPreviewTemplateResponse(w http.ResponseWriter) error
+}
+
+type PreviewTemplate200ImagepngResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response PreviewTemplate200ImagepngResponse) VisitPreviewTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/png")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PreviewTemplate400Response struct {
+}
+
+func (response PreviewTemplate400Response) VisitPreviewTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PreviewTemplate404Response struct {
+}
+
+func (response PreviewTemplate404Response) VisitPreviewTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PreviewTemplate422JSONResponse InvalidParameters
+
+func (response PreviewTemplate422JSONResponse) VisitPreviewTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrintTemplateRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *PrintTemplateJSONRequestBody
+}
+
+type PrintTemplateResponseObject interface {
+	VisitPrintTemplateResponse(w http.ResponseWriter) error
+}
+
+type PrintTemplate202JSONResponse PrintJob
+
+func (response PrintTemplate202JSONResponse) VisitPrintTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrintTemplate400Response struct {
+}
+
+func (response PrintTemplate400Response) VisitPrintTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PrintTemplate404Response struct {
+}
+
+func (response PrintTemplate404Response) VisitPrintTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PrintTemplate422JSONResponse InvalidParameters
+
+func (response PrintTemplate422JSONResponse) VisitPrintTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTemplateRevisionsRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type ListTemplateRevisionsResponseObject interface {
+	VisitListTemplateRevisionsResponse(w http.ResponseWriter) error
+}
+
+type ListTemplateRevisions200JSONResponse []TemplateRevision
+
+func (response ListTemplateRevisions200JSONResponse) VisitListTemplateRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTemplateRevisions400Response struct {
+}
+
+func (response ListTemplateRevisions400Response) VisitListTemplateRevisionsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type ListTemplateRevisions404Response struct {
+}
+
+func (response ListTemplateRevisions404Response) VisitListTemplateRevisionsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetTemplateRevisionRequestObject struct {
+	Uuid     Uuid     `json:"uuid"`
+	Revision Revision `json:"revision"`
+}
+
+type GetTemplateRevisionResponseObject interface {
+	VisitGetTemplateRevisionResponse(w http.ResponseWriter) error
+}
+
+type GetTemplateRevision200JSONResponse TemplateRevision
+
+func (response GetTemplateRevision200JSONResponse) VisitGetTemplateRevisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTemplateRevision400Response struct {
+}
+
+func (response GetTemplateRevision400Response) VisitGetTemplateRevisionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetTemplateRevision404Response struct {
+}
+
+func (response GetTemplateRevision404Response) VisitGetTemplateRevisionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PreviewTemplateRevisionRequestObject struct {
+	Uuid     Uuid     `json:"uuid"`
+	Revision Revision `json:"revision"`
+	Body     *PreviewTemplateRevisionJSONRequestBody
+}
+
+type PreviewTemplateRevisionResponseObject interface {
+	VisitPreviewTemplateRevisionResponse(w http.ResponseWriter) error
+}
+
+type PreviewTemplateRevision200ImagepngResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response PreviewTemplateRevision200ImagepngResponse) VisitPreviewTemplateRevisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/png")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PreviewTemplateRevision400Response struct {
+}
+
+func (response PreviewTemplateRevision400Response) VisitPreviewTemplateRevisionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
 }
 
-type CreateOrUpdateTemplate404Response struct {
+type PreviewTemplateRevision404Response struct {
 }
 
-func (response CreateOrUpdateTemplate404Response) VisitCreateOrUpdateTemplateResponse(w http.ResponseWriter) error {
+func (response PreviewTemplateRevision404Response) VisitPreviewTemplateRevisionResponse(w http.ResponseWriter) error {
 	w.WriteHeader(404)
 	return nil
 }
 
-type PrintTemplateRequestObject struct {
-	Uuid Uuid `json:"uuid"`
-	Body *PrintTemplateJSONRequestBody
+type PreviewTemplateRevision422JSONResponse InvalidParameters
+
+func (response PreviewTemplateRevision422JSONResponse) VisitPreviewTemplateRevisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PrintTemplateResponseObject interface {
-	VisitPrintTemplateResponse(w http.ResponseWriter) error
+type RestoreTemplateRevisionRequestObject struct {
+	Uuid     Uuid     `json:"uuid"`
+	Revision Revision `json:"revision"`
+	Params   RestoreTemplateRevisionParams
 }
 
-type PrintTemplate202Response struct {
+type RestoreTemplateRevisionResponseObject interface {
+	VisitRestoreTemplateRevisionResponse(w http.ResponseWriter) error
 }
 
-func (response PrintTemplate202Response) VisitPrintTemplateResponse(w http.ResponseWriter) error {
-	w.WriteHeader(202)
-	return nil
+type RestoreTemplateRevision200JSONResponse Template
+
+func (response RestoreTemplateRevision200JSONResponse) VisitRestoreTemplateRevisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PrintTemplate400Response struct {
+type RestoreTemplateRevision400Response struct {
 }
 
-func (response PrintTemplate400Response) VisitPrintTemplateResponse(w http.ResponseWriter) error {
+func (response RestoreTemplateRevision400Response) VisitRestoreTemplateRevisionResponse(w http.ResponseWriter) error {
 	w.WriteHeader(400)
 	return nil
 }
 
-type PrintTemplate404Response struct {
+type RestoreTemplateRevision404Response struct {
 }
 
-func (response PrintTemplate404Response) VisitPrintTemplateResponse(w http.ResponseWriter) error {
+func (response RestoreTemplateRevision404Response) VisitRestoreTemplateRevisionResponse(w http.ResponseWriter) error {
 	w.WriteHeader(404)
 	return nil
 }
 
-type PrintTemplate422JSONResponse struct {
-	Reason string `json:"reason"`
-}
+type RestoreTemplateRevision409JSONResponse string
 
-func (response PrintTemplate422JSONResponse) VisitPrintTemplateResponse(w http.ResponseWriter) error {
+func (response RestoreTemplateRevision409JSONResponse) VisitRestoreTemplateRevisionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PrintTemplate503Response struct {
-}
-
-func (response PrintTemplate503Response) VisitPrintTemplateResponse(w http.ResponseWriter) error {
-	w.WriteHeader(503)
-	return nil
-}
-
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Get the progress of a batch of print jobs
+	// (GET /batch/{uuid})
+	GetBatch(ctx context.Context, request GetBatchRequestObject) (GetBatchResponseObject, error)
 	// List fonts
 	// (GET /font)
 	ListFont(ctx context.Context, request ListFontRequestObject) (ListFontResponseObject, error)
+	// Upload a TrueType or OpenType font
+	// (POST /font)
+	UploadFont(ctx context.Context, request UploadFontRequestObject) (UploadFontResponseObject, error)
+	// Delete an uploaded font
+	// (DELETE /font/{uuid})
+	DeleteFont(ctx context.Context, request DeleteFontRequestObject) (DeleteFontResponseObject, error)
+	// Rename a font
+	// (PATCH /font/{uuid})
+	RenameFont(ctx context.Context, request RenameFontRequestObject) (RenameFontResponseObject, error)
+	// List the labels which have been printed, newest first
+	// (GET /history)
+	ListHistory(ctx context.Context, request ListHistoryRequestObject) (ListHistoryResponseObject, error)
+	// Get an entry from the print history
+	// (GET /history/{uuid})
+	GetHistory(ctx context.Context, request GetHistoryRequestObject) (GetHistoryResponseObject, error)
+	// Print a label again exactly as it was printed
+	// (POST /history/{uuid}/reprint)
+	Reprint(ctx context.Context, request ReprintRequestObject) (ReprintResponseObject, error)
+	// Get a PNG of the bitmap which was printed
+	// (GET /history/{uuid}/thumbnail)
+	GetHistoryThumbnail(ctx context.Context, request GetHistoryThumbnailRequestObject) (GetHistoryThumbnailResponseObject, error)
+	// List print jobs
+	// (GET /job)
+	ListJob(ctx context.Context, request ListJobRequestObject) (ListJobResponseObject, error)
+	// Get the status of a print job
+	// (GET /job/{uuid})
+	GetJob(ctx context.Context, request GetJobRequestObject) (GetJobResponseObject, error)
+	// Cancel a print job
+	// (POST /job/{uuid}/cancel)
+	CancelJob(ctx context.Context, request CancelJobRequestObject) (CancelJobResponseObject, error)
 	// Print an image directly
 	// (POST /printer)
 	PrintImage(ctx context.Context, request PrintImageRequestObject) (PrintImageResponseObject, error)
+	// Stream of printer events
+	// (GET /printer/events)
+	GetPrinterEvents(ctx context.Context, request GetPrinterEventsRequestObject) (GetPrinterEventsResponseObject, error)
 	// Get device info
 	// (GET /printer/info)
 	GetPrinterInfo(ctx context.Context, request GetPrinterInfoRequestObject) (GetPrinterInfoResponseObject, error)
 	// List templates
 	// (GET /template)
 	ListTemplate(ctx context.Context, request ListTemplateRequestObject) (ListTemplateResponseObject, error)
+	// Preview a template which hasn't been saved, exactly as it would be printed
+	// (POST /template/preview)
+	PreviewUnsavedTemplate(ctx context.Context, request PreviewUnsavedTemplateRequestObject) (PreviewUnsavedTemplateResponseObject, error)
+	// Delete a template
+	// (DELETE /template/{uuid})
+	DeleteTemplate(ctx context.Context, request DeleteTemplateRequestObject) (DeleteTemplateResponseObject, error)
 	// Get a single template
 	// (GET /template/{uuid})
 	GetTemplate(ctx context.Context, request GetTemplateRequestObject) (GetTemplateResponseObject, error)
+	// Rename a template
+	// (PATCH /template/{uuid})
+	RenameTemplate(ctx context.Context, request RenameTemplateRequestObject) (RenameTemplateResponseObject, error)
 	// Create a new template, or update an existing one
 	// (PUT /template/{uuid})
 	CreateOrUpdateTemplate(ctx context.Context, request CreateOrUpdateTemplateRequestObject) (CreateOrUpdateTemplateResponseObject, error)
+	// Print a template once for each row of a CSV file or JSON array
+	// (POST /template/{uuid}/batch)
+	PrintTemplateBatch(ctx context.Context, request PrintTemplateBatchRequestObject) (PrintTemplateBatchResponseObject, error)
+	// Copy a template and everything in it under a new UUID
+	// (POST /template/{uuid}/duplicate)
+	DuplicateTemplate(ctx context.Context, request DuplicateTemplateRequestObject) (DuplicateTemplateResponseObject, error)
+	// Preview a template exactly as it would be printed
+	// (POST /template/{uuid}/preview)
+	PreviewTemplate(ctx context.Context, request PreviewTemplateRequestObject) (PreviewTemplateResponseObject, error)
 	// Print a template
 	// (POST /template/{uuid}/print)
 	PrintTemplate(ctx context.Context, request PrintTemplateRequestObject) (PrintTemplateResponseObject, error)
+	// List a template's revisions, newest first
+	// (GET /template/{uuid}/revision)
+	ListTemplateRevisions(ctx context.Context, request ListTemplateRevisionsRequestObject) (ListTemplateRevisionsResponseObject, error)
+	// Get a template as it was at one of its revisions
+	// (GET /template/{uuid}/revision/{revision})
+	GetTemplateRevision(ctx context.Context, request GetTemplateRevisionRequestObject) (GetTemplateRevisionResponseObject, error)
+	// Preview a template as it was at one of its revisions
+	// (POST /template/{uuid}/revision/{revision}/preview)
+	PreviewTemplateRevision(ctx context.Context, request PreviewTemplateRevisionRequestObject) (PreviewTemplateRevisionResponseObject, error)
+	// Make an earlier revision of a template the current one
+	// (POST /template/{uuid}/revision/{revision}/restore)
+	RestoreTemplateRevision(ctx context.Context, request RestoreTemplateRevisionRequestObject) (RestoreTemplateRevisionResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -682,29 +6770,323 @@ func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []Strict
 	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetBatch operation middleware
+func (sh *strictHandler) GetBatch(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request GetBatchRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBatch(ctx, request.(GetBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBatchResponseObject); ok {
+		if err := validResponse.VisitGetBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFont operation middleware
+func (sh *strictHandler) ListFont(w http.ResponseWriter, r *http.Request) {
+	var request ListFontRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFont(ctx, request.(ListFontRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFont")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFontResponseObject); ok {
+		if err := validResponse.VisitListFontResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UploadFont operation middleware
+func (sh *strictHandler) UploadFont(w http.ResponseWriter, r *http.Request, params UploadFontParams) {
+	var request UploadFontRequestObject
+
+	request.Params = params
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UploadFont(ctx, request.(UploadFontRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UploadFont")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UploadFontResponseObject); ok {
+		if err := validResponse.VisitUploadFontResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFont operation middleware
+func (sh *strictHandler) DeleteFont(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request DeleteFontRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFont(ctx, request.(DeleteFontRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFont")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFontResponseObject); ok {
+		if err := validResponse.VisitDeleteFontResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RenameFont operation middleware
+func (sh *strictHandler) RenameFont(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request RenameFontRequestObject
+
+	request.Uuid = uuid
+
+	var body RenameFontJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RenameFont(ctx, request.(RenameFontRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RenameFont")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RenameFontResponseObject); ok {
+		if err := validResponse.VisitRenameFontResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListHistory operation middleware
+func (sh *strictHandler) ListHistory(w http.ResponseWriter, r *http.Request, params ListHistoryParams) {
+	var request ListHistoryRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListHistory(ctx, request.(ListHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListHistoryResponseObject); ok {
+		if err := validResponse.VisitListHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHistory operation middleware
+func (sh *strictHandler) GetHistory(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request GetHistoryRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHistory(ctx, request.(GetHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHistoryResponseObject); ok {
+		if err := validResponse.VisitGetHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Reprint operation middleware
+func (sh *strictHandler) Reprint(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request ReprintRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Reprint(ctx, request.(ReprintRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Reprint")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReprintResponseObject); ok {
+		if err := validResponse.VisitReprintResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHistoryThumbnail operation middleware
+func (sh *strictHandler) GetHistoryThumbnail(w http.ResponseWriter, r *http.Request, uuid Uuid, params GetHistoryThumbnailParams) {
+	var request GetHistoryThumbnailRequestObject
+
+	request.Uuid = uuid
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHistoryThumbnail(ctx, request.(GetHistoryThumbnailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHistoryThumbnail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHistoryThumbnailResponseObject); ok {
+		if err := validResponse.VisitGetHistoryThumbnailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListJob operation middleware
+func (sh *strictHandler) ListJob(w http.ResponseWriter, r *http.Request) {
+	var request ListJobRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListJob(ctx, request.(ListJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListJob")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListJobResponseObject); ok {
+		if err := validResponse.VisitListJobResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetJob operation middleware
+func (sh *strictHandler) GetJob(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request GetJobRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJob(ctx, request.(GetJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJob")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJobResponseObject); ok {
+		if err := validResponse.VisitGetJobResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelJob operation middleware
+func (sh *strictHandler) CancelJob(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request CancelJobRequestObject
 
-// ListFont operation middleware
-func (sh *strictHandler) ListFont(w http.ResponseWriter, r *http.Request) {
-	var request ListFontRequestObject
+	request.Uuid = uuid
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListFont(ctx, request.(ListFontRequestObject))
+		return sh.ssi.CancelJob(ctx, request.(CancelJobRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListFont")
+		handler = middleware(handler, "CancelJob")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListFontResponseObject); ok {
-		if err := validResponse.VisitListFontResponse(w); err != nil {
+	} else if validResponse, ok := response.(CancelJobResponseObject); ok {
+		if err := validResponse.VisitCancelJobResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -743,6 +7125,30 @@ func (sh *strictHandler) PrintImage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetPrinterEvents operation middleware
+func (sh *strictHandler) GetPrinterEvents(w http.ResponseWriter, r *http.Request) {
+	var request GetPrinterEventsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPrinterEvents(ctx, request.(GetPrinterEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPrinterEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPrinterEventsResponseObject); ok {
+		if err := validResponse.VisitGetPrinterEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetPrinterInfo operation middleware
 func (sh *strictHandler) GetPrinterInfo(w http.ResponseWriter, r *http.Request) {
 	var request GetPrinterInfoRequestObject
@@ -791,6 +7197,63 @@ func (sh *strictHandler) ListTemplate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// PreviewUnsavedTemplate operation middleware
+func (sh *strictHandler) PreviewUnsavedTemplate(w http.ResponseWriter, r *http.Request) {
+	var request PreviewUnsavedTemplateRequestObject
+
+	var body PreviewUnsavedTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PreviewUnsavedTemplate(ctx, request.(PreviewUnsavedTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PreviewUnsavedTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PreviewUnsavedTemplateResponseObject); ok {
+		if err := validResponse.VisitPreviewUnsavedTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteTemplate operation middleware
+func (sh *strictHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request DeleteTemplateRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTemplate(ctx, request.(DeleteTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteTemplateResponseObject); ok {
+		if err := validResponse.VisitDeleteTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetTemplate operation middleware
 func (sh *strictHandler) GetTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid) {
 	var request GetTemplateRequestObject
@@ -817,11 +7280,45 @@ func (sh *strictHandler) GetTemplate(w http.ResponseWriter, r *http.Request, uui
 	}
 }
 
+// RenameTemplate operation middleware
+func (sh *strictHandler) RenameTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request RenameTemplateRequestObject
+
+	request.Uuid = uuid
+
+	var body RenameTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RenameTemplate(ctx, request.(RenameTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RenameTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RenameTemplateResponseObject); ok {
+		if err := validResponse.VisitRenameTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // CreateOrUpdateTemplate operation middleware
-func (sh *strictHandler) CreateOrUpdateTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+func (sh *strictHandler) CreateOrUpdateTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid, params CreateOrUpdateTemplateParams) {
 	var request CreateOrUpdateTemplateRequestObject
 
 	request.Uuid = uuid
+	request.Params = params
 
 	var body CreateOrUpdateTemplateJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -850,6 +7347,111 @@ func (sh *strictHandler) CreateOrUpdateTemplate(w http.ResponseWriter, r *http.R
 	}
 }
 
+// PrintTemplateBatch operation middleware
+func (sh *strictHandler) PrintTemplateBatch(w http.ResponseWriter, r *http.Request, uuid Uuid, params PrintTemplateBatchParams) {
+	var request PrintTemplateBatchRequestObject
+
+	request.Uuid = uuid
+	request.Params = params
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+
+		var body PrintTemplateBatchJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		request.Body = r.Body
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PrintTemplateBatch(ctx, request.(PrintTemplateBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PrintTemplateBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PrintTemplateBatchResponseObject); ok {
+		if err := validResponse.VisitPrintTemplateBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DuplicateTemplate operation middleware
+func (sh *strictHandler) DuplicateTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request DuplicateTemplateRequestObject
+
+	request.Uuid = uuid
+
+	var body DuplicateTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DuplicateTemplate(ctx, request.(DuplicateTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DuplicateTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DuplicateTemplateResponseObject); ok {
+		if err := validResponse.VisitDuplicateTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PreviewTemplate operation middleware
+func (sh *strictHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request PreviewTemplateRequestObject
+
+	request.Uuid = uuid
+
+	var body PreviewTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PreviewTemplate(ctx, request.(PreviewTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PreviewTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PreviewTemplateResponseObject); ok {
+		if err := validResponse.VisitPreviewTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PrintTemplate operation middleware
 func (sh *strictHandler) PrintTemplate(w http.ResponseWriter, r *http.Request, uuid Uuid) {
 	var request PrintTemplateRequestObject
@@ -882,3 +7484,118 @@ func (sh *strictHandler) PrintTemplate(w http.ResponseWriter, r *http.Request, u
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// ListTemplateRevisions operation middleware
+func (sh *strictHandler) ListTemplateRevisions(w http.ResponseWriter, r *http.Request, uuid Uuid) {
+	var request ListTemplateRevisionsRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTemplateRevisions(ctx, request.(ListTemplateRevisionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTemplateRevisions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTemplateRevisionsResponseObject); ok {
+		if err := validResponse.VisitListTemplateRevisionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTemplateRevision operation middleware
+func (sh *strictHandler) GetTemplateRevision(w http.ResponseWriter, r *http.Request, uuid Uuid, revision Revision) {
+	var request GetTemplateRevisionRequestObject
+
+	request.Uuid = uuid
+	request.Revision = revision
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTemplateRevision(ctx, request.(GetTemplateRevisionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTemplateRevision")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTemplateRevisionResponseObject); ok {
+		if err := validResponse.VisitGetTemplateRevisionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PreviewTemplateRevision operation middleware
+func (sh *strictHandler) PreviewTemplateRevision(w http.ResponseWriter, r *http.Request, uuid Uuid, revision Revision) {
+	var request PreviewTemplateRevisionRequestObject
+
+	request.Uuid = uuid
+	request.Revision = revision
+
+	var body PreviewTemplateRevisionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PreviewTemplateRevision(ctx, request.(PreviewTemplateRevisionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PreviewTemplateRevision")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PreviewTemplateRevisionResponseObject); ok {
+		if err := validResponse.VisitPreviewTemplateRevisionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RestoreTemplateRevision operation middleware
+func (sh *strictHandler) RestoreTemplateRevision(w http.ResponseWriter, r *http.Request, uuid Uuid, revision Revision, params RestoreTemplateRevisionParams) {
+	var request RestoreTemplateRevisionRequestObject
+
+	request.Uuid = uuid
+	request.Revision = revision
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreTemplateRevision(ctx, request.(RestoreTemplateRevisionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreTemplateRevision")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RestoreTemplateRevisionResponseObject); ok {
+		if err := validResponse.VisitRestoreTemplateRevisionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
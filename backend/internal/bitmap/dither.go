@@ -0,0 +1,129 @@
+// This file implements turning a full colour image into a black and white
+// one, with a choice of dithering algorithms and tone adjustments, as some
+// suit photos and others suit logos or text.
+package bitmap
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/makeworld-the-better-one/dither/v2"
+)
+
+type DitherAlgorithm string
+
+const (
+	// No dithering, each pixel is just black or white depending on whether
+	// it's darker or lighter than mid-grey
+	Threshold         DitherAlgorithm = "THRESHOLD"
+	// Ordered dithering with an 8x8 Bayer matrix
+	Bayer             DitherAlgorithm = "BAYER"
+	FloydSteinberg    DitherAlgorithm = "FLOYD_STEINBERG"
+	Atkinson          DitherAlgorithm = "ATKINSON"
+	JarvisJudiceNinke DitherAlgorithm = "JARVIS_JUDICE_NINKE"
+	Stucki            DitherAlgorithm = "STUCKI"
+	Burkes            DitherAlgorithm = "BURKES"
+	Sierra            DitherAlgorithm = "SIERRA"
+)
+
+var errorDiffusionMatrices = map[DitherAlgorithm]dither.ErrorDiffusionMatrix{
+	FloydSteinberg:    dither.FloydSteinberg,
+	Atkinson:          dither.Atkinson,
+	JarvisJudiceNinke: dither.JarvisJudiceNinke,
+	Stucki:            dither.Stucki,
+	Burkes:            dither.Burkes,
+	Sierra:            dither.Sierra,
+}
+
+type RenderOptions struct {
+	Algorithm  DitherAlgorithm
+	// Each pixel's grey level (0 to 1) is raised to this power, so values
+	// below 1 lighten the image
+	Gamma      float64
+	// Added to each pixel's grey level, from -1 (all black) to 1 (all white)
+	Brightness float64
+	// From -1 (flat grey) to 1 (maximum contrast)
+	Contrast   float64
+	Invert     bool
+}
+
+func DefaultRenderOptions() RenderOptions {
+	return RenderOptions{
+		Algorithm: FloydSteinberg,
+		// apply a gamma correction of 0.5 otherwise image appears too dark with T02
+		// no logic used to pick 0.5 as gamma factor, just looks empirically close to image on display
+		Gamma: 0.5,
+	}
+}
+
+func (o RenderOptions) Validate() error {
+	if _, ok := errorDiffusionMatrices[o.Algorithm]; !ok && o.Algorithm != Threshold && o.Algorithm != Bayer {
+		return fmt.Errorf(`Unrecognised dithering algorithm "%s"`, o.Algorithm)
+	}
+	if o.Gamma <= 0 {
+		return fmt.Errorf("Gamma must be positive")
+	}
+	if o.Brightness < -1 || o.Brightness > 1 {
+		return fmt.Errorf("Brightness must be between -1 and 1")
+	}
+	if o.Contrast < -1 || o.Contrast > 1 {
+		return fmt.Errorf("Contrast must be between -1 and 1")
+	}
+	return nil
+}
+
+// Turns an image black and white, without scaling it
+func Dither(i image.Image, o RenderOptions) *image.Paletted {
+	bounds := i.Bounds()
+
+	// turn full colour image into monochrome pixel by pixel
+	monochromeImage := image.NewGray16(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			grayColor := color.Gray16Model.Convert(i.At(x, y)).(color.Gray16)
+			grayValue := adjustTone(float64(grayColor.Y) / float64(0xFFFF), o)
+			monochromeImage.Set(x, y, color.Gray16{Y: uint16(grayValue * float64(0xFFFF))})
+		}
+	}
+
+	// dither monochrome image to black and white
+	palette := []color.Color{color.Black, color.White}
+	switch o.Algorithm {
+	case Threshold:
+		thresholdImage := image.NewPaletted(bounds, palette)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				if monochromeImage.Gray16At(x, y).Y >= 0x8000 {
+					thresholdImage.SetColorIndex(x, y, 1)
+				}
+			}
+		}
+		return thresholdImage
+	case Bayer:
+		ditherer := dither.NewDitherer(palette)
+		ditherer.Mapper = dither.Bayer(8, 8, 1.0)
+		return ditherer.DitherPaletted(monochromeImage)
+	default:
+		matrix, ok := errorDiffusionMatrices[o.Algorithm]
+		if !ok {
+			matrix = dither.FloydSteinberg
+		}
+		ditherer := dither.NewDitherer(palette)
+		ditherer.Matrix = matrix
+		ditherer.Serpentine = true
+		return ditherer.DitherPaletted(monochromeImage)
+	}
+}
+
+func adjustTone(v float64, o RenderOptions) float64 {
+	v = math.Pow(v, o.Gamma)
+	v = (v - 0.5) * (1 + o.Contrast) + 0.5
+	v += o.Brightness
+	v = math.Max(0, math.Min(1, v))
+	if o.Invert {
+		v = 1 - v
+	}
+	return v
+}
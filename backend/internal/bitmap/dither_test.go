@@ -0,0 +1,57 @@
+package bitmap
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func aGradient() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, 256, 4))
+	for y := range 4 {
+		for x := range 256 {
+			img.SetGray(x, y, color.Gray{Y: uint8(x)})
+		}
+	}
+	return img
+}
+
+func TestDitherThreshold(t *testing.T) {
+	o := DefaultRenderOptions()
+	o.Algorithm = Threshold
+	o.Gamma = 1
+
+	dithered := Dither(aGradient(), o)
+	white := dithered.Palette.Index(color.White)
+	for x := range 256 {
+		isWhite := dithered.ColorIndexAt(x, 0) == uint8(white)
+		if isWhite != (x >= 128) {
+			t.Errorf("Expected pixel %v to be white=%v", x, x >= 128)
+		}
+	}
+
+	o.Invert = true
+	inverted := Dither(aGradient(), o)
+	for x := range 256 {
+		if inverted.ColorIndexAt(x, 0) == dithered.ColorIndexAt(x, 0) && x != 128 {
+			t.Errorf("Expected pixel %v to be inverted", x)
+		}
+	}
+}
+
+func TestRenderOptionsValidate(t *testing.T) {
+	if err := DefaultRenderOptions().Validate(); err != nil {
+		t.Errorf("Expected default options to be valid: %v", err)
+	}
+	invalid := []RenderOptions{
+		{Algorithm: "SPIRAL", Gamma: 1},
+		{Algorithm: Bayer, Gamma: 0},
+		{Algorithm: Atkinson, Gamma: 1, Brightness: 2},
+		{Algorithm: Stucki, Gamma: 1, Contrast: -1.5},
+	}
+	for _, o := range invalid {
+		if err := o.Validate(); err == nil {
+			t.Errorf("Expected options to be invalid: %+v", o)
+		}
+	}
+}
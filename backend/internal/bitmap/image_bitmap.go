@@ -5,8 +5,6 @@ import (
 	"image"
 	"image/color"
 	"golang.org/x/image/draw"
-	"math"
-	"github.com/makeworld-the-better-one/dither/v2"
 )
 
 type ImageBitmap struct {
@@ -49,13 +47,14 @@ func FromPaletted(i *image.Paletted) (*ImageBitmap, error) {
 	}, nil
 }
 
-// take an image, monochrome-ify using dithering, so it can be used for an ImageBitmap
-func RenderForDevice(i image.Image) *image.Paletted {
+// take an image, monochrome-ify using dithering, so it can be used for an ImageBitmap.
+// Images wider than maxWidth (the number of dots across the print head) are
+// scaled down to fit.
+func RenderForDevice(i image.Image, maxWidth int, o RenderOptions) *image.Paletted {
 	// TODO: if image less than printer max width, pad with white pixels
 	// Phomemo T02 hardware seems to act unpredictably if input bitmap less than device width
 
 	// determine width of bitmap to print, ready to scale
-	maxWidth := 48 * 8
 	newWidth := i.Bounds().Dx()
 	if newWidth > maxWidth {
 		newWidth = maxWidth
@@ -65,28 +64,28 @@ func RenderForDevice(i image.Image) *image.Paletted {
 	// resize image using Catmull Rom scaling
 	draw.CatmullRom.Scale(scaledImage, scaledBounds, i, i.Bounds(), draw.Over, nil)
 
-	// turn full colour image into monochrome pixel by pixel
-	monochromeImage := image.NewGray16(scaledBounds)
-	for y := scaledBounds.Min.Y; y < scaledBounds.Max.Y; y++ {
-		for x := scaledBounds.Min.X; x < scaledBounds.Max.X; x++ {
-			originalColor := scaledImage.At(x, y)
-			grayColor := color.Gray16Model.Convert(originalColor).(color.Gray16)
-			grayValue := float64(grayColor.Y) / float64(0xFFFF)
+	return Dither(scaledImage, o)
+}
 
-			// apply a gamma correction of 0.5 otherwise image appears too dark with T02
-			// no logic used to pick 0.5 as gamma factor, just looks empirically close to image on display
-			scaledGrayValue := math.Pow(grayValue, 0.5)
-			scaledGrayColor := color.Gray16{Y:uint16(scaledGrayValue * float64(0xFFFF))}
-			monochromeImage.Set(x, y, scaledGrayColor)
-		}
+// Runs an image through the same steps used when printing it: dithering it to
+// black and white and packing it into the bitmap format the device consumes.
+func PackImage(i image.Image, maxWidth int, o RenderOptions) (*PackedBitmap, error) {
+	b, err := FromPaletted(RenderForDevice(i, maxWidth, o))
+	if err != nil {
+		return nil, err
 	}
-	
-	// dither monochrome image to black and white
-	palette := []color.Color{color.Black, color.White}
-	ditherer :=  dither.NewDitherer(palette)
-	ditherer.Matrix = dither.FloydSteinberg
-	ditherer.Serpentine = true
-	ditheredImage := ditherer.DitherPaletted(monochromeImage)
+	return PackBitmap(b), nil
+}
 
-	return ditheredImage
+// Draws a bitmap as a black and white image, with high bits drawn black like
+// they are when printed.
+func ToPaletted(b Bitmap) *image.Paletted {
+	palette := color.Palette{color.White, color.Black}
+	img := image.NewPaletted(image.Rect(0, 0, b.Width(), b.Height()), palette)
+	for y := range b.Height() {
+		for x := range b.Width() {
+			img.SetColorIndex(x, y, b.GetBit(x, y))
+		}
+	}
+	return img
 }
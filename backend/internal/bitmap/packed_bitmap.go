@@ -61,6 +61,19 @@ func (b *PackedBitmap) VerticalSlice(start int, height int) *PackedBitmap {
 	}
 }
 
+// Wraps bitmap data which has already been packed, e.g. one which was stored
+// after printing it, checking there's the right amount of it for the size
+func NewPackedBitmap(data []byte, width int, height int) (*PackedBitmap, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("Packed bitmap must have a positive width and height")
+	}
+	stride := (width + bitsPerWord - 1) / bitsPerWord
+	if len(data) != stride * height {
+		return nil, fmt.Errorf("Expected %v bytes of data for a %vx%v packed bitmap, got %v", stride * height, width, height, len(data))
+	}
+	return &PackedBitmap{data, width, height, stride}, nil
+}
+
 // Take data from any Bitmap implementation and pack it into the Phomemo bitmap structure
 func PackBitmap(b Bitmap) *PackedBitmap {
 	width, height, stride := b.Width(), b.Height(), (b.Width() + bitsPerWord - 1) / bitsPerWord
@@ -65,3 +65,25 @@ func TestPackBitmapMany(t *testing.T) {
 		})
 	}
 }
+
+func TestToPalettedRoundTrip(t *testing.T) {
+	testBitmap := PackBitmap(aRandomBitmap())
+	img, err := FromPaletted(ToPaletted(testBitmap))
+	if err != nil {
+		t.Fatalf("Couldn't create bitmap from paletted image: %v", err)
+	}
+	assertBitmapsIdentical(t, testBitmap, img)
+}
+
+func TestNewPackedBitmapRoundTrip(t *testing.T) {
+	testBitmap := PackBitmap(aRandomBitmap())
+	unpacked, err := NewPackedBitmap(testBitmap.Data(), testBitmap.Width(), testBitmap.Height())
+	if err != nil {
+		t.Fatalf("Couldn't create packed bitmap: %v", err)
+	}
+	assertBitmapsIdentical(t, testBitmap, unpacked)
+
+	if _, err := NewPackedBitmap(testBitmap.Data(), testBitmap.Width() + 8, testBitmap.Height()); err == nil {
+		t.Errorf("Expected bitmap with too little data to be rejected")
+	}
+}
@@ -0,0 +1,443 @@
+// This package implements the command line client, for printing and looking
+// at templates and the printer from scripts without the web interface. Each
+// command either works directly with the printer and the database, or, given
+// a server's URL, as a client of that server's API.
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/config"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// The subcommands, each of which is run with the arguments after its name
+var commands = map[string]func(*Cli, []string) error{
+	"print":    (*Cli).print,
+	"template": (*Cli).template,
+	"scan":     (*Cli).scan,
+	"info":     (*Cli).info,
+}
+
+// Whether the arguments start with a subcommand, rather than the server's
+// flags
+func IsCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	_, ok := commands[args[0]]
+	return ok
+}
+
+type Cli struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	// Opens the database, for commands which don't go through a server
+	OpenDatabase func() *sql.DB
+	// Which printer to use when not going through a server, unless the
+	// command's flags say otherwise
+	Printer config.Printer
+}
+
+func NewCli(stdout, stderr io.Writer, openDatabase func() *sql.DB, printer config.Printer) *Cli {
+	return &Cli{Stdout: stdout, Stderr: stderr, OpenDatabase: openDatabase, Printer: printer}
+}
+
+// Runs a subcommand, returning the exit code
+func (c *Cli) Run(args []string) int {
+	if !IsCommand(args) {
+		fmt.Fprintf(c.Stderr, "Usage: phogoprint [print|template|scan|info] ...\n")
+		return 2
+	}
+	if err := commands[args[0]](c, args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(c.Stderr, "%v\n", err)
+		}
+		return 1
+	}
+	return 0
+}
+
+// How commands reach the printer and templates: directly, or through a server
+type backend interface {
+	PrintImage(ctx context.Context, data []byte, options *printFlags) (*printResult, error)
+	PrintTemplate(ctx context.Context, u uuid.UUID, params map[string]string, options *printFlags) (*printResult, error)
+	ListTemplates(ctx context.Context) ([]templateSummary, error)
+	Info(ctx context.Context) (*api.DeviceInfo, error)
+	Close() error
+}
+
+// Flags for choosing how to reach the printer, which every command which
+// uses it has
+type backendFlags struct {
+	server  string
+	printer config.Printer
+}
+
+func (f *backendFlags) register(fs *flag.FlagSet, defaults config.Printer) {
+	fs.StringVar(&f.server, "server", "", "URL of a running server to send the command to, e.g. http://localhost:8080, instead of using the printer directly")
+	f.printer = defaults
+	f.printer.RegisterConnection(fs)
+}
+
+func (c *Cli) backend(f *backendFlags) (backend, error) {
+	if f.server != "" {
+		return newRemote(f.server)
+	}
+	return newLocal(c.OpenDatabase, f), nil
+}
+
+// Flags for how a label is printed. Flags which aren't given leave the
+// option as the template's or the printer's default.
+type printFlags struct {
+	intensity string
+	justify   string
+	feedLines *int
+	copies    *int
+}
+
+var intensities = map[string]printer.LaserIntensity{"low": printer.Low, "medium": printer.Medium, "high": printer.High}
+var justifications = map[string]printer.Justify{"left": printer.Left, "centre": printer.Centre, "right": printer.Right}
+
+func (f *printFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.intensity, "intensity", "", "Laser intensity: low, medium or high")
+	fs.StringVar(&f.justify, "justify", "", "Where the label goes across the paper: left, centre or right")
+	fs.Func("feed-lines", "Blank lines to feed after the label", intFlag(&f.feedLines))
+	fs.Func("copies", "Number of copies to print", intFlag(&f.copies))
+}
+
+// Parses an integer flag which is left nil if it isn't given
+func intFlag(p **int) func(string) error {
+	return func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("Expected a number, got %q", s)
+		}
+		*p = &n
+		return nil
+	}
+}
+
+func (f *printFlags) validate() error {
+	if _, ok := intensities[f.intensity]; f.intensity != "" && !ok {
+		return fmt.Errorf("Unrecognised intensity %q", f.intensity)
+	}
+	if _, ok := justifications[f.justify]; f.justify != "" && !ok {
+		return fmt.Errorf("Unrecognised justification %q", f.justify)
+	}
+	return nil
+}
+
+// The options to print with, starting from the defaults
+func (f *printFlags) options(defaults printer.PrintOptions) printer.PrintOptions {
+	o := defaults
+	if f.intensity != "" {
+		o.Intensity = intensities[f.intensity]
+	}
+	if f.justify != "" {
+		o.Justify = justifications[f.justify]
+	}
+	if f.feedLines != nil {
+		o.FeedLines = *f.feedLines
+	}
+	if f.copies != nil {
+		o.Copies = *f.copies
+	}
+	return o
+}
+
+// The options to ask a server to print with, leaving out the ones which
+// weren't given
+func (f *printFlags) toJson() *api.PrintOptions {
+	o := &api.PrintOptions{}
+	if f.intensity != "" {
+		intensity := api.LaserIntensity(strings.ToUpper(f.intensity))
+		o.Intensity = &intensity
+	}
+	if f.justify != "" {
+		justify := api.Justify(strings.ToUpper(f.justify))
+		o.Justify = &justify
+	}
+	o.FeedLines = f.feedLines
+	o.Copies = f.copies
+	return o
+}
+
+// Parameter values given as name=value, which can be given more than once
+type paramFlag map[string]string
+
+func (p paramFlag) String() string {
+	return fmt.Sprint(map[string]string(p))
+}
+
+func (p paramFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("Expected name=value, got %q", s)
+	}
+	p[name] = value
+	return nil
+}
+
+// What printing a label did. Printing through a server queues a job, which
+// is printed later; printing directly has finished by the time it returns.
+type printResult struct {
+	Status    api.JobStatus `json:"status"`
+	Uuid      *string       `json:"uuid,omitempty"`
+	BatchUuid *string       `json:"batchUuid,omitempty"`
+}
+
+type templateSummary struct {
+	Uuid     string `json:"uuid"`
+	Name     string `json:"name"`
+	Revision int    `json:"revision,omitempty"`
+}
+
+type scannedDevice struct {
+	Address   string `json:"address"`
+	LocalName string `json:"localName"`
+	Rssi      int16  `json:"rssi"`
+	// The model the printer is treated as, if it's a known one
+	Model string `json:"model,omitempty"`
+}
+
+func (c *Cli) print(args []string) error {
+	fs := c.flagSet("print", "print [flags] image, or - to read it from stdin")
+	var bf backendFlags
+	var pf printFlags
+	bf.register(fs, c.Printer)
+	pf.register(fs)
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := pf.validate(); err != nil {
+		return err
+	}
+	var data []byte
+	if positional[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(positional[0])
+	}
+	if err != nil {
+		return fmt.Errorf("Couldn't read image:\n%w", err)
+	}
+
+	b, err := c.backend(&bf)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+	res, err := b.PrintImage(context.Background(), data, &pf)
+	if err != nil {
+		return err
+	}
+	return c.writePrintResult(res)
+}
+
+func (c *Cli) template(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("Usage: phogoprint template [list|print] ...")
+	}
+	switch args[0] {
+	case "list":
+		return c.listTemplates(args[1:])
+	case "print":
+		return c.printTemplate(args[1:])
+	default:
+		return fmt.Errorf("Unknown template command %q, expected list or print", args[0])
+	}
+}
+
+func (c *Cli) listTemplates(args []string) error {
+	fs := c.flagSet("template list", "template list [flags]")
+	var bf backendFlags
+	bf.register(fs, c.Printer)
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	b, err := c.backend(&bf)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+	ts, err := b.ListTemplates(context.Background())
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(ts, func(a, b templateSummary) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return c.write(ts, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "UUID\tNAME\tREVISION\n")
+		for _, t := range ts {
+			fmt.Fprintf(w, "%v\t%v\t%v\n", t.Uuid, t.Name, t.Revision)
+		}
+	})
+}
+
+func (c *Cli) printTemplate(args []string) error {
+	fs := c.flagSet("template print", "template print [flags] uuid")
+	var bf backendFlags
+	var pf printFlags
+	params := paramFlag{}
+	bf.register(fs, c.Printer)
+	pf.register(fs)
+	fs.Var(params, "param", "A parameter value, as name=value; give it once for each parameter")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := pf.validate(); err != nil {
+		return err
+	}
+	u, err := uuid.Parse(positional[0])
+	if err != nil {
+		return fmt.Errorf("Invalid template UUID %q", positional[0])
+	}
+
+	b, err := c.backend(&bf)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+	res, err := b.PrintTemplate(context.Background(), u, params, &pf)
+	if err != nil {
+		return err
+	}
+	return c.writePrintResult(res)
+}
+
+func (c *Cli) scan(args []string) error {
+	fs := c.flagSet("scan", "scan [flags]")
+	timeout := fs.Duration("timeout", 5*time.Second, "How long to scan for")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	found, err := printer.ScanDevices(*timeout)
+	if err != nil {
+		return err
+	}
+	devices := make([]scannedDevice, len(found))
+	for i, d := range found {
+		devices[i] = scannedDevice{Address: d.Address, LocalName: d.LocalName, Rssi: d.RSSI}
+		if d.LocalName != "" {
+			if p, ok := printer.ProfileForName(d.LocalName); ok {
+				devices[i].Model = p.Name
+			}
+		}
+	}
+	return c.write(devices, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "ADDRESS\tNAME\tRSSI\tMODEL\n")
+		for _, d := range devices {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", d.Address, d.LocalName, d.Rssi, d.Model)
+		}
+	})
+}
+
+func (c *Cli) info(args []string) error {
+	fs := c.flagSet("info", "info [flags]")
+	var bf backendFlags
+	bf.register(fs, c.Printer)
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	b, err := c.backend(&bf)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+	info, err := b.Info(context.Background())
+	if err != nil {
+		return err
+	}
+	return c.write(info, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "Model:\t%v\n", info.Model)
+		fmt.Fprintf(w, "State:\t%v\n", info.State)
+		fmt.Fprintf(w, "Firmware:\t%v\n", info.FirmwareVersion)
+		fmt.Fprintf(w, "Battery:\t%v%%\n", info.BatteryLevel)
+		fmt.Fprintf(w, "Print head:\t%v dots at %v DPI\n", info.DotsPerLine, info.Dpi)
+	})
+}
+
+func (c *Cli) flagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "Usage: phogoprint %v\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// Parses a command's flags, which can come before or after its arguments,
+// and checks it has the right number of arguments
+func parse(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != n {
+		fs.Usage()
+		return nil, flag.ErrHelp
+	}
+	return positional, nil
+}
+
+func (c *Cli) writePrintResult(res *printResult) error {
+	return c.write(res, func(w *tabwriter.Writer) {
+		switch {
+		case res.Uuid == nil:
+			fmt.Fprintf(w, "Printed\n")
+		case res.BatchUuid != nil:
+			fmt.Fprintf(w, "Queued batch %v, starting with job %v\n", *res.BatchUuid, *res.Uuid)
+		default:
+			fmt.Fprintf(w, "Queued job %v\n", *res.Uuid)
+		}
+	})
+}
+
+// Writes a command's output as JSON, unless it's going to a terminal, where
+// it's written as text for people to read
+func (c *Cli) write(v any, text func(*tabwriter.Writer)) error {
+	if !isTerminal(c.Stdout) {
+		return json.NewEncoder(c.Stdout).Encode(v)
+	}
+	w := tabwriter.NewWriter(c.Stdout, 0, 4, 2, ' ', 0)
+	text(w)
+	return w.Flush()
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
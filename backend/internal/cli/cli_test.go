@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/config"
+	"tomgalvin.uk/phogoprint/internal/database/dbtest"
+	"tomgalvin.uk/phogoprint/internal/job"
+	"tomgalvin.uk/phogoprint/internal/printer"
+	"tomgalvin.uk/phogoprint/internal/server"
+	"tomgalvin.uk/phogoprint/internal/template"
+)
+
+// A database with one template, which has a required parameter. Each command
+// opens its own connection to it, and closes it when it's finished.
+func aDatabase(t *testing.T) (func() *sql.DB, *template.Template) {
+	dsn := dbtest.Create(t)
+	open := func() *sql.DB {
+		db, err := sql.Open("sqlite3", dsn)
+		if err != nil {
+			t.Fatalf("Couldn't open database: %v", err)
+		}
+		return db
+	}
+	db := open()
+	t.Cleanup(func() { db.Close() })
+
+	r := &template.TemplateRepository{Db: db}
+	tmpl := &template.Template{
+		Uuid:         uuid.New(),
+		Name:         "Shelf label",
+		CreatedAt:    time.Now(),
+		MinSize:      50,
+		MaxSize:      100,
+		PrintOptions: printer.DefaultPrintOptions(),
+		Parameters:   []template.Parameter{{Name: "sku", Type: template.StringParameter, Required: true}},
+	}
+	if err := r.Transact(func(tx *sql.Tx) error { return r.Create(tx, tmpl, template.RevisionNote{}) }); err != nil {
+		t.Fatal(err)
+	}
+	return open, tmpl
+}
+
+func run(t *testing.T, open func() *sql.DB, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	c := NewCli(&stdout, &stderr, open, config.DefaultPrinter())
+	if code := c.Run(args); code != 0 {
+		return stdout.String(), fmt.Errorf("Exited with %v: %v", code, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+func TestTemplatesAreListedTheSameWayDirectlyAndThroughAServer(t *testing.T) {
+	open, tmpl := aDatabase(t)
+	db := open()
+	defer db.Close()
+	conn := printer.NewSimulatedConnection(printer.DefaultSimulatorOptions())
+	jobs := job.NewQueue(slog.Default(), &job.JobRepository{Db: db}, conn)
+	si := server.NewServer(slog.Default(), conn, &template.TemplateRepository{Db: db}, jobs)
+	ts := httptest.NewServer(http.StripPrefix("/api", api.Handler(api.NewStrictHandler(si, nil))))
+	defer ts.Close()
+
+	direct, err := run(t, open, "template", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote, err := run(t, nil, "template", "list", "-server", ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var summaries []templateSummary
+	if err := json.Unmarshal([]byte(direct), &summaries); err != nil {
+		t.Fatalf("Expected JSON output, got %q", direct)
+	}
+	if len(summaries) != 1 || summaries[0].Uuid != tmpl.Uuid.String() || summaries[0].Name != "Shelf label" {
+		t.Errorf("Unexpected templates %+v", summaries)
+	}
+	if direct != remote {
+		t.Errorf("Expected the same output through a server, got %q and %q", direct, remote)
+	}
+
+	// printing through the server queues a job
+	out, err := run(t, nil, "template", "print", tmpl.Uuid.String(), "-param", "sku=A-1", "-server", ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res printResult
+	json.Unmarshal([]byte(out), &res)
+	if res.Status != api.QUEUED || res.Uuid == nil {
+		t.Errorf("Expected a queued job, got %q", out)
+	}
+}
+
+func TestTemplatePrintsDirectly(t *testing.T) {
+	open, tmpl := aDatabase(t)
+	dir := t.TempDir()
+
+	_, err := run(t, open, "template", "print", "-simulate", tmpl.Uuid.String())
+	if err == nil || !strings.Contains(err.Error(), "sku") {
+		t.Errorf("Expected missing parameter to be reported, got %v", err)
+	}
+
+	out, err := run(t, open, "template", "print", "-simulate", "-simulator-output", dir, tmpl.Uuid.String(), "-param", "sku=A-1", "-copies", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(out) != `{"status":"DONE"}` {
+		t.Errorf("Unexpected output %q", out)
+	}
+	if labels, _ := os.ReadDir(dir); len(labels) != 2 {
+		t.Errorf("Expected 2 labels to be printed, got %v", len(labels))
+	}
+}
+
+func TestImagePrintsDirectly(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "label.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, image.NewGray(image.Rect(0, 0, 40, 20)))
+	f.Close()
+
+	if _, err := run(t, nil, "print", "-simulate", "-simulator-output", dir, "-intensity", "high", path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(t, nil, "print", "-simulate", "-intensity", "loud", path); err == nil {
+		t.Errorf("Expected unrecognised intensity to be turned away")
+	}
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/printer"
+	"tomgalvin.uk/phogoprint/internal/template"
+)
+
+// Runs commands directly against the printer and the database, which only
+// works while the server isn't running, since it would be connected to the
+// printer. Labels are printed straight away rather than being queued, so
+// they aren't kept in the print history.
+type local struct {
+	openDatabase func() *sql.DB
+	flags        *backendFlags
+	// opened when a command first needs them
+	db   *sql.DB
+	conn printer.Connection
+}
+
+func newLocal(openDatabase func() *sql.DB, flags *backendFlags) *local {
+	return &local{openDatabase: openDatabase, flags: flags}
+}
+
+func (l *local) templates() *template.TemplateRepository {
+	if l.db == nil {
+		l.db = l.openDatabase()
+	}
+	return &template.TemplateRepository{Db: l.db}
+}
+
+func (l *local) connection() (printer.Connection, error) {
+	if l.conn != nil {
+		return l.conn, nil
+	}
+	if l.flags.printer.Simulate {
+		l.conn = printer.NewSimulatedConnection(l.flags.printer.SimulatorOptions())
+	} else {
+		bt, err := l.flags.printer.Bluetooth()
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't find printer:\n%w", err)
+		}
+		l.conn = bt
+	}
+	return l.conn, nil
+}
+
+func (l *local) connect(ctx context.Context) (printer.Printer, error) {
+	conn, err := l.connection()
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("Couldn't connect to printer:\n%w", err)
+	}
+	return conn.GetPrinter(), nil
+}
+
+func (l *local) PrintImage(ctx context.Context, data []byte, options *printFlags) (*printResult, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't decode image:\n%w", err)
+	}
+	return l.print(ctx, []image.Image{img}, options.options(printer.DefaultPrintOptions()))
+}
+
+func (l *local) PrintTemplate(ctx context.Context, u uuid.UUID, params map[string]string, options *printFlags) (*printResult, error) {
+	r := l.templates()
+	t, err := r.Get(r.Db, u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch template:\n%w", err)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("No template %v", u)
+	}
+	conn, err := l.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	// templates which print the copy index need every copy rendering
+	// separately, like they are when queued
+	printOptions := options.options(t.PrintOptions)
+	copies := 1
+	if t.UsesCopyIndex() {
+		copies, printOptions.Copies = printOptions.Copies, 1
+	}
+	images := make([]image.Image, copies)
+	for i := range images {
+		if images[i], err = template.RenderCopy(t, params, conn.Profile(), i+1, copies); err != nil {
+			return nil, err
+		}
+	}
+	return l.print(ctx, images, printOptions)
+}
+
+func (l *local) print(ctx context.Context, images []image.Image, options printer.PrintOptions) (*printResult, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	p, err := l.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range images {
+		if err := p.WriteImage(ctx, img, options); err != nil {
+			return nil, fmt.Errorf("Couldn't print:\n%w", err)
+		}
+	}
+	return &printResult{Status: api.DONE}, nil
+}
+
+func (l *local) ListTemplates(ctx context.Context) ([]templateSummary, error) {
+	ts, err := l.templates().List()
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list templates:\n%w", err)
+	}
+	summaries := make([]templateSummary, len(ts))
+	for i, t := range ts {
+		summaries[i] = templateSummary{Uuid: t.Uuid.String(), Name: t.Name, Revision: t.Revision}
+	}
+	return summaries, nil
+}
+
+func (l *local) Info(ctx context.Context) (*api.DeviceInfo, error) {
+	p, err := l.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := p.Info()
+	profile := l.conn.Profile()
+	return &api.DeviceInfo{
+		BatteryLevel:    info.BatteryLevel,
+		State:           deviceStates[info.State],
+		FirmwareVersion: info.FirmwareVersion,
+		Model:           profile.Name,
+		DotsPerLine:     profile.DotsPerLine,
+		Dpi:             profile.DPI,
+	}, nil
+}
+
+var deviceStates = map[printer.DeviceState]api.DeviceState{
+	printer.Disconnected: api.DISCONNECTED,
+	printer.Connecting:   api.CONNECTING,
+	printer.Ready:        api.READY,
+	printer.Busy:         api.BUSY,
+	printer.OutOfPaper:   api.OUTOFPAPER,
+	printer.Reconnecting: api.RECONNECTING,
+}
+
+func (l *local) Close() error {
+	if l.conn != nil {
+		l.conn.Disconnect()
+	}
+	if l.db != nil {
+		return l.db.Close()
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"tomgalvin.uk/phogoprint/api"
+)
+
+// Runs commands through a running server's API
+type remote struct {
+	client *api.ClientWithResponses
+}
+
+func newRemote(server string) (*remote, error) {
+	client, err := api.NewClientWithResponses(strings.TrimSuffix(server, "/") + "/api")
+	if err != nil {
+		return nil, fmt.Errorf("Invalid server URL %q:\n%w", server, err)
+	}
+	return &remote{client: client}, nil
+}
+
+func (r *remote) PrintImage(ctx context.Context, data []byte, options *printFlags) (*printResult, error) {
+	var file openapi_types.File
+	file.InitFromBytes(data, "")
+	res, err := r.client.PrintImageWithResponse(ctx, api.PrintImageJSONRequestBody{
+		ContentType: http.DetectContentType(data),
+		Data:        file,
+		Options:     options.toJson(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't send image to server:\n%w", err)
+	}
+	if res.JSON202 == nil {
+		return nil, unexpected(res.HTTPResponse, res.Body)
+	}
+	return jobResult(res.JSON202), nil
+}
+
+func (r *remote) PrintTemplate(ctx context.Context, u uuid.UUID, params map[string]string, options *printFlags) (*printResult, error) {
+	values := []api.ParameterValue{}
+	for name, value := range params {
+		values = append(values, api.ParameterValue{ParameterName: name, Value: value})
+	}
+	res, err := r.client.PrintTemplateWithResponse(ctx, u.String(), api.PrintTemplateJSONRequestBody{
+		Options:         options.toJson(),
+		ParameterValues: values,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't send template to server:\n%w", err)
+	}
+	switch {
+	case res.JSON202 != nil:
+		return jobResult(res.JSON202), nil
+	case res.JSON422 != nil:
+		reasons := []string{res.JSON422.Reason}
+		if res.JSON422.ParameterErrors != nil {
+			for _, e := range *res.JSON422.ParameterErrors {
+				reasons = append(reasons, fmt.Sprintf("%v: %v", e.Parameter, e.Message))
+			}
+		}
+		return nil, fmt.Errorf("Couldn't print template:\n%v", strings.Join(reasons, "\n"))
+	case res.StatusCode() == http.StatusNotFound:
+		return nil, fmt.Errorf("No template %v", u)
+	default:
+		return nil, unexpected(res.HTTPResponse, res.Body)
+	}
+}
+
+func jobResult(j *api.PrintJob) *printResult {
+	return &printResult{Status: j.Status, Uuid: &j.Uuid, BatchUuid: j.BatchUuid}
+}
+
+func (r *remote) ListTemplates(ctx context.Context) ([]templateSummary, error) {
+	res, err := r.client.ListTemplateWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list templates:\n%w", err)
+	}
+	if res.JSON200 == nil {
+		return nil, unexpected(res.HTTPResponse, res.Body)
+	}
+	summaries := make([]templateSummary, len(*res.JSON200))
+	for i, t := range *res.JSON200 {
+		summaries[i] = templateSummary{Uuid: t.Uuid, Name: t.Name}
+		if t.Revision != nil {
+			summaries[i].Revision = *t.Revision
+		}
+	}
+	return summaries, nil
+}
+
+func (r *remote) Info(ctx context.Context) (*api.DeviceInfo, error) {
+	res, err := r.client.GetPrinterInfoWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get printer info:\n%w", err)
+	}
+	if res.StatusCode() == http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("Server isn't connected to the printer")
+	}
+	if res.JSON200 == nil {
+		return nil, unexpected(res.HTTPResponse, res.Body)
+	}
+	return res.JSON200, nil
+}
+
+func (r *remote) Close() error {
+	return nil
+}
+
+func unexpected(res *http.Response, body []byte) error {
+	return fmt.Errorf("Server responded %v: %v", res.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,181 @@
+// This package loads the server's settings, which can be given in a YAML
+// file, as PHOGOPRINT_* environment variables or as flags. Flags take
+// precedence over environment variables, which take precedence over the
+// file, which takes precedence over the defaults.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The file read when no other is given, if it exists
+const DefaultFile = "phogoprint.yaml"
+
+// Prefix of the environment variable for each flag, e.g. PHOGOPRINT_PORT for
+// -port and PHOGOPRINT_PRINTER_NAME for -printer-name
+const envPrefix = "PHOGOPRINT_"
+
+type Config struct {
+	// HTTP port for the web interface, API and IPP printer
+	Port int `yaml:"port"`
+	// TCP port to accept raw print jobs on, or 0 to not accept them
+	RawPort int `yaml:"rawPort"`
+	// Path of the SQLite database
+	Database string `yaml:"database"`
+	// Directory the web interface is served from
+	StaticDir string `yaml:"staticDir"`
+	// debug, info, warn or error
+	LogLevel string  `yaml:"logLevel"`
+	Printer  Printer `yaml:"printer"`
+}
+
+func Default() Config {
+	return Config{
+		Port:      8080,
+		Database:  "app.db",
+		StaticDir: "resources/web",
+		LogLevel:  "info",
+		Printer:   DefaultPrinter(),
+	}
+}
+
+// Reads the settings from the config file, the environment and the
+// arguments, which are the server's flags. The file is the one given by
+// -config or PHOGOPRINT_CONFIG, or DefaultFile if neither is given.
+func Load(args []string, lookupEnv func(string) (string, bool), output io.Writer) (*Config, error) {
+	c := Default()
+	var file string
+	fs := flag.NewFlagSet("phogoprint", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&file, "config", "", "YAML file to read settings from (default "+DefaultFile+", if it exists)")
+	c.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(output, "Usage of phogoprint:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(output, "\nEach flag can also be given as an environment variable, e.g. %v for -printer-name\n", envName("printer-name"))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// the flags are parsed straight away to find the config file, then given
+	// again after everything else, so they override it
+	given := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		given[f.Name] = f.Value.String()
+	})
+	c = Default()
+
+	if env, ok := lookupEnv(envPrefix + "CONFIG"); ok && file == "" {
+		file = env
+	}
+	if err := c.readFile(file); err != nil {
+		return nil, err
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		name := envName(f.Name)
+		if value, ok := lookupEnv(name); ok && f.Name != "config" && err == nil {
+			if setErr := fs.Set(f.Name, value); setErr != nil {
+				err = fmt.Errorf("Invalid %v:\n%w", name, setErr)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range given {
+		fs.Set(name, value)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+func (c *Config) register(fs *flag.FlagSet) {
+	fs.IntVar(&c.Port, "port", c.Port, "HTTP port for the web interface, API and IPP printer")
+	fs.IntVar(&c.RawPort, "raw-port", c.RawPort, "TCP port to accept raw PNG, PBM and ESC/POS print jobs on, like a network printer's port 9100, or 0 to not accept them")
+	fs.StringVar(&c.Database, "database", c.Database, "Path of the SQLite database")
+	fs.StringVar(&c.StaticDir, "static-dir", c.StaticDir, "Directory to serve the web interface from")
+	fs.StringVar(&c.LogLevel, "log-level", c.LogLevel, "Lowest level of log messages to show: debug, info, warn or error")
+	c.Printer.register(fs)
+}
+
+// Reads settings from a YAML file. A missing default file is ignored, but a
+// file which was asked for has to exist.
+func (c *Config) readFile(path string) error {
+	asked := path != ""
+	if !asked {
+		path = DefaultFile
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !asked {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Couldn't read config file:\n%w", err)
+	}
+
+	d := yaml.NewDecoder(bytes.NewReader(data))
+	// misspelt settings would otherwise be silently ignored
+	d.KnownFields(true)
+	if err := d.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("Couldn't read config file %v:\n%w", path, err)
+	}
+	return nil
+}
+
+// Checks every setting, returning all the problems with them at once
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("Port must be between 1 and 65535, was %v", c.Port))
+	}
+	if c.RawPort < 0 || c.RawPort > 65535 {
+		errs = append(errs, fmt.Errorf("Raw port must be between 1 and 65535, or 0 to turn it off, was %v", c.RawPort))
+	} else if c.RawPort != 0 && c.RawPort == c.Port {
+		errs = append(errs, fmt.Errorf("Raw port must be different from the HTTP port"))
+	}
+	if c.Database == "" {
+		errs = append(errs, fmt.Errorf("Database path must be given"))
+	}
+	if c.StaticDir == "" {
+		errs = append(errs, fmt.Errorf("Static directory must be given"))
+	}
+	if _, err := c.Level(); err != nil {
+		errs = append(errs, fmt.Errorf("Log level must be debug, info, warn or error, was %q", c.LogLevel))
+	}
+	errs = append(errs, c.Printer.validate()...)
+	return errors.Join(errs...)
+}
+
+func (c *Config) Level() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(c.LogLevel))
+	return level, err
+}
+
+// The address for the HTTP server to listen on
+func (c *Config) Addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// The address for the raw print listener to listen on, if it's turned on
+func (c *Config) RawAddr() string {
+	return fmt.Sprintf(":%d", c.RawPort)
+}
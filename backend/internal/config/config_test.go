@@ -0,0 +1,105 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func aFile(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "phogoprint.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestFlagsOverrideEnvironmentWhichOverridesFile(t *testing.T) {
+	path := aFile(t, `
+port: 9000
+logLevel: debug
+printer:
+  name: Q30
+  pollInterval: 5s
+`)
+	c, err := Load([]string{"-port", "9002"}, env(map[string]string{
+		"PHOGOPRINT_CONFIG":       path,
+		"PHOGOPRINT_PORT":         "9001",
+		"PHOGOPRINT_PRINTER_NAME": "M02",
+	}), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Port != 9002 {
+		t.Errorf("Expected the flag's port, got %v", c.Port)
+	}
+	if c.Printer.Name != "M02" {
+		t.Errorf("Expected the environment's printer name, got %v", c.Printer.Name)
+	}
+	if c.Printer.PollInterval != 5*time.Second || c.LogLevel != "debug" {
+		t.Errorf("Expected the file's poll interval and log level, got %v and %v", c.Printer.PollInterval, c.LogLevel)
+	}
+	if c.Database != "app.db" || c.StaticDir != "resources/web" {
+		t.Errorf("Expected defaults for settings which weren't given, got %v and %v", c.Database, c.StaticDir)
+	}
+	if c.Printer.SimulatorOptions().Timeouts.PollInterval != 5*time.Second {
+		t.Errorf("Expected the poll interval to be passed to the printer")
+	}
+}
+
+func TestConfigFileOnlyHasToExistWhenGiven(t *testing.T) {
+	if _, err := Load(nil, env(nil), io.Discard); err != nil {
+		t.Errorf("Expected defaults without a config file, got %v", err)
+	}
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if _, err := Load([]string{"-config", missing}, env(nil), io.Discard); err == nil {
+		t.Errorf("Expected a missing config file to be reported")
+	}
+}
+
+func TestInvalidSettingsAreReported(t *testing.T) {
+	path := aFile(t, `
+port: 0
+printer:
+  address: nowhere
+  pollInterval: 10ms
+`)
+	_, err := Load([]string{"-config", path}, env(nil), io.Discard)
+	if err == nil {
+		t.Fatal("Expected invalid settings to be turned away")
+	}
+	for _, s := range []string{"Port", "nowhere", "Poll interval"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("Expected %q to be reported, got %v", s, err)
+		}
+	}
+
+	path = aFile(t, "prot: 9000\n")
+	if _, err := Load([]string{"-config", path}, env(nil), io.Discard); err == nil {
+		t.Errorf("Expected an unknown setting to be turned away")
+	}
+
+	_, err = Load(nil, env(map[string]string{"PHOGOPRINT_PORT": "eighty"}), io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "PHOGOPRINT_PORT") {
+		t.Errorf("Expected the bad environment variable to be named, got %v", err)
+	}
+}
+
+func TestPrinterAddressIsParsed(t *testing.T) {
+	c := Default()
+	c.Printer.Address = "01:23:45:67:89:ab"
+	if err := c.Validate(); err != nil {
+		t.Errorf("Expected a MAC address to be accepted, got %v", err)
+	}
+}
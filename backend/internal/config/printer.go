@@ -0,0 +1,96 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// How to reach the printer
+type Printer struct {
+	// Bluetooth name of the printer, which also decides which model it's
+	// treated as
+	Name string `yaml:"name"`
+	// Bluetooth address to connect to instead of scanning for the name
+	Address string `yaml:"address"`
+	// Use a simulated printer instead of one over Bluetooth
+	Simulate bool `yaml:"simulate"`
+	// Directory to save labels printed by the simulated printer to
+	SimulatorOutput string `yaml:"simulatorOutput"`
+	// How often to ask the printer for its battery level and paper status
+	PollInterval time.Duration `yaml:"pollInterval"`
+}
+
+func DefaultPrinter() Printer {
+	return Printer{
+		Name:         "T02",
+		PollInterval: printer.DefaultTimeouts().PollInterval,
+	}
+}
+
+func (p *Printer) register(fs *flag.FlagSet) {
+	p.RegisterConnection(fs)
+	fs.DurationVar(&p.PollInterval, "poll-interval", p.PollInterval, "How often to ask the printer for its battery level and paper status")
+}
+
+// Registers the flags for which printer to use, so commands which use the
+// printer directly can take them too
+func (p *Printer) RegisterConnection(fs *flag.FlagSet) {
+	fs.StringVar(&p.Name, "printer-name", p.Name, "Bluetooth name of the printer to connect to, which also decides which model it's treated as")
+	fs.StringVar(&p.Address, "printer-address", p.Address, "Bluetooth address of the printer, to connect to it without scanning for its name first")
+	fs.BoolVar(&p.Simulate, "simulate", p.Simulate, "Use a simulated printer instead of connecting to one over Bluetooth")
+	fs.StringVar(&p.SimulatorOutput, "simulator-output", p.SimulatorOutput, "Directory to save labels printed by the simulated printer to")
+}
+
+func (p *Printer) validate() []error {
+	var errs []error
+	if p.Name == "" {
+		errs = append(errs, fmt.Errorf("Printer name must be given"))
+	}
+	if p.Address != "" {
+		if _, err := printer.ParseAddress(p.Address); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if p.PollInterval < time.Second {
+		errs = append(errs, fmt.Errorf("Poll interval must be at least 1s, was %v", p.PollInterval))
+	}
+	return errs
+}
+
+func (p *Printer) Timeouts() printer.Timeouts {
+	t := printer.DefaultTimeouts()
+	t.PollInterval = p.PollInterval
+	return t
+}
+
+func (p *Printer) SimulatorOptions() printer.SimulatorOptions {
+	options := printer.DefaultSimulatorOptions()
+	options.Name = p.Name
+	options.OutputDir = p.SimulatorOutput
+	options.Timeouts = p.Timeouts()
+	return options
+}
+
+// Finds the printer over Bluetooth, by its address if it's given or by
+// scanning for its name otherwise
+func (p *Printer) Bluetooth() (*printer.BluetoothConnection, error) {
+	var bt *printer.BluetoothConnection
+	var err error
+	if p.Address != "" {
+		address, parseErr := printer.ParseAddress(p.Address)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		bt, err = printer.FromBluetoothAddress(p.Name, address)
+	} else {
+		bt, err = printer.FromBluetoothName(p.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	bt.Timeouts = p.Timeouts()
+	return bt, nil
+}
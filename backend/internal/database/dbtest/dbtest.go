@@ -0,0 +1,64 @@
+// Databases for tests. These are kept out of the database package, so the
+// testing package isn't built into the server.
+package dbtest
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"tomgalvin.uk/phogoprint/internal/database"
+)
+
+// Creates a database for a test in a temporary directory, with every
+// migration applied, and returns the data source name to open it with
+func Create(t testing.TB) string {
+	t.Helper()
+	dsn := create(t)
+	if _, _, err := database.Migrate(open(t, dsn), Migrations(t)); err != nil {
+		t.Fatalf("Couldn't initialise database: %v", err)
+	}
+	return dsn
+}
+
+// Opens a new database for a test, with every migration applied. It's closed
+// when the test finishes.
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+	return open(t, Create(t))
+}
+
+// Opens a new database for a test without any tables in it. It's closed when
+// the test finishes.
+func OpenEmpty(t testing.TB) *sql.DB {
+	t.Helper()
+	return open(t, create(t))
+}
+
+// The migrations in resources/sql/migrations, which are found relative to
+// this file so they can be read from any package's tests
+func Migrations(t testing.TB) []database.Migration {
+	t.Helper()
+	_, file, _, _ := runtime.Caller(0)
+	migrations, err := database.ReadMigrations(os.DirFS(filepath.Join(filepath.Dir(file), "../../../resources/sql/migrations")))
+	if err != nil {
+		t.Fatalf("Couldn't read migrations: %v", err)
+	}
+	return migrations
+}
+
+func create(t testing.TB) string {
+	return "file:" + filepath.Join(t.TempDir(), "test.db") + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+func open(t testing.TB, dsn string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("Couldn't open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A change to the schema, read from a file named after its version, e.g.
+// 0002_add_print_history.sql
+type Migration struct {
+	Version int
+	Name    string
+	Sql     string
+}
+
+// Reads the .sql files in a directory as migrations, checking they're
+// numbered from 1 with no gaps, so they're always applied in the same order
+func ReadMigrations(dir fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(dir, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]Migration, 0, len(files))
+	for _, file := range files {
+		number, _, _ := strings.Cut(file, "_")
+		version, err := strconv.Atoi(number)
+		if err != nil {
+			return nil, fmt.Errorf(`Migration file "%s" doesn't start with a version number`, file)
+		}
+		contents, err := fs.ReadFile(dir, file)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read migration %s:\n%w", file, err)
+		}
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    strings.TrimSuffix(path.Base(file), ".sql"),
+			Sql:     string(contents),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("Expected migration %v, found %s", i+1, m.Name)
+		}
+	}
+	return migrations, nil
+}
+
+// Brings the database's schema up to date by applying any migrations it
+// hasn't had yet, each one in its own transaction. Returns the versions the
+// schema was and is now at. A database with a newer schema than the
+// migrations know about is refused, rather than risk writing to it in a way
+// the newer version doesn't expect.
+func Migrate(db *sql.DB, migrations []Migration) (int, int, error) {
+	ctx := context.Background()
+	// foreign keys can only be turned off outside a transaction, so every
+	// migration runs on the same connection, which has them turned off. That
+	// lets migrations rebuild tables which other tables refer to.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return 0, 0, fmt.Errorf("Couldn't turn off foreign keys for migrating:\n%w", err)
+	}
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+
+	from, err := currentVersion(ctx, conn)
+	if err != nil {
+		return 0, 0, err
+	}
+	latest := len(migrations)
+	if from > latest {
+		return from, from, fmt.Errorf("Database schema is at version %v, which is newer than this version of phogoprint knows about (%v)", from, latest)
+	}
+
+	for _, m := range migrations[from:] {
+		if err := apply(ctx, conn, m); err != nil {
+			return from, m.Version - 1, err
+		}
+	}
+	return from, latest, nil
+}
+
+func currentVersion(ctx context.Context, conn *sql.Conn) (int, error) {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_version(
+			version INT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)`)
+	if err != nil {
+		return 0, fmt.Errorf("Couldn't create schema version table:\n%w", err)
+	}
+
+	var version int
+	if err := conn.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("Couldn't read schema version:\n%w", err)
+	}
+	if version > 0 {
+		return version, nil
+	}
+
+	// databases from before there were migrations were created from
+	// schema.sql, which is kept as the first migration, so they're already at
+	// version 1
+	var tables int
+	if err := conn.QueryRowContext(ctx, "SELECT COUNT(1) FROM sqlite_master WHERE type = 'table' AND name = 'template'").Scan(&tables); err != nil {
+		return 0, fmt.Errorf("Couldn't check for existing tables:\n%w", err)
+	}
+	if tables > 0 {
+		_, err := conn.ExecContext(ctx, "INSERT INTO schema_version(version, name, applied_at) VALUES (1, 'existing', ?)", time.Now())
+		if err != nil {
+			return 0, fmt.Errorf("Couldn't record schema version of existing database:\n%w", err)
+		}
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func apply(ctx context.Context, conn *sql.Conn, m Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.Sql); err != nil {
+		return fmt.Errorf("Couldn't apply migration %s:\n%w", m.Name, err)
+	}
+	// foreign keys aren't checked while migrating, so check nothing was left
+	// pointing at a row which doesn't exist
+	rows, err := tx.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return fmt.Errorf("Couldn't check foreign keys after migration %s:\n%w", m.Name, err)
+	}
+	broken := rows.Next()
+	rows.Close()
+	if broken {
+		return fmt.Errorf("Migration %s broke foreign key constraints", m.Name)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO schema_version(version, name, applied_at) VALUES (?, ?, ?)", m.Version, m.Name, time.Now())
+	if err != nil {
+		return fmt.Errorf("Couldn't record migration %s:\n%w", m.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("Couldn't commit migration %s:\n%w", m.Name, err)
+	}
+	return nil
+}
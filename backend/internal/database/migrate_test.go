@@ -0,0 +1,150 @@
+package database_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"tomgalvin.uk/phogoprint/internal/database"
+	"tomgalvin.uk/phogoprint/internal/database/dbtest"
+)
+
+func aDatabase(t *testing.T) *sql.DB {
+	return dbtest.OpenEmpty(t)
+}
+
+func someMigrations(t *testing.T, files map[string]string) []database.Migration {
+	dir := fstest.MapFS{}
+	for name, contents := range files {
+		dir[name] = &fstest.MapFile{Data: []byte(contents)}
+	}
+	migrations, err := database.ReadMigrations(dir)
+	if err != nil {
+		t.Fatalf("Couldn't read migrations: %v", err)
+	}
+	return migrations
+}
+
+func TestMigrationsAreAppliedOnce(t *testing.T) {
+	db := aDatabase(t)
+	migrations := someMigrations(t, map[string]string{
+		"0001_initial.sql": "CREATE TABLE a(id INTEGER PRIMARY KEY);",
+		"0002_add_b.sql":   "CREATE TABLE b(id INTEGER PRIMARY KEY, a_id INT REFERENCES a(id));",
+	})
+
+	if from, to, err := database.Migrate(db, migrations[:1]); err != nil || from != 0 || to != 1 {
+		t.Fatalf("Expected to migrate from 0 to 1, got %v to %v, %v", from, to, err)
+	}
+	if from, to, err := database.Migrate(db, migrations); err != nil || from != 1 || to != 2 {
+		t.Fatalf("Expected to migrate from 1 to 2, got %v to %v, %v", from, to, err)
+	}
+	if from, to, err := database.Migrate(db, migrations); err != nil || from != 2 || to != 2 {
+		t.Fatalf("Expected nothing to migrate, got %v to %v, %v", from, to, err)
+	}
+}
+
+func TestFailedMigrationIsRolledBack(t *testing.T) {
+	db := aDatabase(t)
+	migrations := someMigrations(t, map[string]string{
+		"0001_initial.sql": "CREATE TABLE a(id INTEGER PRIMARY KEY);",
+		"0002_broken.sql":  "CREATE TABLE b(id INTEGER PRIMARY KEY); INSERT INTO nowhere VALUES (1);",
+	})
+
+	if _, to, err := database.Migrate(db, migrations); err == nil || to != 1 {
+		t.Fatalf("Expected migration 2 to fail and leave the schema at 1, got %v, %v", to, err)
+	}
+	var tables int
+	db.QueryRow("SELECT COUNT(1) FROM sqlite_master WHERE name = 'b'").Scan(&tables)
+	if tables != 0 {
+		t.Errorf("Expected table created by failed migration to be rolled back")
+	}
+}
+
+func TestNewerSchemaIsRefused(t *testing.T) {
+	db := aDatabase(t)
+	migrations := someMigrations(t, map[string]string{
+		"0001_initial.sql": "CREATE TABLE a(id INTEGER PRIMARY KEY);",
+		"0002_add_b.sql":   "CREATE TABLE b(id INTEGER PRIMARY KEY);",
+	})
+	if _, _, err := database.Migrate(db, migrations); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := database.Migrate(db, migrations[:1]); err == nil {
+		t.Errorf("Expected database from a newer version to be refused")
+	}
+}
+
+// Databases from before there were migrations were made by running
+// schema.sql, kept in testdata as it was
+func TestExistingDatabaseIsMigratedFromVersion1(t *testing.T) {
+	schema, err := os.ReadFile("testdata/schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrations := dbtest.Migrations(t)
+	if migrations[0].Sql != string(schema) {
+		t.Fatalf("Expected the first migration to be the old schema.sql")
+	}
+
+	db := aDatabase(t)
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+		INSERT INTO template(id, uuid, name, created_at, landscape, min_size, max_size)
+		VALUES (1, '5e2f55fe-8ebf-4683-8d6b-fe3feaebe653', 'Shelf label', '2024-01-01', 0, 50, 100);
+		INSERT INTO template_parameter(template_id, name, max_length) VALUES (1, 'sku', 8);
+		INSERT INTO template_text(template_id, text, x, y, width, height, font_id, font_size)
+		VALUES (1, '$sku', 0, 0, 100, 20, 1, 12);
+		-- left behind by a template which was deleted, back when foreign keys
+		-- weren't turned on
+		PRAGMA foreign_keys = OFF;
+		INSERT INTO template_parameter(template_id, name, max_length) VALUES (2, 'gone', 0);
+		PRAGMA foreign_keys = ON;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from, to, err := database.Migrate(db, migrations)
+	if err != nil || from != 1 || to != len(migrations) {
+		t.Fatalf("Expected to migrate from 1 to %v, got %v to %v, %v", len(migrations), from, to, err)
+	}
+	var parameters, texts int
+	// parameters could be left blank before they had types, so they stay optional
+	db.QueryRow("SELECT COUNT(1) FROM template_parameter WHERE type = 'STRING' AND required = 0").Scan(&parameters)
+	db.QueryRow("SELECT COUNT(1) FROM template_text WHERE font_id = 1 AND align = 'LEFT'").Scan(&texts)
+	if parameters != 1 || texts != 1 {
+		t.Errorf("Expected the template's parameter and text to be kept, got %v and %v", parameters, texts)
+	}
+	var family string
+	db.QueryRow("SELECT family FROM font WHERE id = 1").Scan(&family)
+	if family != "Go" {
+		t.Errorf("Expected built-in fonts to have their family filled in, got %q", family)
+	}
+
+	// templates delete their children now
+	if _, err := db.Exec("DELETE FROM template WHERE id = 1"); err != nil {
+		t.Fatal(err)
+	}
+	db.QueryRow("SELECT COUNT(1) FROM template_parameter").Scan(&parameters)
+	if parameters != 0 {
+		t.Errorf("Expected deleting the template to delete its parameters")
+	}
+}
+
+func TestMigrationsMustBeNumberedInOrder(t *testing.T) {
+	_, err := database.ReadMigrations(fstest.MapFS{
+		"0001_initial.sql": {Data: []byte("")},
+		"0003_skipped.sql": {Data: []byte("")},
+	})
+	if err == nil {
+		t.Errorf("Expected a gap in the migrations to be rejected")
+	}
+}
+
+func TestRepositoryMigrationsApply(t *testing.T) {
+	if _, _, err := database.Migrate(aDatabase(t), dbtest.Migrations(t)); err != nil {
+		t.Errorf("Couldn't apply migrations: %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package database
+
+import (
+	"github.com/ncruces/go-sqlite3"
+	"github.com/tetratelabs/wazero"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+)
+
+// SQLite runs as WebAssembly. wazero's compiler miscompiles copying a value
+// which follows a large one in a row on some amd64 machines, so the value
+// after a label's image was written as zeros, or the insert crashed, for one
+// in every 16 byte offsets. The interpreter doesn't, and is fast enough for a
+// label printer's database.
+func init() {
+	sqlite3.RuntimeConfig = wazero.NewRuntimeConfigInterpreter().WithMemoryLimitPages(4096)
+}
@@ -0,0 +1,32 @@
+package database_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"tomgalvin.uk/phogoprint/internal/database/dbtest"
+)
+
+func TestValuesAfterALargeBlobAreKept(t *testing.T) {
+	db := dbtest.OpenEmpty(t)
+	if _, err := db.Exec(`CREATE TABLE row(id INTEGER PRIMARY KEY, name TEXT, image BLOB, created_at TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	image := bytes.Repeat([]byte{0xaa}, 40_000)
+	// the name moves the image and what's after it along by one byte each time
+	for n := 0; n < 32; n++ {
+		name := strings.Repeat("a", n)
+		if _, err := db.Exec(`INSERT INTO row(id, name, image, created_at) VALUES (?, ?, ?, '2026-01-01')`, n, name, image); err != nil {
+			t.Fatalf("Couldn't insert row %v: %v", n, err)
+		}
+		var createdAt string
+		var saved []byte
+		if err := db.QueryRow(`SELECT image, created_at FROM row WHERE id = ?`, n).Scan(&saved, &createdAt); err != nil {
+			t.Fatal(err)
+		}
+		if createdAt != "2026-01-01" || !bytes.Equal(saved, image) {
+			t.Errorf("Expected row %v to be unchanged, created_at was %q", n, createdAt)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// This package implements enough of the Internet Printing Protocol for
+// desktops to use the printer as a driverless printer: they can ask what the
+// printer supports, send it raster or PNG documents, and list its jobs.
+package ipp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Delimiter tags, which start each group of attributes
+const (
+	operationGroup   byte = 0x01
+	jobGroup         byte = 0x02
+	endOfAttributes  byte = 0x03
+	printerGroup     byte = 0x04
+	unsupportedGroup byte = 0x05
+)
+
+// Value tags, which say how an attribute's value is encoded
+const (
+	tagInteger         byte = 0x21
+	tagBoolean         byte = 0x22
+	tagEnum            byte = 0x23
+	tagResolution      byte = 0x32
+	tagRange           byte = 0x33
+	tagBeginCollection byte = 0x34
+	tagEndCollection   byte = 0x37
+	tagText            byte = 0x41
+	tagName            byte = 0x42
+	tagKeyword         byte = 0x44
+	tagUri             byte = 0x45
+	tagCharset         byte = 0x47
+	tagLanguage        byte = 0x48
+	tagMimeType        byte = 0x49
+	tagMemberName      byte = 0x4a
+)
+
+// An IPP request or response. Requests have an operation as their code, and
+// responses a status.
+type Message struct {
+	Version   [2]byte
+	Code      uint16
+	RequestId uint32
+	Groups    []Group
+	// Anything after the attributes, which is the document being printed
+	Data io.Reader
+}
+
+type Group struct {
+	Tag        byte
+	Attributes []Attribute
+}
+
+type Attribute struct {
+	Name   string
+	Values []Value
+}
+
+// A single value, kept as it's encoded. Collections are kept as their
+// begin-collection value followed by the member names and values and the
+// end-collection value, which is how they're encoded.
+type Value struct {
+	Tag  byte
+	Data []byte
+}
+
+// Finds an attribute in the first group with the tag which has it, or nil
+func (m *Message) Attribute(group byte, name string) *Attribute {
+	for _, g := range m.Groups {
+		if g.Tag != group {
+			continue
+		}
+		for i := range g.Attributes {
+			if g.Attributes[i].Name == name {
+				return &g.Attributes[i]
+			}
+		}
+	}
+	return nil
+}
+
+// The attribute's first value as a string, or "" if there isn't one
+func (a *Attribute) String() string {
+	if a == nil || len(a.Values) == 0 {
+		return ""
+	}
+	return string(a.Values[0].Data)
+}
+
+// The attribute's first value as an integer or enum, or false if it isn't one
+func (a *Attribute) Int() (int, bool) {
+	if a == nil || len(a.Values) == 0 || len(a.Values[0].Data) != 4 {
+		return 0, false
+	}
+	return int(int32(binary.BigEndian.Uint32(a.Values[0].Data))), true
+}
+
+// The attribute's values as strings, for attributes with several keywords
+func (a *Attribute) Strings() []string {
+	if a == nil {
+		return nil
+	}
+	s := make([]string, len(a.Values))
+	for i, v := range a.Values {
+		s[i] = string(v.Data)
+	}
+	return s
+}
+
+// Reads a message's header and attributes. The rest of the reader is left as
+// the message's data.
+func Decode(r io.Reader) (*Message, error) {
+	br := bufio.NewReader(r)
+	m := &Message{Data: br}
+	var header [8]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("Couldn't read IPP header:\n%w", err)
+	}
+	copy(m.Version[:], header[0:2])
+	m.Code = binary.BigEndian.Uint16(header[2:4])
+	m.RequestId = binary.BigEndian.Uint32(header[4:8])
+
+	var group *Group
+	var attribute *Attribute
+	for {
+		tag, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("IPP attributes ended early:\n%w", err)
+		}
+		if tag == endOfAttributes {
+			return m, nil
+		}
+		if tag < 0x10 {
+			m.Groups = append(m.Groups, Group{Tag: tag})
+			group, attribute = &m.Groups[len(m.Groups)-1], nil
+			continue
+		}
+		if group == nil {
+			return nil, errors.New("IPP attribute isn't in a group")
+		}
+
+		name, err := readString(br)
+		if err != nil {
+			return nil, err
+		}
+		data, err := readString(br)
+		if err != nil {
+			return nil, err
+		}
+		value := Value{Tag: tag, Data: []byte(data)}
+		if name == "" {
+			// another value of the attribute before, including the parts of
+			// a collection
+			if attribute == nil {
+				return nil, errors.New("IPP value has no attribute")
+			}
+			attribute.Values = append(attribute.Values, value)
+		} else {
+			group.Attributes = append(group.Attributes, Attribute{Name: name, Values: []Value{value}})
+			attribute = &group.Attributes[len(group.Attributes)-1]
+		}
+	}
+}
+
+func readString(r io.Reader) (string, error) {
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return "", fmt.Errorf("IPP attributes ended early:\n%w", err)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return "", fmt.Errorf("IPP attributes ended early:\n%w", err)
+	}
+	return string(data), nil
+}
+
+// Writes a message's header and attributes, followed by its data if it has
+// any
+func (m *Message) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.Write(m.Version[:])
+	binary.Write(bw, binary.BigEndian, m.Code)
+	binary.Write(bw, binary.BigEndian, m.RequestId)
+	for _, g := range m.Groups {
+		bw.WriteByte(g.Tag)
+		for _, a := range g.Attributes {
+			for i, v := range a.Values {
+				name := a.Name
+				if i > 0 {
+					name = ""
+				}
+				bw.WriteByte(v.Tag)
+				writeString(bw, name)
+				writeString(bw, string(v.Data))
+			}
+		}
+	}
+	bw.WriteByte(endOfAttributes)
+	if m.Data != nil {
+		if _, err := io.Copy(bw, m.Data); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func writeString(w *bufio.Writer, s string) {
+	binary.Write(w, binary.BigEndian, uint16(len(s)))
+	w.WriteString(s)
+}
+
+// Values of each type, for building responses
+
+func stringValues(tag byte, s ...string) []Value {
+	vs := make([]Value, len(s))
+	for i := range s {
+		vs[i] = Value{Tag: tag, Data: []byte(s[i])}
+	}
+	return vs
+}
+
+func intValues(tag byte, n ...int) []Value {
+	vs := make([]Value, len(n))
+	for i := range n {
+		vs[i] = Value{Tag: tag, Data: binary.BigEndian.AppendUint32(nil, uint32(int32(n[i])))}
+	}
+	return vs
+}
+
+func boolValue(b bool) []Value {
+	if b {
+		return []Value{{Tag: tagBoolean, Data: []byte{1}}}
+	}
+	return []Value{{Tag: tagBoolean, Data: []byte{0}}}
+}
+
+func rangeValue(lower, upper int) []Value {
+	data := binary.BigEndian.AppendUint32(nil, uint32(int32(lower)))
+	return []Value{{Tag: tagRange, Data: binary.BigEndian.AppendUint32(data, uint32(int32(upper)))}}
+}
+
+// A resolution in dots per inch
+func resolutionValue(dpi int) []Value {
+	data := binary.BigEndian.AppendUint32(nil, uint32(dpi))
+	data = binary.BigEndian.AppendUint32(data, uint32(dpi))
+	return []Value{{Tag: tagResolution, Data: append(data, 3)}}
+}
+
+// A collection, as the values which encode it. Members are encoded in the
+// order they're given.
+func collectionValue(members ...Attribute) []Value {
+	vs := []Value{{Tag: tagBeginCollection}}
+	for _, m := range members {
+		for i, v := range m.Values {
+			if i == 0 {
+				vs = append(vs, Value{Tag: tagMemberName, Data: []byte(m.Name)})
+			}
+			vs = append(vs, v)
+		}
+	}
+	return append(vs, Value{Tag: tagEndCollection})
+}
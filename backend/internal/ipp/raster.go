@@ -0,0 +1,257 @@
+package ipp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+)
+
+// PWG raster colour spaces, which are the same as CUPS raster's
+const (
+	pwgWhite = 0
+	pwgRgb   = 1
+	pwgBlack = 3
+	pwgSGray = 18
+	pwgSRgb  = 19
+)
+
+// URF colour spaces
+const (
+	urfGray = 0
+	urfSRgb = 1
+)
+
+// What a document's pages are allowed to add up to. Raster lines compress so
+// well that a small document could otherwise decode to gigabytes, so these
+// are checked before each page is decoded.
+type PageLimits struct {
+	MaxWidth  int
+	MaxHeight int
+	MaxPages  int
+	// Total across every page
+	MaxPixels int
+}
+
+// Wrapped by the error for a document with too many pages or pixels
+var errDocumentTooLarge = errors.New("Document is too large")
+
+var (
+	pwgSync = []byte("RaS2")
+	urfSync = []byte("UNIRAST\x00")
+)
+
+// Reads every page of a PWG raster document (image/pwg-raster). Only the
+// formats the printer advertises are understood: 1 bit black, and 8 bit grey
+// and 24 bit RGB.
+func DecodePwgRaster(r io.Reader, limits PageLimits) ([]image.Image, error) {
+	br := bufio.NewReader(r)
+	sync := make([]byte, len(pwgSync))
+	if _, err := io.ReadFull(br, sync); err != nil || !bytes.Equal(sync, pwgSync) {
+		return nil, errors.New("Document isn't PWG raster")
+	}
+
+	pages := []image.Image{}
+	header := make([]byte, 1796)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			if errors.Is(err, io.EOF) && len(pages) > 0 {
+				return pages, nil
+			}
+			return nil, fmt.Errorf("Couldn't read header of page %v:\n%w", len(pages)+1, err)
+		}
+		width := int(binary.BigEndian.Uint32(header[372:]))
+		height := int(binary.BigEndian.Uint32(header[376:]))
+		bitsPerPixel := int(binary.BigEndian.Uint32(header[388:]))
+		bytesPerLine := int(binary.BigEndian.Uint32(header[392:]))
+		colorSpace := binary.BigEndian.Uint32(header[400:])
+		if err := limits.check(pages, width, height); err != nil {
+			return nil, fmt.Errorf("Couldn't read page %v:\n%w", len(pages)+1, err)
+		}
+
+		var toGray func([]byte, int, int) *image.Gray
+		white := byte(0xff)
+		switch {
+		case colorSpace == pwgBlack && bitsPerPixel == 1:
+			toGray, white = blackToGray, 0
+		case (colorSpace == pwgSGray || colorSpace == pwgWhite) && bitsPerPixel == 8:
+			toGray = grayToGray
+		case (colorSpace == pwgSRgb || colorSpace == pwgRgb) && bitsPerPixel == 24:
+			toGray = rgbToGray
+		default:
+			return nil, fmt.Errorf("Page %v has unsupported colour space %v with %v bits per pixel", len(pages)+1, colorSpace, bitsPerPixel)
+		}
+		if bytesPerLine != (width*bitsPerPixel+7)/8 {
+			return nil, fmt.Errorf("Page %v has %v bytes per line, which doesn't match its width", len(pages)+1, bytesPerLine)
+		}
+
+		data, err := decodeLines(br, bytesPerLine, max(bitsPerPixel/8, 1), height, white)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read page %v:\n%w", len(pages)+1, err)
+		}
+		pages = append(pages, toGray(data, width, height))
+	}
+}
+
+// Reads every page of an Apple raster document (image/urf), which is like PWG
+// raster with smaller headers. Only 8 bit grey and 24 bit RGB are understood.
+func DecodeUrf(r io.Reader, limits PageLimits) ([]image.Image, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(urfSync)+4)
+	if _, err := io.ReadFull(br, header); err != nil || !bytes.Equal(header[:len(urfSync)], urfSync) {
+		return nil, errors.New("Document isn't URF raster")
+	}
+
+	// the page count in the file header can be 0 when it isn't known, so
+	// pages are read until the end of the document instead
+	pages := []image.Image{}
+	header = make([]byte, 32)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			if errors.Is(err, io.EOF) && len(pages) > 0 {
+				return pages, nil
+			}
+			return nil, fmt.Errorf("Couldn't read header of page %v:\n%w", len(pages)+1, err)
+		}
+		bitsPerPixel, colorSpace := int(header[0]), header[1]
+		width := int(binary.BigEndian.Uint32(header[12:]))
+		height := int(binary.BigEndian.Uint32(header[16:]))
+		if err := limits.check(pages, width, height); err != nil {
+			return nil, fmt.Errorf("Couldn't read page %v:\n%w", len(pages)+1, err)
+		}
+
+		var toGray func([]byte, int, int) *image.Gray
+		switch {
+		case colorSpace == urfGray && bitsPerPixel == 8:
+			toGray = grayToGray
+		case colorSpace == urfSRgb && bitsPerPixel == 24:
+			toGray = rgbToGray
+		default:
+			return nil, fmt.Errorf("Page %v has unsupported colour space %v with %v bits per pixel", len(pages)+1, colorSpace, bitsPerPixel)
+		}
+
+		data, err := decodeLines(br, width*bitsPerPixel/8, bitsPerPixel/8, height, 0xff)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read page %v:\n%w", len(pages)+1, err)
+		}
+		pages = append(pages, toGray(data, width, height))
+	}
+}
+
+// Checks a page of the given size can be decoded after the pages which
+// already have been. The sizes are checked on their own first, as their
+// product can overflow, and 1 bit pages are unpacked to a byte per pixel.
+func (l PageLimits) check(pages []image.Image, width, height int) error {
+	if width > l.MaxWidth {
+		return fmt.Errorf("Page is %v pixels wide, but at most %v are accepted", width, l.MaxWidth)
+	}
+	if height > l.MaxHeight {
+		return fmt.Errorf("Page is %v pixels long, but at most %v are accepted", height, l.MaxHeight)
+	}
+	if len(pages) >= l.MaxPages {
+		return fmt.Errorf("%w: it has more than %v pages", errDocumentTooLarge, l.MaxPages)
+	}
+	pixels := width * height
+	for _, page := range pages {
+		pixels += page.Bounds().Dx() * page.Bounds().Dy()
+	}
+	if pixels > l.MaxPixels {
+		return fmt.Errorf("%w: its pages have more than %v pixels", errDocumentTooLarge, l.MaxPixels)
+	}
+	return nil
+}
+
+// Decodes a page of raster lines, which PWG raster and URF compress the same
+// way. Each line starts with how many times it's repeated, less one. The
+// line's pixels then come in runs, each starting with a count: 0-127 repeats
+// the next pixel that many times plus one, 129-255 is followed by 257 minus
+// that many pixels as they are, and 128 fills the rest of the line with
+// white. Pixels less than a byte are packed into bytes, which are treated as
+// one pixel.
+func decodeLines(r *bufio.Reader, lineBytes, pixelBytes, height int, white byte) ([]byte, error) {
+	if lineBytes <= 0 || height <= 0 {
+		return nil, errors.New("Page has no pixels")
+	}
+	data := make([]byte, lineBytes*height)
+	for y := 0; y < height; {
+		repeat, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("Raster data is too short:\n%w", err)
+		}
+		line := data[y*lineBytes : (y+1)*lineBytes]
+		for x := 0; x < lineBytes; {
+			count, err := r.ReadByte()
+			if err != nil {
+				return nil, fmt.Errorf("Raster data is too short:\n%w", err)
+			}
+			switch {
+			case count == 128:
+				for ; x < lineBytes; x++ {
+					line[x] = white
+				}
+			case count < 128:
+				n := (int(count) + 1) * pixelBytes
+				if x+n > lineBytes {
+					return nil, errors.New("Raster run goes past the end of the line")
+				}
+				if _, err := io.ReadFull(r, line[x:x+pixelBytes]); err != nil {
+					return nil, fmt.Errorf("Raster data is too short:\n%w", err)
+				}
+				for i := pixelBytes; i < n; i++ {
+					line[x+i] = line[x+i-pixelBytes]
+				}
+				x += n
+			default:
+				n := (257 - int(count)) * pixelBytes
+				if x+n > lineBytes {
+					return nil, errors.New("Raster run goes past the end of the line")
+				}
+				if _, err := io.ReadFull(r, line[x:x+n]); err != nil {
+					return nil, fmt.Errorf("Raster data is too short:\n%w", err)
+				}
+				x += n
+			}
+		}
+		y++
+		for range repeat {
+			if y == height {
+				return nil, errors.New("Raster line is repeated past the end of the page")
+			}
+			copy(data[y*lineBytes:], line)
+			y++
+		}
+	}
+	return data, nil
+}
+
+// 1 bit per pixel, where 1 is black
+func blackToGray(data []byte, width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	stride := (width + 7) / 8
+	for y := range height {
+		for x := range width {
+			if data[y*stride+x/8]&(0x80>>(x%8)) == 0 {
+				img.Pix[y*img.Stride+x] = 0xff
+			}
+		}
+	}
+	return img
+}
+
+func grayToGray(data []byte, width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	copy(img.Pix, data)
+	return img
+}
+
+func rgbToGray(data []byte, width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		r, g, b := int(data[i*3]), int(data[i*3+1]), int(data[i*3+2])
+		img.Pix[i] = byte((299*r + 587*g + 114*b) / 1000)
+	}
+	return img
+}
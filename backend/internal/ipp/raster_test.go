@@ -0,0 +1,148 @@
+package ipp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"testing"
+)
+
+// A PWG raster page header for a page of the given size and format
+func pwgHeader(width, height, bitsPerPixel, colorSpace int) []byte {
+	header := make([]byte, 1796)
+	binary.BigEndian.PutUint32(header[372:], uint32(width))
+	binary.BigEndian.PutUint32(header[376:], uint32(height))
+	binary.BigEndian.PutUint32(header[384:], uint32(min(bitsPerPixel, 8)))
+	binary.BigEndian.PutUint32(header[388:], uint32(bitsPerPixel))
+	binary.BigEndian.PutUint32(header[392:], uint32((width*bitsPerPixel+7)/8))
+	binary.BigEndian.PutUint32(header[400:], uint32(colorSpace))
+	return header
+}
+
+func urfHeader(width, height, bitsPerPixel, colorSpace int) []byte {
+	header := make([]byte, 32)
+	header[0], header[1] = byte(bitsPerPixel), byte(colorSpace)
+	binary.BigEndian.PutUint32(header[12:], uint32(width))
+	binary.BigEndian.PutUint32(header[16:], uint32(height))
+	binary.BigEndian.PutUint32(header[20:], 203)
+	return header
+}
+
+var testLimits = PageLimits{MaxWidth: 384, MaxHeight: 8000, MaxPages: 10, MaxPixels: 384 * 8000}
+
+func expectPixels(t *testing.T, img image.Image, expected []string) {
+	t.Helper()
+	gray := img.(*image.Gray)
+	if gray.Rect.Dx() != len(expected[0]) || gray.Rect.Dy() != len(expected) {
+		t.Fatalf("Expected a %vx%v page, got %v", len(expected[0]), len(expected), gray.Rect)
+	}
+	for y, row := range expected {
+		for x, c := range row {
+			if black := gray.GrayAt(x, y).Y < 0x80; black != (c == '#') {
+				t.Errorf("Expected pixel %v,%v to be %c, was %v", x, y, c, gray.GrayAt(x, y).Y)
+			}
+		}
+	}
+}
+
+func TestPwgRasterPagesAreDecompressed(t *testing.T) {
+	data := append([]byte("RaS2"), pwgHeader(16, 3, 1, pwgBlack)...)
+	data = append(data,
+		// one line, repeated once: 2 bytes as they are
+		1, 255, 0xf0, 0x0f,
+		// the last line: the rest of the line is white
+		0, 0, 0x81, 128,
+	)
+	data = append(data, pwgHeader(4, 2, 8, pwgSGray)...)
+	data = append(data,
+		// both lines: 1 black pixel then 3 white ones
+		1, 0, 0x00, 2, 0xff,
+	)
+
+	pages, err := DecodePwgRaster(bytes.NewReader(data), testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("Expected 2 pages, got %v", len(pages))
+	}
+	expectPixels(t, pages[0], []string{
+		"####........####",
+		"####........####",
+		"#......#........",
+	})
+	expectPixels(t, pages[1], []string{"#...", "#..."})
+}
+
+func TestUrfPagesAreDecompressed(t *testing.T) {
+	data := append([]byte("UNIRAST\x00"), 0, 0, 0, 1)
+	data = append(data, urfHeader(3, 2, 24, urfSRgb)...)
+	data = append(data,
+		0, 0, 0xff, 0xff, 0xff, 255, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff,
+		0, 128,
+	)
+
+	pages, err := DecodeUrf(bytes.NewReader(data), testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectPixels(t, pages[0], []string{".#.", "..."})
+}
+
+func TestBrokenRasterIsTurnedAway(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"not raster":  []byte("%PDF-1.7"),
+		"no pages":    []byte("RaS2"),
+		"cut short":   append(append([]byte("RaS2"), pwgHeader(8, 2, 8, pwgSGray)...), 0, 7, 0),
+		"colour":      append([]byte("RaS2"), pwgHeader(8, 2, 32, 6)...),
+		"long run":    append(append([]byte("RaS2"), pwgHeader(2, 1, 8, pwgSGray)...), 0, 5, 0),
+		"long repeat": append(append([]byte("RaS2"), pwgHeader(1, 1, 8, pwgSGray)...), 3, 0, 0),
+		"too wide":    append([]byte("RaS2"), pwgHeader(385, 1, 8, pwgSGray)...),
+		"too long":    append([]byte("RaS2"), pwgHeader(8, 8001, 1, pwgBlack)...),
+		"huge":        append([]byte("RaS2"), pwgHeader(1<<32-1, 1<<32-1, 1, pwgBlack)...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodePwgRaster(bytes.NewReader(data), testLimits); err == nil {
+				t.Errorf("Expected raster to be turned away")
+			}
+		})
+	}
+}
+
+func TestHugeUrfPagesAreTurnedAway(t *testing.T) {
+	data := append([]byte("UNIRAST\x00"), 0, 0, 0, 1)
+	data = append(data, urfHeader(1<<32-1, 1<<32-1, 24, urfSRgb)...)
+	if _, err := DecodeUrf(bytes.NewReader(data), testLimits); err == nil {
+		t.Errorf("Expected page to be turned away")
+	}
+}
+
+// A PWG raster document of blank pages, each of which takes a few bytes
+// however big it is, as every line is filled with white and repeated
+func blankPages(pages, width, height int) []byte {
+	data := []byte("RaS2")
+	for range pages {
+		data = append(data, pwgHeader(width, height, 24, pwgSRgb)...)
+		for y := 0; y < height; y += 256 {
+			data = append(data, byte(min(height-y, 256)-1), 128)
+		}
+	}
+	return data
+}
+
+func TestDocumentsWhichDecodeTooBigAreTurnedAway(t *testing.T) {
+	if _, err := DecodePwgRaster(bytes.NewReader(blankPages(2, 384, 4000)), testLimits); err != nil {
+		t.Fatalf("Expected pages within the limits to be decoded, got %v", err)
+	}
+	for name, data := range map[string][]byte{
+		"too many pixels": blankPages(3, 384, 4000),
+		"too many pages":  blankPages(11, 1, 1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodePwgRaster(bytes.NewReader(data), testLimits); !errors.Is(err, errDocumentTooLarge) {
+				t.Errorf("Expected document to be too large, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,469 @@
+package ipp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/internal/job"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// Operations
+const (
+	printJob             uint16 = 0x0002
+	validateJob          uint16 = 0x0004
+	getJobs              uint16 = 0x000a
+	getPrinterAttributes uint16 = 0x000b
+)
+
+// Status codes
+const (
+	statusOk                        uint16 = 0x0000
+	statusBadRequest                uint16 = 0x0400
+	statusRequestTooLarge           uint16 = 0x0408
+	statusDocumentFormatUnsupported uint16 = 0x040a
+	statusAttributesUnsupported     uint16 = 0x040b
+	statusDocumentFormatError       uint16 = 0x0411
+	statusInternalError             uint16 = 0x0500
+	statusOperationUnsupported      uint16 = 0x0501
+	statusVersionUnsupported        uint16 = 0x0503
+)
+
+// Largest request, including its document, which is read
+const maxRequestSize = 64 << 20
+
+// Desktops size the pages they send from the default media, but labels on a
+// roll can be any length, so the default is a label this long
+const defaultLabelLength = 30
+
+// Longest label which can be printed, in millimetres
+const maxLabelLength = 1000
+
+// Most pages a document can have, and pixels they can add up to once they're
+// decoded
+const (
+	maxDocumentPages  = 100
+	maxDocumentPixels = 64 << 20
+)
+
+const (
+	formatPwgRaster = "image/pwg-raster"
+	formatUrf       = "image/urf"
+	formatPng       = "image/png"
+	formatAuto      = "application/octet-stream"
+)
+
+var documentFormats = []string{formatPwgRaster, formatUrf, formatPng, formatAuto}
+
+// Print qualities, and the laser intensity they print with. Normal is the
+// same intensity as everything else prints with by default.
+var qualities = map[int]printer.LaserIntensity{4: printer.Low, 5: printer.High}
+
+// Serves IPP requests over HTTP, so that desktops can print to the printer
+// without a driver. Printed documents are queued like any other image.
+type Server struct {
+	Log        *slog.Logger
+	Connection printer.Connection
+	Jobs       *job.Queue
+	startedAt  time.Time
+}
+
+func NewServer(log *slog.Logger, conn printer.Connection, jobs *job.Queue) *Server {
+	return &Server{Log: log, Connection: conn, Jobs: jobs, startedAt: time.Now()}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "IPP requests must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+	req, err := Decode(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		s.Log.Warn("Couldn't read IPP request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res := s.handle(req, "ipp://"+r.Host+r.URL.Path)
+	w.Header().Set("Content-Type", "application/ipp")
+	if err := res.Encode(w); err != nil {
+		s.Log.Warn("Couldn't write IPP response", "error", err)
+	}
+}
+
+func (s *Server) handle(req *Message, printerUri string) *Message {
+	log := s.Log.With("operation", fmt.Sprintf("%#04x", req.Code), "request", req.RequestId)
+	log.Debug("Handling IPP request")
+	res := &Message{Version: [2]byte{2, 0}, RequestId: req.RequestId}
+	if req.Version[0] != 1 && req.Version[0] != 2 {
+		return withStatus(res, statusVersionUnsupported, fmt.Sprintf("IPP version %v.%v isn't supported", req.Version[0], req.Version[1]))
+	}
+	res.Version = req.Version
+	if len(req.Groups) == 0 || req.Groups[0].Tag != operationGroup {
+		return withStatus(res, statusBadRequest, "Request doesn't start with operation attributes")
+	}
+
+	switch req.Code {
+	case getPrinterAttributes:
+		res = withStatus(res, statusOk, "")
+		res.Groups = append(res.Groups, Group{
+			Tag:        printerGroup,
+			Attributes: filter(s.printerAttributes(printerUri), req.Attribute(operationGroup, "requested-attributes").Strings(), nil),
+		})
+		return res
+	case validateJob, printJob:
+		options, unsupported := jobOptions(req)
+		if len(unsupported) > 0 {
+			res = withStatus(res, statusAttributesUnsupported, "Some attributes aren't supported")
+			res.Groups = append(res.Groups, Group{Tag: unsupportedGroup, Attributes: unsupported})
+			return res
+		}
+		format := req.Attribute(operationGroup, "document-format").String()
+		if format != "" && !slices.Contains(documentFormats, format) {
+			return withStatus(res, statusDocumentFormatUnsupported, fmt.Sprintf("Document format %v isn't supported", format))
+		}
+		if req.Code == validateJob {
+			return withStatus(res, statusOk, "")
+		}
+		return s.printJob(log, req, res, format, options, printerUri)
+	case getJobs:
+		return s.getJobs(req, res, printerUri)
+	default:
+		return withStatus(res, statusOperationUnsupported, "Operation isn't supported")
+	}
+}
+
+// Starts a response with the required operation attributes and a status
+func withStatus(res *Message, status uint16, message string) *Message {
+	res.Code = status
+	attributes := []Attribute{
+		{Name: "attributes-charset", Values: stringValues(tagCharset, "utf-8")},
+		{Name: "attributes-natural-language", Values: stringValues(tagLanguage, "en")},
+	}
+	if message != "" {
+		attributes = append(attributes, Attribute{Name: "status-message", Values: stringValues(tagText, message)})
+	}
+	res.Groups = []Group{{Tag: operationGroup, Attributes: attributes}}
+	return res
+}
+
+// The print options asked for by a job's attributes, and the attributes
+// which can't be printed as they were asked for. Attributes which aren't
+// understood at all are ignored.
+func jobOptions(req *Message) (printer.PrintOptions, []Attribute) {
+	options := printer.DefaultPrintOptions()
+	unsupported := []Attribute{}
+	if a := req.Attribute(jobGroup, "copies"); a != nil {
+		if n, ok := a.Int(); ok && n >= 1 && n <= 99 {
+			options.Copies = n
+		} else {
+			unsupported = append(unsupported, *a)
+		}
+	}
+	if a := req.Attribute(jobGroup, "print-quality"); a != nil {
+		n, _ := a.Int()
+		if intensity, ok := qualities[n]; ok {
+			options.Intensity = intensity
+		} else {
+			unsupported = append(unsupported, *a)
+		}
+	}
+	return options, unsupported
+}
+
+func (s *Server) printJob(log *slog.Logger, req, res *Message, format string, options printer.PrintOptions, printerUri string) *Message {
+	pages, err := decodeDocument(req.Data, format, s.Connection.Profile())
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) || errors.Is(err, errDocumentTooLarge) {
+			return withStatus(res, statusRequestTooLarge, "Document is too large")
+		}
+		log.Warn("Couldn't read IPP document", "format", format, "error", err)
+		return withStatus(res, statusDocumentFormatError, err.Error())
+	}
+
+	var first *job.Job
+	if len(pages) == 1 {
+		first, err = s.Jobs.SubmitImage(pages[0], nil, options)
+	} else {
+		images := make([][]byte, len(pages))
+		for i, page := range pages {
+			if images[i], err = job.EncodeImage(page); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			var js []*job.Job
+			_, js, err = s.Jobs.SubmitBatch(images, make([]*job.Source, len(pages)), options)
+			if err == nil {
+				first = js[0]
+			}
+		}
+	}
+	if err != nil {
+		log.Error("Couldn't queue IPP job", "error", err)
+		return withStatus(res, statusInternalError, "Couldn't queue job")
+	}
+	log.Info("Queued IPP job", "uuid", first.Uuid, "pages", len(pages))
+
+	res = withStatus(res, statusOk, "")
+	res.Groups = append(res.Groups, Group{Tag: jobGroup, Attributes: jobAttributes(first, printerUri)})
+	return res
+}
+
+// Reads the pages of a document in one of the supported formats, working out
+// which one it is if it isn't given. Pages are scaled to the print head's
+// width when they're printed, so raster pages can be a little wider in case
+// the desktop rounds the media size up.
+func decodeDocument(r io.Reader, format string, profile printer.Profile) ([]image.Image, error) {
+	limits := PageLimits{
+		MaxWidth:  profile.DotsPerLine * 2,
+		MaxHeight: maxLabelLength * profile.DPI * 10 / 254,
+		MaxPages:  maxDocumentPages,
+		MaxPixels: maxDocumentPixels,
+	}
+	br := bufio.NewReader(r)
+	if format == "" || format == formatAuto {
+		head, _ := br.Peek(len(urfSync))
+		switch {
+		case bytes.HasPrefix(head, pwgSync):
+			format = formatPwgRaster
+		case bytes.HasPrefix(head, urfSync):
+			format = formatUrf
+		case bytes.HasPrefix(head, []byte("\x89PNG")):
+			format = formatPng
+		default:
+			return nil, errors.New("Document isn't PWG raster, URF raster or PNG")
+		}
+	}
+	switch format {
+	case formatPwgRaster:
+		return DecodePwgRaster(br, limits)
+	case formatUrf:
+		return DecodeUrf(br, limits)
+	default:
+		// PNG headers give the size, so a huge image is turned away before
+		// anything is allocated for it
+		head, _ := br.Peek(64)
+		config, err := png.DecodeConfig(bytes.NewReader(head))
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read PNG:\n%w", err)
+		}
+		if err := limits.check(nil, config.Width, config.Height); err != nil {
+			return nil, err
+		}
+		img, err := png.Decode(br)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read PNG:\n%w", err)
+		}
+		return []image.Image{img}, nil
+	}
+}
+
+func (s *Server) getJobs(req, res *Message, printerUri string) *Message {
+	which := req.Attribute(operationGroup, "which-jobs").String()
+	var statuses []job.Status
+	switch which {
+	case "", "not-completed":
+		statuses = []job.Status{job.Queued, job.Printing}
+	case "completed":
+		statuses = []job.Status{job.Done, job.Failed, job.Cancelled}
+	case "all":
+		statuses = []job.Status{job.Queued, job.Printing, job.Done, job.Failed, job.Cancelled}
+	default:
+		res = withStatus(res, statusAttributesUnsupported, fmt.Sprintf("which-jobs %v isn't supported", which))
+		res.Groups = append(res.Groups, Group{Tag: unsupportedGroup, Attributes: []Attribute{*req.Attribute(operationGroup, "which-jobs")}})
+		return res
+	}
+	limit, ok := req.Attribute(operationGroup, "limit").Int()
+	if !ok || limit < 1 {
+		limit = -1
+	}
+
+	js, err := s.Jobs.Repository.List()
+	if err != nil {
+		s.Log.Error("Couldn't list jobs", "error", err)
+		return withStatus(res, statusInternalError, "Couldn't list jobs")
+	}
+	res = withStatus(res, statusOk, "")
+	requested := req.Attribute(operationGroup, "requested-attributes").Strings()
+	// newest first
+	for i := len(js) - 1; i >= 0 && limit != 0; i-- {
+		if !slices.Contains(statuses, js[i].Status) {
+			continue
+		}
+		res.Groups = append(res.Groups, Group{
+			Tag:        jobGroup,
+			Attributes: filter(jobAttributes(&js[i], printerUri), requested, []string{"job-id", "job-uri"}),
+		})
+		limit--
+	}
+	return res
+}
+
+func jobAttributes(j *job.Job, printerUri string) []Attribute {
+	var state int
+	var reason string
+	switch j.Status {
+	case job.Queued:
+		state, reason = 3, "none"
+	case job.Printing:
+		state, reason = 5, "job-printing"
+	case job.Done:
+		state, reason = 9, "job-completed-successfully"
+	case job.Failed:
+		state, reason = 8, "aborted-by-system"
+	case job.Cancelled:
+		state, reason = 7, "job-canceled-by-user"
+	}
+	attributes := []Attribute{
+		{Name: "job-id", Values: intValues(tagInteger, j.Id)},
+		{Name: "job-uri", Values: stringValues(tagUri, printerUri+"/"+strconv.Itoa(j.Id))},
+		{Name: "job-uuid", Values: stringValues(tagUri, j.Uuid.URN())},
+		{Name: "job-state", Values: intValues(tagEnum, state)},
+		{Name: "job-state-reasons", Values: stringValues(tagKeyword, reason)},
+		{Name: "job-printer-uri", Values: stringValues(tagUri, printerUri)},
+	}
+	if j.Reason != "" {
+		attributes = append(attributes, Attribute{Name: "job-state-message", Values: stringValues(tagText, j.Reason)})
+	}
+	return attributes
+}
+
+// Keeps the attributes which were asked for, or the defaults if none were.
+// Asking for all of them, or for a group of them, like
+// "printer-description", keeps all of them.
+func filter(attributes []Attribute, requested []string, defaults []string) []Attribute {
+	if len(requested) == 0 {
+		requested = defaults
+	}
+	if requested == nil || slices.ContainsFunc(requested, func(r string) bool {
+		return r == "all" || r == "printer-description" || r == "job-template" || r == "job-description"
+	}) {
+		return attributes
+	}
+	kept := []Attribute{}
+	for _, a := range attributes {
+		if slices.Contains(requested, a.Name) {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+func (s *Server) printerAttributes(printerUri string) []Attribute {
+	profile := s.Connection.Profile()
+	info := s.Connection.GetPrinter().Info()
+
+	// the print head's width, in hundredths of a millimetre like media sizes
+	// are given in
+	width := profile.DotsPerLine * 2540 / profile.DPI
+	mm := strconv.FormatFloat(float64(width)/100, 'f', -1, 64)
+	media := fmt.Sprintf("custom_label_%vx%vmm", mm, defaultLabelLength)
+	mediaSize := collectionValue(
+		Attribute{Name: "x-dimension", Values: intValues(tagInteger, width)},
+		Attribute{Name: "y-dimension", Values: intValues(tagInteger, defaultLabelLength*100)},
+	)
+	mediaCol := collectionValue(
+		Attribute{Name: "media-size", Values: mediaSize},
+		Attribute{Name: "media-top-margin", Values: intValues(tagInteger, 0)},
+		Attribute{Name: "media-bottom-margin", Values: intValues(tagInteger, 0)},
+		Attribute{Name: "media-left-margin", Values: intValues(tagInteger, 0)},
+		Attribute{Name: "media-right-margin", Values: intValues(tagInteger, 0)},
+	)
+
+	// jobs are queued while the printer is disconnected, and it's connected
+	// to when there's something to print, so it's only stopped when it can't
+	// print at all
+	state, reasons := 3, []string{"none"}
+	switch info.State {
+	case printer.Busy:
+		state = 4
+	case printer.OutOfPaper:
+		state, reasons = 5, []string{"media-empty-error"}
+	case printer.Connecting, printer.Reconnecting:
+		reasons = []string{"connecting-to-device"}
+	}
+	queued := 0
+	if js, err := s.Jobs.Repository.List(); err == nil {
+		for _, j := range js {
+			if j.Status == job.Queued || j.Status == job.Printing {
+				queued++
+			}
+		}
+	}
+
+	qualitiesSupported := []int{}
+	for q, intensity := range qualities {
+		if slices.Contains(profile.Intensities, intensity) {
+			qualitiesSupported = append(qualitiesSupported, q)
+		}
+	}
+	slices.Sort(qualitiesSupported)
+
+	return []Attribute{
+		{Name: "printer-uri-supported", Values: stringValues(tagUri, printerUri)},
+		{Name: "uri-security-supported", Values: stringValues(tagKeyword, "none")},
+		{Name: "uri-authentication-supported", Values: stringValues(tagKeyword, "none")},
+		{Name: "printer-name", Values: stringValues(tagName, profile.Name)},
+		{Name: "printer-info", Values: stringValues(tagText, "Phogoprint "+profile.Name)},
+		{Name: "printer-make-and-model", Values: stringValues(tagText, "Phomemo "+profile.Name)},
+		{Name: "printer-uuid", Values: stringValues(tagUri, uuid.NewSHA1(uuid.NameSpaceURL, []byte(printerUri)).URN())},
+		{Name: "printer-state", Values: intValues(tagEnum, state)},
+		{Name: "printer-state-reasons", Values: stringValues(tagKeyword, reasons...)},
+		{Name: "printer-state-message", Values: stringValues(tagText, info.State.String())},
+		{Name: "printer-is-accepting-jobs", Values: boolValue(true)},
+		{Name: "printer-up-time", Values: intValues(tagInteger, int(time.Since(s.startedAt).Seconds())+1)},
+		{Name: "queued-job-count", Values: intValues(tagInteger, queued)},
+		{Name: "ipp-versions-supported", Values: stringValues(tagKeyword, "1.1", "2.0")},
+		{Name: "operations-supported", Values: intValues(tagEnum, int(printJob), int(validateJob), int(getJobs), int(getPrinterAttributes))},
+		{Name: "charset-configured", Values: stringValues(tagCharset, "utf-8")},
+		{Name: "charset-supported", Values: stringValues(tagCharset, "utf-8")},
+		{Name: "natural-language-configured", Values: stringValues(tagLanguage, "en")},
+		{Name: "generated-natural-language-supported", Values: stringValues(tagLanguage, "en")},
+		{Name: "pdl-override-supported", Values: stringValues(tagKeyword, "attempted")},
+		{Name: "compression-supported", Values: stringValues(tagKeyword, "none")},
+		{Name: "multiple-document-jobs-supported", Values: boolValue(false)},
+		{Name: "document-format-default", Values: stringValues(tagMimeType, formatAuto)},
+		{Name: "document-format-supported", Values: stringValues(tagMimeType, documentFormats...)},
+		{Name: "color-supported", Values: boolValue(false)},
+		{Name: "print-color-mode-default", Values: stringValues(tagKeyword, "monochrome")},
+		{Name: "print-color-mode-supported", Values: stringValues(tagKeyword, "monochrome")},
+		{Name: "sides-default", Values: stringValues(tagKeyword, "one-sided")},
+		{Name: "sides-supported", Values: stringValues(tagKeyword, "one-sided")},
+		{Name: "copies-default", Values: intValues(tagInteger, 1)},
+		{Name: "copies-supported", Values: rangeValue(1, 99)},
+		{Name: "print-quality-default", Values: intValues(tagEnum, 4)},
+		{Name: "print-quality-supported", Values: intValues(tagEnum, qualitiesSupported...)},
+		{Name: "printer-resolution-default", Values: resolutionValue(profile.DPI)},
+		{Name: "printer-resolution-supported", Values: resolutionValue(profile.DPI)},
+		{Name: "media-default", Values: stringValues(tagKeyword, media)},
+		{Name: "media-ready", Values: stringValues(tagKeyword, media)},
+		{Name: "media-supported", Values: stringValues(tagKeyword, media)},
+		{Name: "media-col-default", Values: mediaCol},
+		{Name: "media-col-ready", Values: mediaCol},
+		{Name: "media-size-supported", Values: collectionValue(
+			Attribute{Name: "x-dimension", Values: intValues(tagInteger, width)},
+			Attribute{Name: "y-dimension", Values: rangeValue(500, maxLabelLength*100)},
+		)},
+		{Name: "media-top-margin-supported", Values: intValues(tagInteger, 0)},
+		{Name: "media-bottom-margin-supported", Values: intValues(tagInteger, 0)},
+		{Name: "media-left-margin-supported", Values: intValues(tagInteger, 0)},
+		{Name: "media-right-margin-supported", Values: intValues(tagInteger, 0)},
+		{Name: "pwg-raster-document-resolution-supported", Values: resolutionValue(profile.DPI)},
+		{Name: "pwg-raster-document-type-supported", Values: stringValues(tagKeyword, "black_1", "sgray_8", "srgb_8")},
+		{Name: "urf-supported", Values: stringValues(tagKeyword, "V1.4", "CP1", "W8", "SRGB24", fmt.Sprintf("RS%v", profile.DPI))},
+	}
+}
@@ -0,0 +1,174 @@
+package ipp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tomgalvin.uk/phogoprint/internal/database/dbtest"
+	"tomgalvin.uk/phogoprint/internal/job"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// A server with a queue which isn't started, so jobs stay queued
+func aServer(t *testing.T) (*job.Queue, *httptest.Server) {
+	db := dbtest.Open(t)
+
+	conn := printer.NewSimulatedConnection(printer.DefaultSimulatorOptions())
+	q := job.NewQueue(slog.Default(), &job.JobRepository{Db: db}, conn)
+	ts := httptest.NewServer(NewServer(slog.Default(), conn, q))
+	t.Cleanup(ts.Close)
+	return q, ts
+}
+
+func request(operation uint16, jobAttributes []Attribute, operationAttributes ...Attribute) *Message {
+	attributes := append([]Attribute{
+		{Name: "attributes-charset", Values: stringValues(tagCharset, "utf-8")},
+		{Name: "attributes-natural-language", Values: stringValues(tagLanguage, "en")},
+	}, operationAttributes...)
+	m := &Message{Version: [2]byte{2, 0}, Code: operation, RequestId: 7, Groups: []Group{{Tag: operationGroup, Attributes: attributes}}}
+	if jobAttributes != nil {
+		m.Groups = append(m.Groups, Group{Tag: jobGroup, Attributes: jobAttributes})
+	}
+	return m
+}
+
+func send(t *testing.T, ts *httptest.Server, req *Message) *Message {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := req.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(ts.URL+"/ipp/print", "application/ipp", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	m, err := Decode(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.RequestId != req.RequestId {
+		t.Errorf("Expected request id %v, got %v", req.RequestId, m.RequestId)
+	}
+	return m
+}
+
+func TestPrinterAttributesDescribeTheProfile(t *testing.T) {
+	_, ts := aServer(t)
+	res := send(t, ts, request(getPrinterAttributes, nil, Attribute{
+		Name:   "requested-attributes",
+		Values: stringValues(tagKeyword, "printer-make-and-model", "media-default", "document-format-supported"),
+	}))
+	if res.Code != statusOk {
+		t.Fatalf("Expected success, got %#04x", res.Code)
+	}
+	if a := res.Attribute(printerGroup, "printer-make-and-model").String(); a != "Phomemo T02" {
+		t.Errorf("Unexpected make and model %q", a)
+	}
+	// 384 dots at 203 DPI
+	if a := res.Attribute(printerGroup, "media-default").String(); a != "custom_label_48.04x30mm" {
+		t.Errorf("Unexpected media %q", a)
+	}
+	if a := res.Attribute(printerGroup, "document-format-supported").Strings(); len(a) != len(documentFormats) {
+		t.Errorf("Unexpected formats %v", a)
+	}
+	if res.Attribute(printerGroup, "printer-state") != nil {
+		t.Errorf("Expected only the requested attributes")
+	}
+}
+
+func TestPrintJobIsQueued(t *testing.T) {
+	q, ts := aServer(t)
+	var doc bytes.Buffer
+	png.Encode(&doc, image.NewGray(image.Rect(0, 0, 20, 10)))
+	req := request(printJob, []Attribute{
+		{Name: "copies", Values: intValues(tagInteger, 2)},
+		{Name: "print-quality", Values: intValues(tagEnum, 5)},
+	}, Attribute{Name: "document-format", Values: stringValues(tagMimeType, formatAuto)})
+	req.Data = &doc
+
+	res := send(t, ts, req)
+	if res.Code != statusOk {
+		t.Fatalf("Expected success, got %#04x", res.Code)
+	}
+	id, _ := res.Attribute(jobGroup, "job-id").Int()
+	js, _ := q.Repository.List()
+	if len(js) != 1 || js[0].Id != id {
+		t.Fatalf("Expected job %v to be queued, got %v", id, js)
+	}
+	if js[0].Options.Copies != 2 || js[0].Options.Intensity != printer.High {
+		t.Errorf("Expected job attributes to be used, got %+v", js[0].Options)
+	}
+
+	res = send(t, ts, request(getJobs, nil))
+	if n, _ := res.Attribute(jobGroup, "job-id").Int(); n != id {
+		t.Errorf("Expected job %v to be listed, got %v", id, n)
+	}
+}
+
+func TestUnprintableJobsAreTurnedAway(t *testing.T) {
+	q, ts := aServer(t)
+	req := request(printJob, nil, Attribute{Name: "document-format", Values: stringValues(tagMimeType, "application/pdf")})
+	if res := send(t, ts, req); res.Code != statusDocumentFormatUnsupported {
+		t.Errorf("Expected PDF to be turned away, got %#04x", res.Code)
+	}
+
+	req = request(printJob, nil)
+	req.Data = bytes.NewReader([]byte("RaS2"))
+	if res := send(t, ts, req); res.Code != statusDocumentFormatError {
+		t.Errorf("Expected empty raster to be turned away, got %#04x", res.Code)
+	}
+
+	req = request(validateJob, []Attribute{{Name: "copies", Values: intValues(tagInteger, 0)}})
+	res := send(t, ts, req)
+	if res.Code != statusAttributesUnsupported || res.Attribute(unsupportedGroup, "copies") == nil {
+		t.Errorf("Expected copies to be unsupported, got %#04x", res.Code)
+	}
+
+	if js, _ := q.Repository.List(); len(js) != 0 {
+		t.Errorf("Expected no jobs to be queued, got %v", len(js))
+	}
+}
+
+// A PNG whose header says it's far bigger than its data
+func aHugePng(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	// the IHDR chunk's width and height, then its checksum
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	if config, err := png.DecodeConfig(bytes.NewReader(data)); err != nil || config.Width != 100000 {
+		t.Fatalf("Couldn't make huge PNG: %v", err)
+	}
+	return data
+}
+
+func TestHugeDocumentsAreTurnedAway(t *testing.T) {
+	q, ts := aServer(t)
+	req := request(printJob, nil)
+	req.Data = bytes.NewReader(blankPages(20, 768, 7992))
+	if res := send(t, ts, req); res.Code != statusRequestTooLarge {
+		t.Errorf("Expected document which decodes too big to be turned away, got %#04x", res.Code)
+	}
+
+	req = request(printJob, nil)
+	req.Data = bytes.NewReader(aHugePng(t))
+	if res := send(t, ts, req); res.Code != statusDocumentFormatError {
+		t.Errorf("Expected huge PNG to be turned away, got %#04x", res.Code)
+	}
+
+	if js, _ := q.Repository.List(); len(js) != 0 {
+		t.Errorf("Expected no jobs to be queued, got %v", len(js))
+	}
+}
@@ -0,0 +1,210 @@
+package job
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// A label which was sent to the printer, kept along with the bitmap which was
+// printed, so it can be printed again exactly as it was without rendering it
+// again
+type HistoryEntry struct {
+	Id               int
+	Uuid             uuid.UUID
+	// The job which printed it
+	JobUuid          uuid.UUID
+	// The template it was printed from, or nil if it was an image printed
+	// directly. The template may have been deleted since.
+	TemplateUuid     *uuid.UUID
+	TemplateRevision int
+	Parameters       map[string]string
+	// The entry this one printed again, if it was a reprint
+	ReprintOf        *uuid.UUID
+	Options          printer.PrintOptions
+	// Done, Failed or Cancelled
+	Outcome          Status
+	Reason           string
+	PrintedAt        time.Time
+	Width, Height    int
+	// Not loaded when listing history
+	Bitmap           *bitmap.PackedBitmap
+}
+
+type SourceKind string
+
+const (
+	ImageSource    SourceKind = "IMAGE"
+	TemplateSource SourceKind = "TEMPLATE"
+)
+
+// Narrows down which history entries are listed. Zero values match anything.
+type HistoryFilter struct {
+	Source       SourceKind
+	TemplateUuid *uuid.UUID
+	Outcome      Status
+	// Only entries printed at or after From, and before To
+	From, To     *time.Time
+	// The most entries to list, newest first
+	Limit        int
+}
+
+// Entries are listed 100 at a time unless asked for otherwise
+const defaultHistoryLimit = 100
+
+func (e *HistoryEntry) Source() SourceKind {
+	if e.TemplateUuid == nil {
+		return ImageSource
+	}
+	return TemplateSource
+}
+
+// The bitmap column is substituted in, so it can be left out (as NULL) when
+// listing history
+const selectHistory = `
+	SELECT id, uuid, job_uuid, template_uuid, template_revision, parameters, reprint_of,
+		intensity, feed_lines, justify, copies, outcome, reason, printed_at, width, height, %s
+	FROM print_history`
+
+func scanHistory(row interface{ Scan(...any) error }, e *HistoryEntry) error {
+	var uuidString, jobUuidString string
+	var templateUuidString, parameters, reprintOfString, reason sql.NullString
+	var templateRevision sql.NullInt64
+	var data []byte
+	if err := row.Scan(&e.Id, &uuidString, &jobUuidString, &templateUuidString, &templateRevision, &parameters, &reprintOfString,
+		&e.Options.Intensity, &e.Options.FeedLines, &e.Options.Justify, &e.Options.Copies,
+		&e.Outcome, &reason, &e.PrintedAt, &e.Width, &e.Height, &data); err != nil {
+		return err
+	}
+	e.Uuid = uuid.MustParse(uuidString)
+	e.JobUuid = uuid.MustParse(jobUuidString)
+	e.Reason = reason.String
+	if templateUuidString.Valid {
+		u := uuid.MustParse(templateUuidString.String)
+		e.TemplateUuid = &u
+		e.TemplateRevision = int(templateRevision.Int64)
+	}
+	if parameters.Valid {
+		if err := json.Unmarshal([]byte(parameters.String), &e.Parameters); err != nil {
+			return fmt.Errorf("Couldn't decode history parameters:\n%w", err)
+		}
+	}
+	if reprintOfString.Valid {
+		u := uuid.MustParse(reprintOfString.String)
+		e.ReprintOf = &u
+	}
+	if data != nil {
+		b, err := bitmap.NewPackedBitmap(data, e.Width, e.Height)
+		if err != nil {
+			return err
+		}
+		e.Bitmap = b
+	}
+	return nil
+}
+
+// Records a label which was sent to the printer
+func (r *JobRepository) AddHistory(e *HistoryEntry) error {
+	var templateUuid, templateRevision, reprintOf any
+	if e.TemplateUuid != nil {
+		templateUuid = e.TemplateUuid.String()
+		templateRevision = e.TemplateRevision
+	}
+	if e.ReprintOf != nil {
+		reprintOf = e.ReprintOf.String()
+	}
+	parameters, err := encodeParameters(e.Parameters)
+	if err != nil {
+		return err
+	}
+	row := r.Db.QueryRow(`
+		INSERT INTO print_history(uuid, job_uuid, template_uuid, template_revision, parameters, reprint_of,
+			intensity, feed_lines, justify, copies, outcome, reason, printed_at, width, height, bitmap)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`, e.Uuid.String(), e.JobUuid.String(), templateUuid, templateRevision, parameters, reprintOf,
+		e.Options.Intensity, e.Options.FeedLines, e.Options.Justify, e.Options.Copies,
+		e.Outcome, sql.NullString{String: e.Reason, Valid: e.Reason != ""}, e.PrintedAt,
+		e.Bitmap.Width(), e.Bitmap.Height(), e.Bitmap.Data())
+	if err := row.Scan(&e.Id); err != nil {
+		return fmt.Errorf("Failed to insert into print_history:\n%w", err)
+	}
+	return nil
+}
+
+// Gets a history entry along with its bitmap, or nil if there isn't one with
+// the UUID
+func (r *JobRepository) GetHistory(u uuid.UUID) (*HistoryEntry, error) {
+	row := r.Db.QueryRow(fmt.Sprintf(selectHistory, "bitmap")+` WHERE uuid = ?`, u.String())
+
+	var e HistoryEntry
+	if err := scanHistory(row, &e); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, fmt.Errorf("Failed to read history entry:\n%w", err)
+		}
+	}
+	return &e, nil
+}
+
+// Lists the labels which have been printed, newest first, without their
+// bitmaps
+func (r *JobRepository) ListHistory(f HistoryFilter) ([]HistoryEntry, error) {
+	where := []string{"1 = 1"}
+	args := []any{}
+	switch f.Source {
+	case ImageSource:
+		where = append(where, "template_uuid IS NULL")
+	case TemplateSource:
+		where = append(where, "template_uuid IS NOT NULL")
+	}
+	if f.TemplateUuid != nil {
+		where = append(where, "template_uuid = ?")
+		args = append(args, f.TemplateUuid.String())
+	}
+	if f.Outcome != "" {
+		where = append(where, "outcome = ?")
+		args = append(args, f.Outcome)
+	}
+	if f.From != nil {
+		// compared as times, since they're stored as text which doesn't
+		// always sort in time order
+		where = append(where, "julianday(printed_at) >= julianday(?)")
+		args = append(args, *f.From)
+	}
+	if f.To != nil {
+		where = append(where, "julianday(printed_at) < julianday(?)")
+		args = append(args, *f.To)
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	args = append(args, limit)
+
+	rows, err := r.Db.Query(fmt.Sprintf(selectHistory, "NULL")+` WHERE `+strings.Join(where, " AND ")+` ORDER BY id DESC LIMIT ?`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query execution failed:\n%w", err)
+	}
+	defer rows.Close()
+
+	entries := []HistoryEntry{}
+	for rows.Next() {
+		var e HistoryEntry
+		if err := scanHistory(rows, &e); err != nil {
+			return nil, fmt.Errorf("Row scanning failed:\n%w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Error iterating rows:\n%w", err)
+	}
+	return entries, nil
+}
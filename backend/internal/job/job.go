@@ -0,0 +1,56 @@
+// This package implements a queue of print jobs which are stored in the
+// database and printed one at a time, so that requests to print don't have to
+// wait for the printer, and queued jobs survive a restart of the server.
+package job
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+type Status string
+
+const (
+	Queued    Status = "QUEUED"
+	Printing  Status = "PRINTING"
+	Done      Status = "DONE"
+	Failed    Status = "FAILED"
+	Cancelled Status = "CANCELLED"
+)
+
+type Job struct {
+	Id           int
+	Uuid         uuid.UUID
+	Status       Status
+	// Why the job failed or was cancelled, if it did
+	Reason       string
+	// The template the job was printed from, if any, and which revision of it
+	TemplateUuid     *uuid.UUID
+	TemplateRevision int
+	// The parameter values the template was printed with
+	Parameters   map[string]string
+	// The history entry this job prints again, if it's a reprint
+	ReprintOf    *uuid.UUID
+	// The batch the job was printed as part of, if any
+	BatchUuid    *uuid.UUID
+	// Encoded image data (PNG, JPEG) of the image to print
+	Image        []byte
+	// A bitmap to print as it is, instead of an image which still needs to be
+	// dithered and packed. Not loaded when listing jobs.
+	Bitmap       *bitmap.PackedBitmap
+	Options      printer.PrintOptions
+	CreatedAt    time.Time
+	StartedAt    *time.Time
+	FinishedAt   *time.Time
+}
+
+// The revision of a template which a job is printed from, and the values it's
+// filled in with
+type Source struct {
+	TemplateUuid uuid.UUID
+	Revision     int
+	Parameters   map[string]string
+}
@@ -0,0 +1,360 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"log/slog"
+	"sync"
+	"time"
+
+	_ "image/jpeg"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// Prints queued jobs one at a time, in the order they were submitted
+type Queue struct {
+	Log        *slog.Logger
+	Repository *JobRepository
+	Connection printer.Connection
+	// signalled whenever a job is submitted, so the worker doesn't have to poll
+	wake       chan struct{}
+	// guards running, the job which is printing right now if there is one
+	mu         sync.Mutex
+	running    *running
+}
+
+type running struct {
+	uuid   uuid.UUID
+	cancel context.CancelFunc
+	// closed once the job's outcome has been recorded
+	done   chan struct{}
+}
+
+func NewQueue(log *slog.Logger, repo *JobRepository, conn printer.Connection) *Queue {
+	return &Queue{
+		Log:        log,
+		Repository: repo,
+		Connection: conn,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Starts printing jobs in the background, starting with any left in the
+// queue from when the server last ran.
+func (q *Queue) Start() error {
+	n, err := q.Repository.FailInterrupted()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		q.Log.Warn("Marked interrupted jobs as failed", "count", n)
+	}
+
+	go q.run()
+	q.signal()
+	return nil
+}
+
+// Adds an encoded image (PNG, JPEG) to the end of the queue
+func (q *Queue) Submit(imageData []byte, source *Source, options printer.PrintOptions) (*Job, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	j := &Job{
+		Uuid:         uuid.New(),
+		Status:       Queued,
+		Image:        imageData,
+		Options:      options,
+		CreatedAt:    time.Now(),
+	}
+	j.setSource(source)
+	if err := q.Repository.Create(j); err != nil {
+		return nil, err
+	}
+	q.Log.Info("Queued job", "uuid", j.Uuid)
+	q.publish(j)
+	q.signal()
+	return j, nil
+}
+
+// Adds several encoded images to the end of the queue at once, as one batch
+// which is printed in order. Each image comes from the source at the same
+// index.
+func (q *Queue) SubmitBatch(images [][]byte, sources []*Source, options printer.PrintOptions) (uuid.UUID, []*Job, error) {
+	if err := options.Validate(); err != nil {
+		return uuid.Nil, nil, err
+	}
+	batchUuid := uuid.New()
+	js := make([]*Job, len(images))
+	for i, imageData := range images {
+		js[i] = &Job{
+			Uuid:         uuid.New(),
+			Status:       Queued,
+			BatchUuid:    &batchUuid,
+			Image:        imageData,
+			Options:      options,
+			CreatedAt:    time.Now(),
+		}
+		js[i].setSource(sources[i])
+	}
+	if err := q.Repository.CreateAll(js); err != nil {
+		return uuid.Nil, nil, err
+	}
+	q.Log.Info("Queued batch", "uuid", batchUuid, "jobs", len(js))
+	for _, j := range js {
+		q.publish(j)
+	}
+	q.signal()
+	return batchUuid, js, nil
+}
+
+// Encodes an image as PNG and adds it to the end of the queue
+func (q *Queue) SubmitImage(img image.Image, source *Source, options printer.PrintOptions) (*Job, error) {
+	imageData, err := EncodeImage(img)
+	if err != nil {
+		return nil, err
+	}
+	return q.Submit(imageData, source, options)
+}
+
+// Adds bitmaps which are already packed to the end of the queue, to be
+// printed as they are. Several bitmaps are queued as one batch, printed in
+// order.
+func (q *Queue) SubmitBitmaps(pbs []*bitmap.PackedBitmap, options printer.PrintOptions) ([]*Job, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	var batchUuid *uuid.UUID
+	if len(pbs) > 1 {
+		u := uuid.New()
+		batchUuid = &u
+	}
+	js := make([]*Job, len(pbs))
+	for i, pb := range pbs {
+		js[i] = &Job{
+			Uuid:      uuid.New(),
+			Status:    Queued,
+			BatchUuid: batchUuid,
+			Bitmap:    pb,
+			Options:   options,
+			CreatedAt: time.Now(),
+		}
+	}
+	if err := q.Repository.CreateAll(js); err != nil {
+		return nil, err
+	}
+	q.Log.Info("Queued bitmaps", "jobs", len(js))
+	for _, j := range js {
+		q.publish(j)
+	}
+	q.signal()
+	return js, nil
+}
+
+// Queues a label from the print history to be printed again, with the same
+// bitmap and options as it was printed with. Returns nil if there's no such
+// history entry.
+func (q *Queue) Reprint(u uuid.UUID) (*Job, error) {
+	e, err := q.Repository.GetHistory(u)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	j := &Job{
+		Uuid:             uuid.New(),
+		Status:           Queued,
+		TemplateUuid:     e.TemplateUuid,
+		TemplateRevision: e.TemplateRevision,
+		Parameters:       e.Parameters,
+		ReprintOf:        &e.Uuid,
+		Bitmap:           e.Bitmap,
+		Options:          e.Options,
+		CreatedAt:        time.Now(),
+	}
+	if err := q.Repository.Create(j); err != nil {
+		return nil, err
+	}
+	q.Log.Info("Queued reprint", "uuid", j.Uuid, "history", e.Uuid)
+	q.publish(j)
+	q.signal()
+	return j, nil
+}
+
+func (j *Job) setSource(source *Source) {
+	if source != nil {
+		u := source.TemplateUuid
+		j.TemplateUuid = &u
+		j.TemplateRevision = source.Revision
+		j.Parameters = source.Parameters
+	}
+}
+
+// Encodes an image as PNG, ready to be submitted as a job
+func EncodeImage(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("Couldn't encode image for job:\n%w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Carries on printing queued jobs, e.g. once the printer is available again
+func (q *Queue) Resume() {
+	q.signal()
+}
+
+// Cancels a job, either by taking it out of the queue or by stopping it while
+// it's printing. Returns false if the job has already finished.
+func (q *Queue) Cancel(u uuid.UUID) (bool, error) {
+	q.mu.Lock()
+	r := q.running
+	q.mu.Unlock()
+	if r != nil && r.uuid == u {
+		// the printer may carry on printing the current label, but nothing
+		// else is sent to it
+		r.cancel()
+		<-r.done
+		j, err := q.Repository.Get(u)
+		if err != nil {
+			return false, err
+		}
+		return j != nil && j.Status == Cancelled, nil
+	}
+	cancelled, err := q.Repository.Cancel(u)
+	if cancelled {
+		q.publish(&Job{Uuid: u, Status: Cancelled})
+	}
+	return cancelled, err
+}
+
+// Lets anyone following the printer's events know a job's status changed
+func (q *Queue) publish(j *Job) {
+	q.Connection.Events().Publish(printer.Event{
+		Type:      printer.JobChanged,
+		JobUuid:   j.Uuid.String(),
+		JobStatus: string(j.Status),
+		Error:     j.Reason,
+	})
+}
+
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+		// worker has already been woken up
+	}
+}
+
+func (q *Queue) run() {
+	for range q.wake {
+		for {
+			j, err := q.Repository.NextQueued()
+			if err != nil {
+				q.Log.Error("Couldn't get next job", "error", err)
+				break
+			}
+			if j == nil {
+				break
+			}
+			// jobs are left queued while the printer is unavailable, until the
+			// queue is woken up again once it's back
+			if err := q.Connection.Connect(context.Background()); err != nil {
+				q.Log.Warn("Printer isn't available, leaving jobs queued", "error", err)
+				break
+			}
+			q.runJob(j)
+		}
+	}
+}
+
+func (q *Queue) runJob(j *Job) {
+	started, err := q.Repository.Start(j)
+	if err != nil {
+		q.Log.Error("Couldn't start job", "uuid", j.Uuid, "error", err)
+		return
+	}
+	if !started {
+		// cancelled since it was read from the queue
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &running{uuid: j.Uuid, cancel: cancel, done: make(chan struct{})}
+	q.mu.Lock()
+	q.running = r
+	q.mu.Unlock()
+	defer func() {
+		q.mu.Lock()
+		q.running = nil
+		q.mu.Unlock()
+		cancel()
+		close(r.done)
+	}()
+
+	q.Log.Info("Printing job", "uuid", j.Uuid)
+	q.publish(j)
+	status, reason := Done, ""
+	pb, err := q.print(ctx, j)
+	if err != nil {
+		if ctx.Err() != nil {
+			q.Log.Info("Job cancelled while printing", "uuid", j.Uuid)
+			status, reason = Cancelled, "Cancelled by user while printing"
+		} else {
+			q.Log.Error("Job failed", "uuid", j.Uuid, "error", err)
+			status, reason = Failed, err.Error()
+		}
+	} else {
+		q.Log.Info("Job finished", "uuid", j.Uuid)
+	}
+
+	if err := q.Repository.Finish(j, status, reason); err != nil {
+		q.Log.Error("Couldn't record job outcome", "uuid", j.Uuid, "error", err)
+	}
+	if pb != nil {
+		q.addHistory(j, pb)
+	}
+	q.publish(j)
+}
+
+// Prints a job, returning the bitmap which was sent to the printer, or nil if
+// it didn't get that far
+func (q *Queue) print(ctx context.Context, j *Job) (*bitmap.PackedBitmap, error) {
+	pb := j.Bitmap
+	if pb == nil {
+		img, _, err := image.Decode(bytes.NewReader(j.Image))
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't decode image: %w", err)
+		}
+		pb, err = bitmap.PackImage(img, q.Connection.Profile().DotsPerLine, bitmap.DefaultRenderOptions())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pb, q.Connection.GetPrinter().WriteBitmap(ctx, pb, j.Options)
+}
+
+// Keeps what a job printed in the print history. The job has already
+// finished by now, so this can only be logged if it fails.
+func (q *Queue) addHistory(j *Job, pb *bitmap.PackedBitmap) {
+	e := &HistoryEntry{
+		Uuid:             uuid.New(),
+		JobUuid:          j.Uuid,
+		TemplateUuid:     j.TemplateUuid,
+		TemplateRevision: j.TemplateRevision,
+		Parameters:       j.Parameters,
+		ReprintOf:        j.ReprintOf,
+		Options:          j.Options,
+		Outcome:          j.Status,
+		Reason:           j.Reason,
+		PrintedAt:        time.Now(),
+		Bitmap:           pb,
+	}
+	if err := q.Repository.AddHistory(e); err != nil {
+		q.Log.Error("Couldn't add job to print history", "uuid", j.Uuid, "error", err)
+	}
+}
@@ -0,0 +1,169 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"image"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// A simulated printer which can be switched off, so connecting to it fails
+type switchableConnection struct {
+	*printer.SimulatedConnection
+	off atomic.Bool
+}
+
+func (c *switchableConnection) Connect(ctx context.Context) error {
+	if c.off.Load() {
+		return errors.New("Printer is switched off")
+	}
+	return c.SimulatedConnection.Connect(ctx)
+}
+
+// A queue which isn't started yet, printing to a simulated printer
+func aQueue(t *testing.T) (*Queue, *switchableConnection) {
+	options := printer.DefaultSimulatorOptions()
+	options.PrintDuration = 300 * time.Millisecond
+	conn := &switchableConnection{SimulatedConnection: printer.NewSimulatedConnection(options)}
+	t.Cleanup(func() { conn.Disconnect() })
+	return NewQueue(slog.Default(), aRepository(t), conn), conn
+}
+
+// Queues a blank label of the given height
+func aLabel(t *testing.T, q *Queue, height int, options printer.PrintOptions) *Job {
+	j, err := q.SubmitImage(image.NewGray(image.Rect(0, 0, 384, height)), nil, options)
+	if err != nil {
+		t.Fatalf("Couldn't submit job: %v", err)
+	}
+	return j
+}
+
+func waitForStatus(t *testing.T, q *Queue, u uuid.UUID, status Status) *Job {
+	t.Helper()
+	var j *Job
+	for range 250 {
+		var err error
+		if j, err = q.Repository.Get(u); err != nil {
+			t.Fatal(err)
+		}
+		if j != nil && j.Status == status {
+			return j
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if j == nil {
+		t.Fatalf("Expected job to be %v, but it doesn't exist", status)
+	}
+	t.Fatalf("Expected job to be %v, was %v", status, j.Status)
+	return nil
+}
+
+func TestJobsArePrintedInTheOrderTheyWereQueued(t *testing.T) {
+	q, conn := aQueue(t)
+	js := []*Job{
+		aLabel(t, q, 10, printer.DefaultPrintOptions()),
+		aLabel(t, q, 20, printer.DefaultPrintOptions()),
+		aLabel(t, q, 30, printer.DefaultPrintOptions()),
+	}
+	if err := q.Start(); err != nil {
+		t.Fatal(err)
+	}
+	for _, j := range js {
+		waitForStatus(t, q, j.Uuid, Done)
+	}
+
+	labels := conn.Labels()
+	if len(labels) != 3 {
+		t.Fatalf("Expected 3 labels, got %v", len(labels))
+	}
+	for i, height := range []int{10, 20, 30} {
+		if labels[i].Rect.Dy() != height {
+			t.Errorf("Expected label %v to be %v dots long, was %v", i+1, height, labels[i].Rect.Dy())
+		}
+	}
+}
+
+func TestCancellingJobs(t *testing.T) {
+	q, conn := aQueue(t)
+	options := printer.DefaultPrintOptions()
+	options.Copies = 3
+	printing := aLabel(t, q, 10, options)
+	queued := aLabel(t, q, 20, printer.DefaultPrintOptions())
+	if err := q.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, q, printing.Uuid, Printing)
+
+	if cancelled, err := q.Cancel(queued.Uuid); err != nil || !cancelled {
+		t.Errorf("Expected queued job to be cancelled, got %v, %v", cancelled, err)
+	}
+	if j := waitForStatus(t, q, queued.Uuid, Cancelled); j.StartedAt != nil {
+		t.Errorf("Expected cancelled job to never start")
+	}
+
+	if cancelled, err := q.Cancel(printing.Uuid); err != nil || !cancelled {
+		t.Errorf("Expected printing job to be cancelled, got %v, %v", cancelled, err)
+	}
+	if j := waitForStatus(t, q, printing.Uuid, Cancelled); j.Reason != "Cancelled by user while printing" {
+		t.Errorf("Expected job to say it was cancelled while printing, got %q", j.Reason)
+	}
+	if n := len(conn.Labels()); n != 1 {
+		t.Errorf("Expected only the first copy to be printed, got %v labels", n)
+	}
+
+	if cancelled, err := q.Cancel(printing.Uuid); err != nil || cancelled {
+		t.Errorf("Expected a finished job not to be cancelled again, got %v, %v", cancelled, err)
+	}
+}
+
+func TestJobsLeftPrintingFailWhenTheQueueStarts(t *testing.T) {
+	q, conn := aQueue(t)
+	interrupted := aLabel(t, q, 10, printer.DefaultPrintOptions())
+	if started, err := q.Repository.Start(interrupted); err != nil || !started {
+		t.Fatalf("Couldn't start job: %v", err)
+	}
+	queued := aLabel(t, q, 20, printer.DefaultPrintOptions())
+	if err := q.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	j := waitForStatus(t, q, interrupted.Uuid, Failed)
+	if j.Reason != "Server stopped while job was printing" {
+		t.Errorf("Expected job to say it was interrupted, got %q", j.Reason)
+	}
+	waitForStatus(t, q, queued.Uuid, Done)
+	if n := len(conn.Labels()); n != 1 {
+		t.Errorf("Expected only the queued job to be printed, got %v labels", n)
+	}
+}
+
+func TestJobsStayQueuedWhileThePrinterIsUnavailable(t *testing.T) {
+	q, conn := aQueue(t)
+	conn.off.Store(true)
+	j := aLabel(t, q, 10, printer.DefaultPrintOptions())
+	if err := q.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	got, err := q.Repository.Get(j.Uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != Queued {
+		t.Errorf("Expected job to stay queued, was %v", got.Status)
+	}
+
+	conn.off.Store(false)
+	q.Resume()
+	waitForStatus(t, q, j.Uuid, Done)
+	if n := len(conn.Labels()); n != 1 {
+		t.Errorf("Expected the job to be printed once the printer is back, got %v labels", n)
+	}
+}
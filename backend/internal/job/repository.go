@@ -0,0 +1,257 @@
+package job
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+)
+
+type JobRepository struct {
+	Db *sql.DB
+}
+
+// The image column is substituted in, so it can be left out (as NULL) when
+// listing jobs
+const selectJob = `
+	SELECT j.id, j.uuid, j.status, j.reason, t.uuid, j.template_revision, j.parameters, j.reprint_of, j.batch_uuid,
+		j.bitmap_width, j.bitmap_height, %s,
+		j.intensity, j.feed_lines, j.justify, j.copies, j.created_at, j.started_at, j.finished_at
+	FROM print_job j
+	LEFT JOIN template t ON t.id = j.template_id`
+
+func scanJob(row interface{ Scan(...any) error }, j *Job) error {
+	var uuidString string
+	var reason, templateUuidString, parameters, reprintOfString, batchUuidString sql.NullString
+	var templateRevision, bitmapWidth, bitmapHeight sql.NullInt64
+	var startedAt, finishedAt sql.NullTime
+	if err := row.Scan(&j.Id, &uuidString, &j.Status, &reason, &templateUuidString, &templateRevision, &parameters, &reprintOfString, &batchUuidString,
+		&bitmapWidth, &bitmapHeight, &j.Image,
+		&j.Options.Intensity, &j.Options.FeedLines, &j.Options.Justify, &j.Options.Copies,
+		&j.CreatedAt, &startedAt, &finishedAt); err != nil {
+		return err
+	}
+	if bitmapWidth.Valid && j.Image != nil {
+		b, err := bitmap.NewPackedBitmap(j.Image, int(bitmapWidth.Int64), int(bitmapHeight.Int64))
+		if err != nil {
+			return err
+		}
+		j.Bitmap, j.Image = b, nil
+	}
+	if parameters.Valid {
+		if err := json.Unmarshal([]byte(parameters.String), &j.Parameters); err != nil {
+			return fmt.Errorf("Couldn't decode job parameters:\n%w", err)
+		}
+	}
+	if reprintOfString.Valid {
+		u := uuid.MustParse(reprintOfString.String)
+		j.ReprintOf = &u
+	}
+	j.Uuid = uuid.MustParse(uuidString)
+	j.Reason = reason.String
+	if templateUuidString.Valid {
+		u := uuid.MustParse(templateUuidString.String)
+		j.TemplateUuid = &u
+		j.TemplateRevision = int(templateRevision.Int64)
+	}
+	if batchUuidString.Valid {
+		u := uuid.MustParse(batchUuidString.String)
+		j.BatchUuid = &u
+	}
+	if startedAt.Valid {
+		j.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		j.FinishedAt = &finishedAt.Time
+	}
+	return nil
+}
+
+func (r *JobRepository) Create(j *Job) error {
+	return create(r.Db, j)
+}
+
+// Creates several jobs at once, so either all of them are queued or none are
+func (r *JobRepository) CreateAll(js []*Job) error {
+	tx, err := r.Db.Begin()
+	if err != nil {
+		return fmt.Errorf("Couldn't begin transaction:\n%w", err)
+	}
+	defer tx.Rollback()
+	for _, j := range js {
+		if err := create(tx, j); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func create(db interface{ QueryRow(string, ...any) *sql.Row }, j *Job) error {
+	var templateUuid, templateRevision, reprintOf, batchUuid, bitmapWidth, bitmapHeight any
+	if j.TemplateUuid != nil {
+		templateUuid = j.TemplateUuid.String()
+		templateRevision = j.TemplateRevision
+	}
+	parameters, err := encodeParameters(j.Parameters)
+	if err != nil {
+		return err
+	}
+	if j.ReprintOf != nil {
+		reprintOf = j.ReprintOf.String()
+	}
+	if j.BatchUuid != nil {
+		batchUuid = j.BatchUuid.String()
+	}
+	imageData := j.Image
+	if j.Bitmap != nil {
+		imageData, bitmapWidth, bitmapHeight = j.Bitmap.Data(), j.Bitmap.Width(), j.Bitmap.Height()
+	}
+	row := db.QueryRow(`
+		INSERT INTO print_job(uuid, status, template_id, template_revision, parameters, reprint_of, batch_uuid,
+			bitmap_width, bitmap_height, image, intensity, feed_lines, justify, copies, created_at)
+		VALUES (?, ?, (SELECT id FROM template WHERE uuid = ?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`, j.Uuid.String(), j.Status, templateUuid, templateRevision, parameters, reprintOf, batchUuid,
+		bitmapWidth, bitmapHeight, imageData, j.Options.Intensity, j.Options.FeedLines, j.Options.Justify, j.Options.Copies, j.CreatedAt)
+	if err := row.Scan(&j.Id); err != nil {
+		return fmt.Errorf("Failed to insert into print_job:\n%w", err)
+	}
+	return nil
+}
+
+// Parameter values are kept as a JSON object, or NULL if there aren't any
+func encodeParameters(params map[string]string) (any, error) {
+	if params == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't encode parameter values:\n%w", err)
+	}
+	return string(data), nil
+}
+
+func (r *JobRepository) Get(u uuid.UUID) (*Job, error) {
+	row := r.Db.QueryRow(fmt.Sprintf(selectJob, "j.image")+` WHERE j.uuid = ?`, u.String())
+
+	var j Job
+	if err := scanJob(row, &j); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, fmt.Errorf("Failed to read job:\n%w", err)
+		}
+	}
+	return &j, nil
+}
+
+// Lists all jobs, oldest first. The image data isn't needed to describe a
+// job, so it's left out.
+func (r *JobRepository) List() ([]Job, error) {
+	return r.list(``)
+}
+
+// Lists the jobs in a batch in the order they're printed, without their
+// image data
+func (r *JobRepository) ListBatch(u uuid.UUID) ([]Job, error) {
+	return r.list(`WHERE j.batch_uuid = ?`, u.String())
+}
+
+func (r *JobRepository) list(where string, args ...any) ([]Job, error) {
+	rows, err := r.Db.Query(fmt.Sprintf(selectJob, "NULL") + ` ` + where + ` ORDER BY j.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query execution failed:\n%w", err)
+	}
+	defer rows.Close()
+
+	jobs := []Job{}
+	for rows.Next() {
+		var j Job
+		if err := scanJob(rows, &j); err != nil {
+			return nil, fmt.Errorf("Row scanning failed:\n%w", err)
+		}
+		jobs = append(jobs, j)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Error iterating rows:\n%w", err)
+	}
+
+	return jobs, nil
+}
+
+// Gets the oldest job which is still waiting to be printed, or nil if there
+// aren't any
+func (r *JobRepository) NextQueued() (*Job, error) {
+	row := r.Db.QueryRow(fmt.Sprintf(selectJob, "j.image")+` WHERE j.status = ? ORDER BY j.id LIMIT 1`, Queued)
+
+	var j Job
+	if err := scanJob(row, &j); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, fmt.Errorf("Failed to read next queued job:\n%w", err)
+		}
+	}
+	return &j, nil
+}
+
+// Marks a queued job as printing. Returns false if the job is no longer
+// queued, e.g. because it was cancelled in the meantime.
+func (r *JobRepository) Start(j *Job) (bool, error) {
+	now := time.Now()
+	res, err := r.Db.Exec(`
+		UPDATE print_job SET status = ?, started_at = ?
+		WHERE id = ? AND status = ?`, Printing, now, j.Id, Queued)
+	if err != nil {
+		return false, fmt.Errorf("Couldn't start job:\n%w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	j.Status, j.StartedAt = Printing, &now
+	return true, nil
+}
+
+// Records the outcome of a job which was printing, and why it failed or was
+// cancelled if it didn't succeed.
+func (r *JobRepository) Finish(j *Job, status Status, reason string) error {
+	now := time.Now()
+	_, err := r.Db.Exec(`
+		UPDATE print_job SET status = ?, reason = ?, finished_at = ?
+		WHERE id = ?`, status, sql.NullString{String: reason, Valid: reason != ""}, now, j.Id)
+	if err != nil {
+		return fmt.Errorf("Couldn't finish job:\n%w", err)
+	}
+	j.Status, j.Reason, j.FinishedAt = status, reason, &now
+	return nil
+}
+
+// Cancels a job if it hasn't started printing yet. Returns false if the job
+// isn't queued any more.
+func (r *JobRepository) Cancel(u uuid.UUID) (bool, error) {
+	res, err := r.Db.Exec(`
+		UPDATE print_job SET status = ?, reason = ?, finished_at = ?
+		WHERE uuid = ? AND status = ?`, Cancelled, "Cancelled by user", time.Now(), u.String(), Queued)
+	if err != nil {
+		return false, fmt.Errorf("Couldn't cancel job:\n%w", err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// Fails any jobs which were left printing, which happens if the server stops
+// partway through a job. There's no way of knowing how much of the label got
+// printed, so these aren't retried.
+func (r *JobRepository) FailInterrupted() (int64, error) {
+	res, err := r.Db.Exec(`
+		UPDATE print_job SET status = ?, reason = ?, finished_at = ?
+		WHERE status = ?`, Failed, "Server stopped while job was printing", time.Now(), Printing)
+	if err != nil {
+		return 0, fmt.Errorf("Couldn't fail interrupted jobs:\n%w", err)
+	}
+	return res.RowsAffected()
+}
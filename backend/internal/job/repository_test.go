@@ -0,0 +1,155 @@
+package job
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/database/dbtest"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+func aRepository(t *testing.T) *JobRepository {
+	return &JobRepository{Db: dbtest.Open(t)}
+}
+
+func TestBatchJobsKeepTheirDetailsWhenPrinted(t *testing.T) {
+	r := aRepository(t)
+	batchUuid := uuid.New()
+	js := make([]*Job, 3)
+	for i := range js {
+		// big enough to spill onto overflow pages
+		image := make([]byte, 200_000)
+		for j := range image {
+			image[j] = byte(i + j)
+		}
+		js[i] = &Job{
+			Uuid:      uuid.New(),
+			Status:    Queued,
+			BatchUuid: &batchUuid,
+			Image:     image,
+			Options:   printer.DefaultPrintOptions(),
+			CreatedAt: time.Now(),
+		}
+	}
+	if err := r.CreateAll(js); err != nil {
+		t.Fatalf("Couldn't create batch: %v", err)
+	}
+
+	next, err := r.NextQueued()
+	if err != nil || next == nil {
+		t.Fatalf("Couldn't get next job: %v", err)
+	}
+	if next.Uuid != js[0].Uuid {
+		t.Errorf("Expected first job in batch to be printed first")
+	}
+	if _, err := r.Start(next); err != nil {
+		t.Fatalf("Couldn't start job: %v", err)
+	}
+	if err := r.Finish(next, Done, ""); err != nil {
+		t.Fatalf("Couldn't finish job: %v", err)
+	}
+
+	batch, err := r.ListBatch(batchUuid)
+	if err != nil {
+		t.Fatalf("Couldn't list batch: %v", err)
+	}
+	if len(batch) != 3 {
+		t.Fatalf("Expected 3 jobs in batch, got %v", len(batch))
+	}
+	if batch[0].Status != Done || batch[1].Status != Queued {
+		t.Errorf("Unexpected statuses %v, %v", batch[0].Status, batch[1].Status)
+	}
+	if batch[0].Options != printer.DefaultPrintOptions() {
+		t.Errorf("Expected options to be unchanged after printing, were %+v", batch[0].Options)
+	}
+	if batch[0].StartedAt == nil || batch[0].CreatedAt.IsZero() {
+		t.Errorf("Expected job to have been created and started")
+	}
+	printed, err := r.Get(js[0].Uuid)
+	if err != nil || !bytes.Equal(printed.Image, js[0].Image) {
+		t.Errorf("Expected image to be unchanged after printing, %v", err)
+	}
+}
+
+func aHistoryEntry(templateUuid *uuid.UUID, outcome Status, printedAt time.Time) *HistoryEntry {
+	pb, _ := bitmap.NewPackedBitmap([]byte{0xf0, 0x0f, 0xaa, 0x55}, 12, 2)
+	return &HistoryEntry{
+		Uuid:         uuid.New(),
+		JobUuid:      uuid.New(),
+		TemplateUuid: templateUuid,
+		Parameters:   map[string]string{"sku": "AB12"},
+		Options:      printer.DefaultPrintOptions(),
+		Outcome:      outcome,
+		PrintedAt:    printedAt,
+		Bitmap:       pb,
+	}
+}
+
+func TestHistoryKeepsBitmapAsPrinted(t *testing.T) {
+	r := aRepository(t)
+	e := aHistoryEntry(nil, Done, time.Now())
+	if err := r.AddHistory(e); err != nil {
+		t.Fatalf("Couldn't add history: %v", err)
+	}
+
+	saved, err := r.GetHistory(e.Uuid)
+	if err != nil || saved == nil {
+		t.Fatalf("Couldn't get history entry: %v", err)
+	}
+	if saved.Width != 12 || saved.Height != 2 || !bytes.Equal(saved.Bitmap.Data(), e.Bitmap.Data()) {
+		t.Errorf("Expected bitmap to be unchanged, was %vx%v %v", saved.Width, saved.Height, saved.Bitmap.Data())
+	}
+	if saved.Parameters["sku"] != "AB12" || saved.Source() != ImageSource {
+		t.Errorf("Unexpected entry %+v", saved)
+	}
+}
+
+func TestHistoryCanBeFiltered(t *testing.T) {
+	r := aRepository(t)
+	templateUuid := uuid.New()
+	now := time.Now()
+	entries := []*HistoryEntry{
+		aHistoryEntry(nil, Done, now.Add(-48*time.Hour)),
+		aHistoryEntry(&templateUuid, Failed, now.Add(-time.Hour)),
+		aHistoryEntry(&templateUuid, Done, now),
+	}
+	for _, e := range entries {
+		if err := r.AddHistory(e); err != nil {
+			t.Fatalf("Couldn't add history: %v", err)
+		}
+	}
+
+	yesterday := now.Add(-24 * time.Hour)
+	for name, test := range map[string]struct {
+		filter   HistoryFilter
+		expected []*HistoryEntry
+	}{
+		"everything":  {HistoryFilter{}, []*HistoryEntry{entries[2], entries[1], entries[0]}},
+		"template":    {HistoryFilter{TemplateUuid: &templateUuid}, []*HistoryEntry{entries[2], entries[1]}},
+		"images":      {HistoryFilter{Source: ImageSource}, []*HistoryEntry{entries[0]}},
+		"outcome":     {HistoryFilter{Outcome: Done}, []*HistoryEntry{entries[2], entries[0]}},
+		"time":        {HistoryFilter{From: &yesterday, To: &now}, []*HistoryEntry{entries[1]}},
+		"limit":       {HistoryFilter{Limit: 1}, []*HistoryEntry{entries[2]}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			listed, err := r.ListHistory(test.filter)
+			if err != nil {
+				t.Fatalf("Couldn't list history: %v", err)
+			}
+			if len(listed) != len(test.expected) {
+				t.Fatalf("Expected %v entries, got %v", len(test.expected), len(listed))
+			}
+			for i, e := range test.expected {
+				if listed[i].Uuid != e.Uuid {
+					t.Errorf("Expected entry %v to be %v, was %v", i, e.Uuid, listed[i].Uuid)
+				}
+				if listed[i].Bitmap != nil {
+					t.Errorf("Expected bitmap to be left out of list")
+				}
+			}
+		})
+	}
+}
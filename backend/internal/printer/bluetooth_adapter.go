@@ -0,0 +1,242 @@
+package printer
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"tinygo.org/x/bluetooth"
+)
+
+type DeviceType byte
+const (
+	Service DeviceType = 0x00
+	Writer DeviceType = 0x02
+	Notifier DeviceType = 0x03
+)
+
+// The parts of the Bluetooth stack which a BluetoothConnection uses to find
+// and talk to a printer. Addresses are passed around as strings, so the
+// connection logic can be tested against a fake adapter on any platform.
+type Adapter interface {
+	// Scans for a device advertising the given local name and returns its
+	// address. A timeout of 0 scans until the device is found.
+	Scan(name string, timeout time.Duration) (string, error)
+	// Connects to the device at an address and discovers the printer's
+	// characteristics
+	Connect(address string) (Device, error)
+	// Sets the function called whenever a device disconnects
+	SetDisconnectHandler(func(address string))
+}
+
+// A connected printer, with the characteristics used to write commands to it
+// and receive notifications from it
+type Device interface {
+	Write(data []byte) error
+	EnableNotifications(func(data []byte)) error
+	Disconnect() error
+}
+
+// Implements Adapter using the system's Bluetooth adapter
+type tinygoAdapter struct {
+	adapter *bluetooth.Adapter
+	// every address seen so far, so they can be turned back into the
+	// platform-specific type when connecting
+	mu sync.Mutex
+	addresses map[string]bluetooth.Address
+}
+
+type tinygoDevice struct {
+	device bluetooth.Device
+	writer bluetooth.DeviceCharacteristic
+	notifier bluetooth.DeviceCharacteristic
+}
+
+func getUUID(t DeviceType) bluetooth.UUID {
+	return bluetooth.NewUUID([16]byte{
+		0x00, 0x00, 0xff, byte(t), 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0x80, 0x5f, 0x9b, 0x34, 0xfb,
+	})
+}
+
+func newTinygoAdapter() (*tinygoAdapter, error) {
+	adapter := bluetooth.DefaultAdapter
+
+	err := adapter.Enable()
+	if err != nil {
+		slog.Error("Failed to enable Bluetooth: ", "err", err)
+		return nil, err
+	}
+
+	return &tinygoAdapter{
+		adapter: adapter,
+		addresses: map[string]bluetooth.Address{},
+	}, nil
+}
+
+func (a *tinygoAdapter) remember(address bluetooth.Address) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := address.String()
+	a.addresses[s] = address
+	return s
+}
+
+func (a *tinygoAdapter) Scan(name string, timeout time.Duration) (string, error) {
+	found := make(chan bluetooth.ScanResult, 1)
+
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			a.adapter.StopScan()
+		})
+		defer timer.Stop()
+	}
+
+	err := a.adapter.Scan(func(adapter *bluetooth.Adapter, result bluetooth.ScanResult) {
+		if result.LocalName() == name {
+			slog.Info("Found device:",
+				"deviceName", result.LocalName(),
+			)
+			select {
+			case found <- result:
+			default:
+			}
+			adapter.StopScan()
+		}
+	})
+	if err != nil {
+		slog.Error("Failed to scan for devices:",
+			"err", err,
+		)
+		return "", err
+	}
+
+	select {
+	case result := <-found:
+		return a.remember(result.Address), nil
+	default:
+		return "", fmt.Errorf("No device called %s found", name)
+	}
+}
+
+// A device which was seen advertising itself while scanning
+type ScannedDevice struct {
+	Address string
+	LocalName string
+	// Signal strength in dBm, of the last advertisement seen
+	RSSI int16
+}
+
+// Scans for every device nearby for as long as the timeout, e.g. to find out
+// what a printer calls itself. Devices are returned strongest signal first.
+func ScanDevices(timeout time.Duration) ([]ScannedDevice, error) {
+	a, err := newTinygoAdapter()
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	seen := map[string]*ScannedDevice{}
+	timer := time.AfterFunc(timeout, func() {
+		a.adapter.StopScan()
+	})
+	defer timer.Stop()
+	err = a.adapter.Scan(func(adapter *bluetooth.Adapter, result bluetooth.ScanResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		address := result.Address.String()
+		d, ok := seen[address]
+		if !ok {
+			d = &ScannedDevice{Address: address}
+			seen[address] = d
+		}
+		// not every advertisement has the name in it
+		if name := result.LocalName(); name != "" {
+			d.LocalName = name
+		}
+		d.RSSI = result.RSSI
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan for devices:\n%w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	devices := make([]ScannedDevice, 0, len(seen))
+	for _, d := range seen {
+		devices = append(devices, *d)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].RSSI > devices[j].RSSI
+	})
+	return devices, nil
+}
+
+func (a *tinygoAdapter) Connect(address string) (Device, error) {
+	a.mu.Lock()
+	bluetoothAddress, ok := a.addresses[address]
+	a.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("Unknown device address %s", address)
+	}
+
+	slog.Debug("Connecting to device...")
+	device, err := a.adapter.Connect(bluetoothAddress, bluetooth.ConnectionParams{})
+	if err != nil {
+		slog.Error("Failed to connect to device:",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	// Discover the primary service (UUID 0xFF00)
+	slog.Debug("Discovering service...")
+	services, err := device.DiscoverServices([]bluetooth.UUID{getUUID(Service)})
+	if err != nil {
+		slog.Error("Failed to discover service:",
+			"err", err,
+		)
+		device.Disconnect()
+		return nil, err
+	}
+
+	slog.Debug("Discovering characteristics...")
+	characteristics, err := services[0].DiscoverCharacteristics([]bluetooth.UUID{getUUID(Writer), getUUID(Notifier)})
+	if err != nil {
+		slog.Error("Failed to discover characteristics:",
+			"err", err,
+		)
+		device.Disconnect()
+		return nil, err
+	}
+
+	return &tinygoDevice{
+		device: device,
+		writer: characteristics[0],
+		notifier: characteristics[1],
+	}, nil
+}
+
+func (a *tinygoAdapter) SetDisconnectHandler(f func(address string)) {
+	a.adapter.SetConnectHandler(func(d bluetooth.Device, connected bool) {
+		if connected {
+			slog.Info("Connected!")
+		} else {
+			f(d.Address.String())
+		}
+	})
+}
+
+func (d *tinygoDevice) Write(data []byte) error {
+	_, err := d.writer.WriteWithoutResponse(data)
+	return err
+}
+
+func (d *tinygoDevice) EnableNotifications(f func(data []byte)) error {
+	return d.notifier.EnableNotifications(f)
+}
+
+func (d *tinygoDevice) Disconnect() error {
+	return d.device.Disconnect()
+}
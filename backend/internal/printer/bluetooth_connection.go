@@ -5,113 +5,124 @@ package printer
 
 import (
 	"bytes"
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"tinygo.org/x/bluetooth"
 )
 
-type DeviceType byte
-const (
-	Service DeviceType = 0x00
-	Writer DeviceType = 0x02
-	Notifier DeviceType = 0x03
-)
-
 type BluetoothConnection struct {
-	device bluetooth.Device
-	adapter *bluetooth.Adapter
-	writer bluetooth.DeviceCharacteristic
-	notifier bluetooth.DeviceCharacteristic
-	printer PhomemoPrinter
-	address bluetooth.Address
+	adapter Adapter
+	// replaced each time the printer connects, while the supervisor and
+	// notifications from the old connection may still be using it
+	printer atomic.Pointer[PhomemoPrinter]
+	// guards address, which changes if the printer is found somewhere else
+	// when rescanning, and the device and profile, which change each time
+	// the supervisor reconnects while requests are using them
+	mu sync.Mutex
+	address string
+	device Device
+	profile Profile
+	// The name the printer advertises itself with, if it was found by name
+	name string
+	Timeouts Timeouts
+	events *EventBus
+	// Called when the printer disconnects without Disconnect being called
+	onDisconnect func()
 }
 
-func getUUID(t DeviceType) bluetooth.UUID {
-	return bluetooth.NewUUID([16]byte{
-		0x00, 0x00, 0xff, byte(t), 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0x80, 0x5f, 0x9b, 0x34, 0xfb,
-	})
-}
-
-func newBluetoothConnection() (*BluetoothConnection, error) {
-	adapter := bluetooth.DefaultAdapter
-
-	err := adapter.Enable()
-	if err != nil {
-		slog.Error("Failed to enable Bluetooth: ", "err", err)
-		return nil, err
+// Creates a connection to the printer at an address, using the given adapter.
+// The name is used to find the printer again if it can't be reached at that
+// address, and can be left empty if it isn't known.
+func NewBluetoothConnection(adapter Adapter, name string, address string) *BluetoothConnection {
+	conn := &BluetoothConnection{
+		adapter: adapter,
+		address: address,
+		name: name,
+		profile: DefaultProfile(),
+		Timeouts: DefaultTimeouts(),
+		events: NewEventBus(),
 	}
-
-	conn := &BluetoothConnection{adapter:adapter}
-	adapter.SetConnectHandler(func(d bluetooth.Device, connected bool) {
-		if connected {
-			slog.Info("Connected!")
-		} else {
-			if d.Address == conn.address && conn.printer.IsConnected() {
-				slog.Info("Disconnected!")
-				conn.printer.uninitialise()
-			} else {
-				slog.Info("Disconnected event fired but printer is not connected or address doesn't match")
+	if name != "" {
+		conn.profile = profileForLocalName(name)
+	}
+	conn.printer.Store(&PhomemoPrinter{})
+
+	adapter.SetDisconnectHandler(func(address string) {
+		if p := conn.printer.Load(); address == conn.Address() && p.IsConnected() {
+			slog.Info("Disconnected!")
+			p.uninitialise()
+			if conn.onDisconnect != nil {
+				conn.onDisconnect()
 			}
+		} else {
+			slog.Info("Disconnected event fired but printer is not connected or address doesn't match")
 		}
 	})
 
-	return conn, nil
+	return conn
 }
 
 func FromBluetoothName(name string) (*BluetoothConnection, error) {
-	p, err := newBluetoothConnection()
-
+	adapter, err := newTinygoAdapter()
 	if err != nil {
 		slog.Error("Couldn't initialise conn", "error", err)
 		return nil, err
 	}
 
-	devices := make(chan bluetooth.ScanResult, 1)
-
-	go func() {
-		err := p.adapter.Scan(func(adapter *bluetooth.Adapter, result bluetooth.ScanResult) {
-			if result.LocalName() == name {
-				slog.Info("Found device:",
-					"deviceName", result.LocalName(),
-				)
-				devices <- result
-				adapter.StopScan()
-			}
-		})
-		if err != nil {
-			slog.Error("Failed to scan for devices:",
-				"err", err,
-			)
-			close(devices)
-		}
-	}()
-
-	dev, ok := <-devices
-
-	if !ok {
-		return nil, errors.New("No devices found")
+	address, err := adapter.Scan(name, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	p.address = dev.Address
-	return p, nil
+	return NewBluetoothConnection(adapter, name, address), nil
 }
 
-func FromBluetoothAddress(address bluetooth.Address) (*BluetoothConnection, error) {
-	p, err := newBluetoothConnection()
-
+// Creates a connection to the printer at a known address, without scanning for
+// it first. The name decides which model it's treated as, and is used to find
+// the printer again if it can't be reached at that address.
+func FromBluetoothAddress(name string, address bluetooth.Address) (*BluetoothConnection, error) {
+	adapter, err := newTinygoAdapter()
 	if err != nil {
 		slog.Error("Couldn't initialise connection", "error", err)
 		return nil, err
 	}
 
-	p.address = address
-	return p, nil
+	return NewBluetoothConnection(adapter, name, adapter.remember(address)), nil
+}
+
+// Parses a printer's address, which is a MAC address like 01:23:45:67:89:AB
+// on Linux and Windows, or a UUID on macOS
+func ParseAddress(s string) (bluetooth.Address, error) {
+	var address bluetooth.Address
+	// Set ignores addresses it can't parse, leaving them zero, and MAC
+	// addresses have to be upper case
+	address.Set(strings.ToUpper(s))
+	if !strings.EqualFold(address.String(), s) {
+		return address, fmt.Errorf("Invalid Bluetooth address %q", s)
+	}
+	return address, nil
+}
+
+func (p *BluetoothConnection) Address() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.address
+}
+
+func (p *BluetoothConnection) currentDevice() Device {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.device
 }
 
 func (p *BluetoothConnection) Write(data []byte) error {
-	_, err := p.writer.WriteWithoutResponse(data)
+	err := p.currentDevice().Write(data)
 
 	if err != nil {
 		slog.Error("Couldn't write data", "error", err)
@@ -123,87 +134,110 @@ func (p *BluetoothConnection) Write(data []byte) error {
 }
 
 func (p *BluetoothConnection) Disconnect() error {
-	if p.printer.IsConnected() {
-		p.device.Disconnect()
+	if p.printer.Load().IsConnected() {
+		p.currentDevice().Disconnect()
 	}
 	return nil
 }
 
-func (p *BluetoothConnection) Connect() error {
-	if !p.printer.IsConnected() {
-		var err error
+// Connects to the printer and waits for it to become ready, for up to the
+// connect timeout
+func (p *BluetoothConnection) Connect(ctx context.Context) error {
+	if !p.printer.Load().IsConnected() {
+		ctx, cancel := context.WithTimeout(ctx, p.Timeouts.Connect)
+		defer cancel()
+
 		// connect to bluetooth device & get characteristics
-		if err = p.connect(); err != nil {
+		device, err := p.adapter.Connect(p.Address())
+		if err != nil {
 			slog.Error("Couldn't connect to bluetooth printer", "error", err)
 			return err
 		}
 
-		c := make(chan bool)
-		p.printer = initialise(p, c)
+		profile := p.Profile()
+		if p.name != "" {
+			profile = profileForLocalName(p.name)
+		}
+		p.mu.Lock()
+		p.device, p.profile = device, profile
+		p.mu.Unlock()
+		slog.Info("Using printer profile", "profile", profile.Name, "dotsPerLine", profile.DotsPerLine)
+
+		c := make(chan bool, 1)
+		printer := initialise(p, c, profile, p.Timeouts, p.events)
+		p.printer.Store(&printer)
 
 		// enable notifications from device to receive ready notification/battery info etc
-		err = p.notifier.EnableNotifications(func (data []byte) {
-			handleBluetoothDataFromPrinter(data, &p.printer)
+		err = device.EnableNotifications(func (data []byte) {
+			handleBluetoothDataFromPrinter(data, &printer)
 		})
 
 		if err != nil {
 			slog.Error("Couldn't enable notifications:",
 				"error", err,
 			)
-			p.device.Disconnect()
+			printer.uninitialise()
+			device.Disconnect()
 			return err
 		}
 
-		if !<-c {
-			return fmt.Errorf("Printer disconnected before becoming ready")
+		select {
+		case <-c:
+		case <-ctx.Done():
+			slog.Error("Printer didn't become ready", "error", ctx.Err())
+			printer.uninitialise()
+			device.Disconnect()
+			return fmt.Errorf("Printer didn't become ready:\n%w", ctx.Err())
 		}
-
-		close(c)
 	}
 	return nil
 }
 
 func (p *BluetoothConnection) GetPrinter() Printer {
-	return &p.printer
+	return p.printer.Load()
 }
 
-func (p *BluetoothConnection) connect() error {
-	slog.Debug("Connecting to device...")
-	device, err := p.adapter.Connect(p.address, bluetooth.ConnectionParams{})
-	if err != nil {
-		slog.Error("Failed to connect to device:",
-			"err", err,
-		)
-		return err
-	}
+func (p *BluetoothConnection) Profile() Profile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.profile
+}
 
-	// Discover the primary service (UUID 0xFF00)
-	slog.Debug("Discovering service...")
-	services, err := device.DiscoverServices([]bluetooth.UUID{getUUID(Service)})
-	if err != nil {
-		slog.Error("Failed to discover service:",
-			"err", err,
-		)
-		device.Disconnect()
-		return err
-	}
+func (p *BluetoothConnection) Events() *EventBus {
+	return p.events
+}
 
-	slog.Debug("Discovering characteristics...")
-	characteristics, err := services[0].DiscoverCharacteristics([]bluetooth.UUID{getUUID(Writer), getUUID(Notifier)})
+// Looks for the printer by name again, in case it's now at a different
+// address. Printers connected to by address can't be looked for.
+func (p *BluetoothConnection) rescan(timeout time.Duration) error {
+	if p.name == "" {
+		return fmt.Errorf("Printer was connected to by address, so can't be looked for by name")
+	}
+	address, err := p.adapter.Scan(p.name, timeout)
 	if err != nil {
-		slog.Error("Failed to discover characteristics:",
-			"err", err,
-		)
-		device.Disconnect()
 		return err
 	}
-	p.writer = characteristics[0]
-	p.notifier = characteristics[1]
 
-	p.device = device
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if address != p.address {
+		slog.Info("Printer address changed", "name", p.name, "old", p.address, "new", address)
+		p.address = address
+	}
 	return nil
 }
 
+// Printers connected to by address don't have a name to go by, and unknown
+// models are most likely to behave like the T02, so both use its profile.
+func profileForLocalName(name string) Profile {
+	profile, ok := ProfileForName(name)
+	if !ok {
+		slog.Warn("Unknown printer model, assuming it's a T02", "name", name)
+		return DefaultProfile()
+	}
+	return profile
+}
+
 func hasPrefix(d []byte, p ...byte) bool {
 	return len(d) >= len(p) && bytes.Equal(d[:len(p)], p)
 }
@@ -57,12 +57,11 @@ func printBitmapHeader(widthBytes byte, heightBits uint16) []byte {
 	}
 }
 
-const maxBitmapHeight = 256
 // Returns one or more commands and bitmap data blocks which will print the
 // provided bitmap to a phomemo printer, splitting the bitmap up vertically
 // if the bitmap height is greater than the max supported individual bitmap
 // height
-func printBitmap(b *bitmap.PackedBitmap) []byte {
+func printBitmap(b *bitmap.PackedBitmap, maxBitmapHeight int) []byte {
 	d := []byte{}
 	strideU8 := byte(b.Stride())
 
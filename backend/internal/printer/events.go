@@ -0,0 +1,84 @@
+package printer
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	StateChanged   EventType = "STATE_CHANGED"
+	BatteryChanged EventType = "BATTERY_CHANGED"
+	// The paper ran out or was loaded, or the lid was opened or closed
+	PaperChanged   EventType = "PAPER_CHANGED"
+	FirmwareInfo   EventType = "FIRMWARE_INFO"
+	PrintStarted   EventType = "PRINT_STARTED"
+	PrintFinished  EventType = "PRINT_FINISHED"
+	PrintFailed    EventType = "PRINT_FAILED"
+	// A print job changed status. Published by the job queue rather than the
+	// printer, so subscribers can follow jobs on the same stream.
+	JobChanged     EventType = "JOB_CHANGED"
+)
+
+// Something which happened to the printer. Only the fields relevant to the
+// type of event are set.
+type Event struct {
+	Type            EventType
+	Time            time.Time
+	State           DeviceState
+	BatteryLevel    int
+	PaperLoaded     bool
+	FirmwareVersion string
+	Copies          int
+	Error           string
+	JobUuid         string
+	JobStatus       string
+}
+
+// Passes events on to any number of subscribers. Each subscriber has its own
+// buffer, and events are dropped for subscribers which fall too far behind
+// rather than holding up the printer.
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: map[chan Event]struct{}{}}
+}
+
+// Returns a channel which receives every event published from now on, and a
+// function to call to stop receiving them, which closes the channel.
+func (b *EventBus) Subscribe(buffer int) (<-chan Event, func()) {
+	c := make(chan Event, buffer)
+	b.mu.Lock()
+	b.subscribers[c] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return c, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, c)
+			b.mu.Unlock()
+			close(c)
+		})
+	}
+}
+
+func (b *EventBus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.subscribers {
+		select {
+		case c <- e:
+		default:
+			slog.Warn("Dropped event for slow subscriber", "type", e.Type)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package printer
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+)
+
+func TestEventBusDeliversToEverySubscriber(t *testing.T) {
+	b := NewEventBus()
+	first, unsubscribeFirst := b.Subscribe(4)
+	second, unsubscribeSecond := b.Subscribe(4)
+	defer unsubscribeSecond()
+
+	b.Publish(Event{Type: BatteryChanged, BatteryLevel: 50})
+	for _, c := range []<-chan Event{first, second} {
+		if e := <-c; e.Type != BatteryChanged || e.BatteryLevel != 50 || e.Time.IsZero() {
+			t.Errorf("Unexpected event %+v", e)
+		}
+	}
+
+	unsubscribeFirst()
+	b.Publish(Event{Type: BatteryChanged, BatteryLevel: 40})
+	if _, ok := <-first; ok {
+		t.Errorf("Expected no events after unsubscribing")
+	}
+	if e := <-second; e.BatteryLevel != 40 {
+		t.Errorf("Expected second subscriber to still get events, got %+v", e)
+	}
+}
+
+func TestEventBusDropsEventsForSlowSubscribers(t *testing.T) {
+	b := NewEventBus()
+	c, unsubscribe := b.Subscribe(1)
+	defer unsubscribe()
+
+	b.Publish(Event{Type: BatteryChanged, BatteryLevel: 50})
+	b.Publish(Event{Type: BatteryChanged, BatteryLevel: 40})
+	if e := <-c; e.BatteryLevel != 50 {
+		t.Errorf("Expected first event, got %+v", e)
+	}
+	select {
+	case e := <-c:
+		t.Errorf("Expected second event to be dropped, got %+v", e)
+	default:
+	}
+}
+
+func TestPrinterPublishesEvents(t *testing.T) {
+	options := DefaultSimulatorOptions()
+	options.PrintDuration = 200 * time.Millisecond
+	c := NewSimulatedConnection(options)
+	events, unsubscribe := c.Events().Subscribe(64)
+	defer unsubscribe()
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Couldn't connect to simulator: %v", err)
+	}
+	if err := c.GetPrinter().WriteImage(context.Background(), image.NewGray(image.Rect(0, 0, 384, 20)), DefaultPrintOptions()); err != nil {
+		t.Fatalf("Couldn't print image: %v", err)
+	}
+	c.SetBatteryLevel(50)
+	c.SetPaperLoaded(false)
+	time.Sleep(50 * time.Millisecond)
+	if level := c.GetPrinter().Info().BatteryLevel; level != 50 {
+		t.Errorf("Expected the battery level to be 50, was %v", level)
+	}
+	c.Disconnect()
+
+	var types []EventType
+	var states []DeviceState
+	for len(events) > 0 {
+		e := <-events
+		types = append(types, e.Type)
+		if e.Type == StateChanged {
+			states = append(states, e.State)
+		}
+	}
+
+	wantStates := []DeviceState{Connecting, Ready, Busy, Ready, OutOfPaper, Disconnected}
+	if len(states) != len(wantStates) {
+		t.Fatalf("Expected states %v, got %v", wantStates, states)
+	}
+	for i := range wantStates {
+		if states[i] != wantStates[i] {
+			t.Errorf("Expected states %v, got %v", wantStates, states)
+			break
+		}
+	}
+	for _, want := range []EventType{BatteryChanged, PaperChanged, FirmwareInfo, PrintStarted, PrintFinished} {
+		found := false
+		for _, got := range types {
+			found = found || got == want
+		}
+		if !found {
+			t.Errorf("Expected a %v event, got %v", want, types)
+		}
+	}
+}
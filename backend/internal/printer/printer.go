@@ -4,6 +4,7 @@
 package printer
 
 import (
+	"context"
 	"fmt"
 	"time"
 	"log/slog"
@@ -15,10 +16,28 @@ import (
 type PhomemoPrinter struct {
 	connected chan bool
 	finished chan bool
+	// closed when the printer disconnects. finished isn't closed instead,
+	// as the printer may still send a finished signal afterwards.
+	disconnected chan struct{}
 	writer DeviceWriter
-	statusTicker *time.Ticker
-	info DeviceInfo
+	profile Profile
+	timeouts Timeouts
+	events *EventBus
 	printLock sync.Mutex
+
+	// guards everything below, which notifications from the printer change
+	// on their own goroutine
+	mu sync.Mutex
+	// stops the status poller when the printer disconnects
+	stopPolling context.CancelFunc
+	info DeviceInfo
+	// nil until the printer first reports its paper status
+	paperLoaded *bool
+	// Labels which were given up on while they were printing, whose finished
+	// signals are still to come. The printer stays Busy until they've all
+	// arrived, or idle is closed, so they aren't taken for the next label's.
+	unfinished int
+	idle chan struct{}
 }
 
 // Writing data via this interface decouples the actual printer logic from
@@ -29,40 +48,85 @@ type DeviceWriter interface {
 	Write(data []byte) error
 }
 
-func initialise(w DeviceWriter, c chan bool) PhomemoPrinter {
+func initialise(w DeviceWriter, c chan bool, profile Profile, timeouts Timeouts, events *EventBus) PhomemoPrinter {
 	info := DeviceInfo{
 		State: Connecting,
 	}
+	events.Publish(Event{Type: StateChanged, State: Connecting})
 	return PhomemoPrinter{
 		connected: c,
 		finished: make(chan bool),
-		statusTicker: time.NewTicker(10 * time.Second),
+		disconnected: make(chan struct{}),
+		stopPolling: func() {},
 		writer: w,
 		info: info,
+		profile: profile,
+		timeouts: timeouts,
+		events: events,
 	}
 }
 
+// Disconnects the printer for good. A new printer is initialised each time
+// it connects again, so nothing which arrives afterwards can bring this one
+// back.
 func (p *PhomemoPrinter) uninitialise() error {
-	p.statusTicker.Stop()
-	close(p.finished)
-	p.info.State = Disconnected
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.info.State == Disconnected {
+		return nil
+	}
+	p.stopPolling()
+	close(p.disconnected)
+	p.setState(Disconnected)
 
 	return nil
 }
 
+// Changes the state of the printer, letting subscribers know if it's
+// different. A disconnected printer stays disconnected. p.mu must be held.
+func (p *PhomemoPrinter) setState(s DeviceState) {
+	if p.info.State != s && p.info.State != Disconnected {
+		p.info.State = s
+		p.events.Publish(Event{Type: StateChanged, State: s})
+	}
+}
+
+// Changes the state of the printer if it's in the state given, reporting
+// whether it was
+func (p *PhomemoPrinter) changeState(from DeviceState, to DeviceState) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.info.State != from {
+		return false
+	}
+	p.setState(to)
+	return true
+}
+
+func (p *PhomemoPrinter) state() DeviceState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.info.State
+}
+
 func (p *PhomemoPrinter) IsConnected() bool {
-	return p.info.State != Disconnected
+	return p.state() != Disconnected
 }
 
 func (p *PhomemoPrinter) Info() DeviceInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.info
 }
 
-func (p *PhomemoPrinter) pollStatus() error {
-	if p.info.State != Disconnected && p.info.State != Busy {
+func (p *PhomemoPrinter) pollStatus(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s := p.state(); s != Disconnected && s != Busy {
 		p.printLock.Lock()
 		defer p.printLock.Unlock()
-		if p.info.State != Disconnected && p.info.State != Busy {
+		if s := p.state(); s != Disconnected && s != Busy {
 			slog.Debug("Polling device status")
 			data := initPrinter()
 			data = append(data, queryBatteryStatus()...)
@@ -77,45 +141,53 @@ func (p *PhomemoPrinter) pollStatus() error {
 	return fmt.Errorf("Printer is not in ready state")
 }
 
-func (p *PhomemoPrinter) WriteImage(i image.Image) error {
-	ig := bitmap.RenderForDevice(i)
-	b, err := bitmap.FromPaletted(ig)
-	pb := bitmap.PackBitmap(b)
-
+// Prints an image, waiting until the printer has finished printing every copy.
+// The image is dithered and packed first, then printed like WriteBitmap.
+func (p *PhomemoPrinter) WriteImage(ctx context.Context, i image.Image, o PrintOptions) error {
+	pb, err := bitmap.PackImage(i, p.profile.DotsPerLine, bitmap.DefaultRenderOptions())
 	if err != nil {
 		slog.Error("Couldn't create packed bitmap from paletted image", "error", err)
 		return err
 	}
+	return p.WriteBitmap(ctx, pb, o)
+}
+
+// Prints a bitmap which is already packed, waiting until the printer has
+// finished printing every copy. Each copy has to finish printing within the
+// print timeout, and cancelling the context stops waiting for the printer;
+// either way the printer stays Busy until the label it was printing has
+// finished, and the next bitmap waits for it before being sent.
+func (p *PhomemoPrinter) WriteBitmap(ctx context.Context, pb *bitmap.PackedBitmap, o PrintOptions) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	if !p.profile.SupportsIntensity(o.Intensity) {
+		return fmt.Errorf("%s printers don't support laser intensity %v", p.profile.Name, o.Intensity)
+	}
+	if pb.Width() > p.profile.DotsPerLine {
+		return fmt.Errorf("Bitmap is %v dots wide, but %s printers only print %v", pb.Width(), p.profile.Name, p.profile.DotsPerLine)
+	}
 
 	slog.Debug("Acquiring lock on printer state")
-	if p.info.State == Ready {
+	if p.readyOrFinishing() {
 		p.printLock.Lock()
 		defer p.printLock.Unlock()
-		if p.info.State == Ready {
-			p.info.State = Busy
+		if err := p.waitForUnfinished(ctx); err != nil {
+			return err
+		}
+		if p.changeState(Ready, Busy) {
+			// the state will have changed already if the printer disconnected
+			// or ran out of paper
+			defer p.finishPrinting()
 
-			if err := p.sendPackedBitmapToPrinter(pb); err != nil {
+			p.events.Publish(Event{Type: PrintStarted, Copies: o.Copies})
+			if err := p.printCopies(ctx, pb, o); err != nil {
+				p.events.Publish(Event{Type: PrintFailed, Copies: o.Copies, Error: err.Error()})
 				return err
 			}
 
-			// The device sometimes outputs an early "finished printing" signal
-			// right after the bitmap data is written, as well as the later one
-			// which the device outputs after printing is finished.
-			// A small delay is added here before waiting for the signal to 
-			// ignore the initial spurious one.
-			// This could probably be more elegant, but printing anything takes
-			// at least 1 second anyway, so sleeping for 100ms doesn't introduce
-			// any additional delay to the process.
-			time.Sleep(100 * time.Millisecond)
-			slog.Info("Waiting for printer to finish printing")
-			if !<-p.finished {
-				// TODO: add a timeout so it doesn't block forever and deadlock if the
-				// printer gets stuck?
-				return fmt.Errorf("Printer didn't finish successfully")
-			}
-
 			slog.Info("Printer finished printing")
-			p.info.State = Ready
+			p.events.Publish(Event{Type: PrintFinished, Copies: o.Copies})
 
 			return nil
 		}
@@ -126,33 +198,182 @@ func (p *PhomemoPrinter) WriteImage(i image.Image) error {
 	return fmt.Errorf("Printer is not in ready state")
 }
 
-func (p *PhomemoPrinter) sendPackedBitmapToPrinter(b *bitmap.PackedBitmap) error {
+func (p *PhomemoPrinter) printCopies(ctx context.Context, pb *bitmap.PackedBitmap, o PrintOptions) error {
+	for copy := 1; copy <= o.Copies; copy++ {
+		if err := p.sendPackedBitmapToPrinter(pb, o); err != nil {
+			return err
+		}
+
+		// The device sometimes outputs an early "finished printing" signal
+		// right after the bitmap data is written, as well as the later one
+		// which the device outputs after printing is finished.
+		// A small delay is added here before waiting for the signal to 
+		// ignore the initial spurious one.
+		// This could probably be more elegant, but printing anything takes
+		// at least 1 second anyway, so sleeping for 100ms doesn't introduce
+		// any additional delay to the process.
+		if err := sleep(ctx, 100 * time.Millisecond); err != nil {
+			p.giveUpOnLabel()
+			return err
+		}
+		slog.Info("Waiting for printer to finish printing", "copy", copy, "copies", o.Copies)
+		if err := p.waitUntilFinished(ctx); err != nil {
+			p.giveUpOnLabel()
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PhomemoPrinter) waitUntilFinished(ctx context.Context) error {
+	timeout := time.NewTimer(p.timeouts.Print)
+	defer timeout.Stop()
+	select {
+	case <-p.finished:
+		return nil
+	case <-p.disconnected:
+		return fmt.Errorf("Printer disconnected before it finished printing")
+	case <-timeout.C:
+		return fmt.Errorf("Printer didn't finish printing within %v", p.timeouts.Print)
+	case <-ctx.Done():
+		return fmt.Errorf("Stopped waiting for printer to finish:\n%w", ctx.Err())
+	}
+}
+
+// Remembers that the label which was just sent will still send a finished
+// signal, unless the printer has disconnected
+func (p *PhomemoPrinter) giveUpOnLabel() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.info.State == Disconnected {
+		return
+	}
+	if p.unfinished == 0 {
+		p.idle = make(chan struct{})
+	}
+	p.unfinished++
+}
+
+// Whether the printer is ready, or will be once the labels which were given up
+// on have finished
+func (p *PhomemoPrinter) readyOrFinishing() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.info.State == Ready || (p.info.State == Busy && p.unfinished > 0)
+}
+
+// Waits for any labels which were given up on to finish, so the next one
+// isn't sent while the printer is still busy. If they don't finish within the
+// print timeout their signals are assumed to be lost.
+func (p *PhomemoPrinter) waitForUnfinished(ctx context.Context) error {
+	p.mu.Lock()
+	idle := p.idle
+	p.mu.Unlock()
+	if idle == nil {
+		return nil
+	}
+
+	slog.Info("Waiting for the last label to finish printing")
+	timeout := time.NewTimer(p.timeouts.Print)
+	defer timeout.Stop()
+	select {
+	case <-idle:
+	case <-p.disconnected:
+	case <-timeout.C:
+		slog.Warn("Printer didn't say the last label had finished, printing anyway")
+		p.mu.Lock()
+		p.unfinished = 0
+		p.becomeIdle()
+		p.mu.Unlock()
+	case <-ctx.Done():
+		return fmt.Errorf("Stopped waiting for printer to finish:\n%w", ctx.Err())
+	}
+	return nil
+}
+
+// Lets anything waiting for the labels which were given up on carry on, and
+// makes the printer Ready again. p.mu must be held.
+func (p *PhomemoPrinter) becomeIdle() {
+	if p.idle != nil {
+		close(p.idle)
+		p.idle = nil
+	}
+	if p.info.State == Busy {
+		p.setState(Ready)
+	}
+}
+
+// Makes the printer Ready after printing, unless it's still printing a label
+// which was given up on
+func (p *PhomemoPrinter) finishPrinting() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.info.State == Busy && p.unfinished == 0 {
+		p.setState(Ready)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *PhomemoPrinter) sendPackedBitmapToPrinter(b *bitmap.PackedBitmap, o PrintOptions) error {
 	data := initPrinter()
-	data = append(data, setJustify(Centre)...)
-	data = append(data, setLaserIntensity(Low)...)
-	data = append(data, printBitmap(b)...)
-	data = append(data, feedLines(4)...)
+	data = append(data, setJustify(o.Justify)...)
+	data = append(data, setLaserIntensity(o.Intensity)...)
+	data = append(data, printBitmap(b, p.profile.MaxBitmapHeight)...)
+	data = append(data, feedLines(byte(o.FeedLines))...)
 	return p.writer.Write(data)
 }
 
 func (p *PhomemoPrinter) onReady() {
 	slog.Info("Printer ready for printing")
 
-	if err := p.pollStatus(); err != nil {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.mu.Lock()
+	p.stopPolling()
+	p.stopPolling = cancel
+	p.mu.Unlock()
+
+	if err := p.pollStatus(ctx); err != nil {
 		slog.Error("Couldn't poll status", "error", err)
 	}
 
-	// start consuming ticker to periodically refresh device details
+	// periodically refresh device details until the printer disconnects
 	go (func() {
-		for range p.statusTicker.C {
-			if err := p.pollStatus(); err != nil {
-				slog.Error("Couldn't poll status", "error", err)
+		ticker := time.NewTicker(p.timeouts.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.pollStatus(ctx); err != nil {
+					slog.Error("Couldn't poll status", "error", err)
+				}
 			}
 		}
 	})()
 }
 
 func (p *PhomemoPrinter) onFinished() {
+	p.mu.Lock()
+	if p.unfinished > 0 {
+		// a label which was given up on, rather than the one being waited for
+		p.unfinished--
+		if p.unfinished == 0 {
+			p.becomeIdle()
+		}
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
 	select {
 	case p.finished <- true:
 		// unblocks WriteImage if we're waiting to finish printing something
@@ -162,21 +383,45 @@ func (p *PhomemoPrinter) onFinished() {
 }
 
 func (p *PhomemoPrinter) onBatteryLevelChange(level int) {
-	p.info.BatteryLevel = level
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.info.State != Disconnected && p.info.BatteryLevel != level {
+		p.info.BatteryLevel = level
+		p.events.Publish(Event{Type: BatteryChanged, BatteryLevel: level})
+	}
 }
 
 func (p *PhomemoPrinter) onPaperStatusChange(loaded bool) {
+	p.mu.Lock()
+	if p.info.State == Disconnected {
+		// arrived after Connect gave up, or the link dropped
+		p.mu.Unlock()
+		return
+	}
+	if p.paperLoaded == nil || *p.paperLoaded != loaded {
+		p.paperLoaded = &loaded
+		p.events.Publish(Event{Type: PaperChanged, PaperLoaded: loaded})
+	}
+
 	oldState := p.info.State
 	if loaded && p.info.State != Busy {
-		p.info.State = Ready
+		p.setState(Ready)
 	} else if !loaded {
-		p.info.State = OutOfPaper
+		p.setState(OutOfPaper)
 	}
+	p.mu.Unlock()
+
 	if oldState == Connecting {
+		// buffered, so this doesn't block if Connect has given up waiting
 		p.connected <- true
 	}
 }
 
 func (p *PhomemoPrinter) onFirmwareVersionReceived(version string) {
-	p.info.FirmwareVersion = version
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.info.FirmwareVersion != version {
+		p.info.FirmwareVersion = version
+		p.events.Publish(Event{Type: FirmwareInfo, FirmwareVersion: version})
+	}
 }
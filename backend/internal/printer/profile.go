@@ -0,0 +1,57 @@
+// This file describes the different Phomemo models which speak the same
+// protocol as the T02, but differ in the size and resolution of their print
+// heads.
+package printer
+
+import (
+	"slices"
+	"strings"
+)
+
+type Profile struct {
+	// Model name, which is also the Bluetooth local name the printer
+	// advertises itself with
+	Name string
+	// Number of dots across the print head, which is the widest image the
+	// printer can print
+	DotsPerLine int
+	DPI int
+	// Tallest block of raster data the printer accepts in one command; taller
+	// bitmaps are split into several blocks
+	MaxBitmapHeight int
+	Intensities []LaserIntensity
+}
+
+// Only the T02 has been tested with real hardware; the rest are taken from
+// the manufacturer's specifications.
+var Profiles = []Profile{
+	{Name: "T02", DotsPerLine: 384, DPI: 203, MaxBitmapHeight: 256, Intensities: []LaserIntensity{Low, Medium, High}},
+	{Name: "M02", DotsPerLine: 384, DPI: 203, MaxBitmapHeight: 256, Intensities: []LaserIntensity{Low, Medium, High}},
+	{Name: "M02S", DotsPerLine: 576, DPI: 300, MaxBitmapHeight: 256, Intensities: []LaserIntensity{Low, Medium, High}},
+	{Name: "M02 Pro", DotsPerLine: 576, DPI: 300, MaxBitmapHeight: 256, Intensities: []LaserIntensity{Low, Medium, High}},
+	{Name: "M03", DotsPerLine: 576, DPI: 300, MaxBitmapHeight: 256, Intensities: []LaserIntensity{Low, Medium, High}},
+	{Name: "M04S", DotsPerLine: 1232, DPI: 300, MaxBitmapHeight: 256, Intensities: []LaserIntensity{Low, Medium, High}},
+}
+
+func DefaultProfile() Profile {
+	return Profiles[0]
+}
+
+// Finds the profile for a printer from the name it advertises over
+// Bluetooth. Some printers add a suffix (e.g. a serial number) to the model
+// name, so the longest model name the local name starts with is used. Returns
+// false if the printer isn't a known model.
+func ProfileForName(localName string) (Profile, bool) {
+	var match Profile
+	found := false
+	for _, p := range Profiles {
+		if strings.HasPrefix(localName, p.Name) && len(p.Name) > len(match.Name) {
+			match, found = p, true
+		}
+	}
+	return match, found
+}
+
+func (p Profile) SupportsIntensity(i LaserIntensity) bool {
+	return slices.Contains(p.Intensities, i)
+}
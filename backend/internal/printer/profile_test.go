@@ -0,0 +1,26 @@
+package printer
+
+import (
+	"testing"
+)
+
+func TestProfileForName(t *testing.T) {
+	tests := []struct {
+		localName string
+		want      string
+		found     bool
+	}{
+		{"T02", "T02", true},
+		{"M02", "M02", true},
+		{"M02S", "M02S", true},
+		{"M02 Pro", "M02 Pro", true},
+		{"M04S-1234", "M04S", true},
+		{"P12", "", false},
+	}
+	for _, tt := range tests {
+		p, found := ProfileForName(tt.localName)
+		if found != tt.found || p.Name != tt.want {
+			t.Errorf("ProfileForName(%q) = %q, %v, want %q, %v", tt.localName, p.Name, found, tt.want, tt.found)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+// This file implements an in-memory stand-in for a Phomemo printer, so the
+// server, API and frontend can be run and tested without any hardware nearby.
+// It consumes the same ESC/POS byte stream that would be written over
+// Bluetooth, and answers with the same notifications a real T02 sends.
+package printer
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type SimulatorOptions struct {
+	// The Bluetooth local name of the simulated printer, which decides its
+	// profile
+	Name string
+	// Directory to write each printed label to as a PNG. Labels are only kept
+	// in memory if this is empty.
+	OutputDir string
+	// How long the simulated printer takes to print a label. This should be
+	// longer than the delay WriteImage waits before listening for the
+	// finished signal, otherwise the signal is ignored like it would be with
+	// a real device.
+	PrintDuration time.Duration
+	FirmwareVersion [3]byte
+	BatteryLevel int
+	Timeouts Timeouts
+}
+
+func DefaultSimulatorOptions() SimulatorOptions {
+	return SimulatorOptions{
+		Name: "T02",
+		PrintDuration: 1500 * time.Millisecond,
+		FirmwareVersion: [3]byte{3, 0, 7},
+		BatteryLevel: 100,
+		Timeouts: DefaultTimeouts(),
+	}
+}
+
+// A simulated printer which implements both Connection (for the server) and
+// DeviceWriter (for the PhomemoPrinter it manages).
+type SimulatedConnection struct {
+	options SimulatorOptions
+	profile Profile
+	events *EventBus
+	// replaced each time the simulator connects, while notifications are
+	// being handled
+	printer atomic.Pointer[PhomemoPrinter]
+	notifications chan []byte
+
+	// guards everything below, which is the state of the simulated device
+	mu sync.Mutex
+	pending []byte
+	paperLoaded bool
+	batteryLevel int
+	justify Justify
+	intensity LaserIntensity
+	raster [][]byte
+	rasterWidthBytes int
+	labels []*image.Paletted
+}
+
+func NewSimulatedConnection(options SimulatorOptions) *SimulatedConnection {
+	c := &SimulatedConnection{
+		options: options,
+		profile: profileForLocalName(options.Name),
+		events: NewEventBus(),
+		notifications: make(chan []byte, 64),
+		paperLoaded: true,
+		batteryLevel: options.BatteryLevel,
+		justify: Centre,
+		intensity: Low,
+	}
+	c.printer.Store(&PhomemoPrinter{})
+
+	// Notifications from a real device arrive on their own goroutine, one at
+	// a time and in order, so deliver the simulated ones the same way.
+	go func() {
+		for d := range c.notifications {
+			handleBluetoothDataFromPrinter(d, c.printer.Load())
+		}
+	}()
+
+	return c
+}
+
+func (c *SimulatedConnection) Connect(ctx context.Context) error {
+	if !c.printer.Load().IsConnected() {
+		ctx, cancel := context.WithTimeout(ctx, c.options.Timeouts.Connect)
+		defer cancel()
+
+		slog.Info("Connecting to simulated printer")
+		ready := make(chan bool, 1)
+		p := initialise(c, ready, c.profile, c.options.Timeouts, c.events)
+		c.printer.Store(&p)
+
+		// a real device announces itself once notifications are enabled
+		c.notify(0x02, 0xb6, 0x00)
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			p.uninitialise()
+			return fmt.Errorf("Printer didn't become ready:\n%w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+func (c *SimulatedConnection) Disconnect() error {
+	if p := c.printer.Load(); p.IsConnected() {
+		slog.Info("Disconnecting from simulated printer")
+		p.uninitialise()
+	}
+	return nil
+}
+
+func (c *SimulatedConnection) GetPrinter() Printer {
+	return c.printer.Load()
+}
+
+func (c *SimulatedConnection) Profile() Profile {
+	return c.profile
+}
+
+func (c *SimulatedConnection) Events() *EventBus {
+	return c.events
+}
+
+// Returns every label printed so far, in the order they were printed.
+func (c *SimulatedConnection) Labels() []*image.Paletted {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*image.Paletted{}, c.labels...)
+}
+
+// Simulates opening the lid or running out of paper (loaded = false), or
+// loading new paper (loaded = true).
+func (c *SimulatedConnection) SetPaperLoaded(loaded bool) {
+	c.mu.Lock()
+	c.paperLoaded = loaded
+	c.mu.Unlock()
+	c.notifyPaperStatus(loaded)
+}
+
+func (c *SimulatedConnection) SetBatteryLevel(level int) {
+	c.mu.Lock()
+	c.batteryLevel = level
+	c.mu.Unlock()
+	c.notify(0x1a, 0x04, byte(level))
+}
+
+// Receives data that would otherwise be written to the Bluetooth writer
+// characteristic. Commands may be split over several writes, so any partial
+// command at the end of the data is kept until the rest of it arrives.
+func (c *SimulatedConnection) Write(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending = append(c.pending, data...)
+	for len(c.pending) > 0 {
+		n, err := c.handleCommand(c.pending)
+		if err != nil {
+			c.pending = nil
+			return err
+		}
+		if n == 0 {
+			// incomplete command, wait for more data
+			break
+		}
+		c.pending = c.pending[n:]
+	}
+
+	slog.Debug("Simulated printer received data", "size", len(data))
+	return nil
+}
+
+// Handles the command at the start of d, returning the number of bytes it
+// used, or 0 if d doesn't contain the whole command yet.
+func (c *SimulatedConnection) handleCommand(d []byte) (int, error) {
+	switch {
+	case hasPrefix(d, Esc, 0x40):
+		return 2, nil
+	case hasPrefix(d, Esc, 0x61):
+		if len(d) < 3 {
+			return 0, nil
+		}
+		c.justify = Justify(d[2])
+		return 3, nil
+	case hasPrefix(d, Esc, 0x64):
+		if len(d) < 3 {
+			return 0, nil
+		}
+		c.finishLabel(int(d[2]))
+		return 3, nil
+	case hasPrefix(d, GS, 0x76, 0x30):
+		return c.handleRaster(d)
+	case hasPrefix(d, US, 0x11, 0x02):
+		if len(d) < 4 {
+			return 0, nil
+		}
+		c.intensity = LaserIntensity(d[3])
+		return 4, nil
+	case hasPrefix(d, US, 0x11):
+		if len(d) < 3 {
+			return 0, nil
+		}
+		c.handleQuery(d[2])
+		return 3, nil
+	case len(d) < 3 && (d[0] == Esc || d[0] == GS || d[0] == US):
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("Simulated printer received unknown command: %x", d[:min(len(d), 8)])
+	}
+}
+
+func (c *SimulatedConnection) handleRaster(d []byte) (int, error) {
+	const headerSize = 8
+	if len(d) < headerSize {
+		return 0, nil
+	}
+	widthBytes := int(d[4]) | int(d[5]) << 8
+	height := int(d[6]) | int(d[7]) << 8
+	size := headerSize + widthBytes * height
+	if len(d) < size {
+		return 0, nil
+	}
+	if widthBytes * 8 > c.profile.DotsPerLine {
+		return 0, fmt.Errorf("Raster block is %d dots wide, but the print head is only %d dots wide", widthBytes * 8, c.profile.DotsPerLine)
+	}
+	if height > c.profile.MaxBitmapHeight {
+		return 0, fmt.Errorf("Raster block is %d rows tall, but at most %d rows can be sent at once", height, c.profile.MaxBitmapHeight)
+	}
+	if len(c.raster) > 0 && widthBytes != c.rasterWidthBytes {
+		return 0, fmt.Errorf("Raster block width %d doesn't match previous width %d", widthBytes, c.rasterWidthBytes)
+	}
+
+	c.rasterWidthBytes = widthBytes
+	for y := 0; y < height; y++ {
+		start := headerSize + y * widthBytes
+		c.raster = append(c.raster, append([]byte{}, d[start:start + widthBytes]...))
+	}
+	return size, nil
+}
+
+func (c *SimulatedConnection) handleQuery(q byte) {
+	switch q {
+	case 0x08:
+		c.notify(0x1a, 0x04, byte(c.batteryLevel))
+	case 0x11:
+		c.notifyPaperStatus(c.paperLoaded)
+	case 0x07:
+		v := c.options.FirmwareVersion
+		c.notify(0x1a, 0x07, v[0], v[1], v[2])
+	default:
+		c.notify(0x01, 0x01)
+	}
+}
+
+// Called when the paper is fed after a bitmap, which is when the real device
+// prints whatever raster data it has received.
+func (c *SimulatedConnection) finishLabel(feedLines int) {
+	if len(c.raster) == 0 {
+		return
+	}
+
+	label := rasterToImage(c.raster, c.rasterWidthBytes)
+	c.raster = nil
+	c.labels = append(c.labels, label)
+	n := len(c.labels)
+
+	slog.Info("Simulated printer printed label",
+		"number", n,
+		"width", label.Rect.Dx(),
+		"height", label.Rect.Dy(),
+		"intensity", c.intensity,
+		"feedLines", feedLines,
+	)
+
+	if c.options.OutputDir != "" {
+		path := filepath.Join(c.options.OutputDir, fmt.Sprintf("label-%04d-%s.png", n, time.Now().Format("20060102-150405")))
+		if err := writePNG(path, label); err != nil {
+			slog.Error("Couldn't save simulated label", "path", path, "error", err)
+		} else {
+			slog.Info("Saved simulated label", "path", path)
+		}
+	}
+
+	time.AfterFunc(c.options.PrintDuration, func() {
+		c.notify(0x1a, 0x0f, 0x0c)
+	})
+}
+
+func (c *SimulatedConnection) notifyPaperStatus(loaded bool) {
+	if loaded {
+		c.notify(0x1a, 0x06, 0x89)
+	} else {
+		c.notify(0x1a, 0x06, 0x88)
+	}
+}
+
+func (c *SimulatedConnection) notify(d ...byte) {
+	c.notifications <- d
+}
+
+// Turns rows of packed raster data into an image the same way the print head
+// does: the most significant bit of each byte is the leftmost dot, and a high
+// bit is printed black.
+func rasterToImage(rows [][]byte, widthBytes int) *image.Paletted {
+	palette := color.Palette{color.White, color.Black}
+	img := image.NewPaletted(image.Rect(0, 0, widthBytes * 8, len(rows)), palette)
+	for y, row := range rows {
+		for x := 0; x < widthBytes * 8; x++ {
+			img.SetColorIndex(x, y, (row[x / 8] >> (7 - x % 8)) & 1)
+		}
+	}
+	return img
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, img)
+}
@@ -0,0 +1,242 @@
+package printer
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+func aConnectedSimulator(t *testing.T) *SimulatedConnection {
+	options := DefaultSimulatorOptions()
+	options.PrintDuration = 300 * time.Millisecond
+	options.BatteryLevel = 42
+	c := NewSimulatedConnection(options)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Couldn't connect to simulator: %v", err)
+	}
+	return c
+}
+
+func TestSimulatorConnect(t *testing.T) {
+	c := aConnectedSimulator(t)
+	defer c.Disconnect()
+
+	// the firmware version notification is handled after the paper status
+	// which unblocks Connect, so give it a moment to arrive
+	time.Sleep(50 * time.Millisecond)
+
+	info := c.GetPrinter().Info()
+	if info.State != Ready {
+		t.Errorf("Expected printer to be Ready, was %v", info.State)
+	}
+	if info.BatteryLevel != 42 {
+		t.Errorf("Expected battery level 42, was %v", info.BatteryLevel)
+	}
+	if info.FirmwareVersion != "3.0.7" {
+		t.Errorf("Expected firmware version 3.0.7, was %v", info.FirmwareVersion)
+	}
+}
+
+func TestSimulatorPrintsLabel(t *testing.T) {
+	c := aConnectedSimulator(t)
+	defer c.Disconnect()
+
+	// taller than maxBitmapHeight so the bitmap is sent in several blocks
+	img := image.NewGray(image.Rect(0, 0, 384, 300))
+	for y := range 300 {
+		for x := range 384 {
+			if x < 100 {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+
+	if err := c.GetPrinter().WriteImage(context.Background(), img, DefaultPrintOptions()); err != nil {
+		t.Fatalf("Couldn't print image: %v", err)
+	}
+
+	labels := c.Labels()
+	if len(labels) != 1 {
+		t.Fatalf("Expected 1 label, got %v", len(labels))
+	}
+	label := labels[0]
+	if label.Rect.Dx() != 384 || label.Rect.Dy() != 300 {
+		t.Errorf("Expected 384x300 label, got %vx%v", label.Rect.Dx(), label.Rect.Dy())
+	}
+	if label.ColorIndexAt(10, 290) != 1 {
+		t.Errorf("Expected pixel at (10, 290) to be black")
+	}
+	if label.ColorIndexAt(200, 10) != 0 {
+		t.Errorf("Expected pixel at (200, 10) to be white")
+	}
+	if c.GetPrinter().Info().State != Ready {
+		t.Errorf("Expected printer to be Ready after printing")
+	}
+}
+
+func TestSimulatorOutOfPaper(t *testing.T) {
+	c := aConnectedSimulator(t)
+	defer c.Disconnect()
+
+	c.SetPaperLoaded(false)
+	time.Sleep(50 * time.Millisecond)
+
+	if state := c.GetPrinter().Info().State; state != OutOfPaper {
+		t.Errorf("Expected printer to be OutOfPaper, was %v", state)
+	}
+	if err := c.GetPrinter().WriteImage(context.Background(), image.NewGray(image.Rect(0, 0, 8, 8)), DefaultPrintOptions()); err == nil {
+		t.Errorf("Expected printing without paper to fail")
+	}
+}
+
+func TestSimulatorHandlesSplitWrites(t *testing.T) {
+	c := NewSimulatedConnection(DefaultSimulatorOptions())
+	data := append(printBitmapHeader(1, 2), 0x80, 0x01)
+	data = append(data, feedLines(4)...)
+
+	for _, b := range data {
+		if err := c.Write([]byte{b}); err != nil {
+			t.Fatalf("Couldn't write byte: %v", err)
+		}
+	}
+
+	labels := c.Labels()
+	if len(labels) != 1 {
+		t.Fatalf("Expected 1 label, got %v", len(labels))
+	}
+	if labels[0].ColorIndexAt(0, 0) != 1 || labels[0].ColorIndexAt(7, 1) != 1 || labels[0].ColorIndexAt(1, 0) != 0 {
+		t.Errorf("Label bits don't match raster data")
+	}
+}
+
+func TestSimulatorPrintsCopiesWithOptions(t *testing.T) {
+	c := aConnectedSimulator(t)
+	defer c.Disconnect()
+
+	o := PrintOptions{Intensity: High, FeedLines: 10, Justify: Centre, Copies: 2}
+	if err := c.GetPrinter().WriteImage(context.Background(), image.NewGray(image.Rect(0, 0, 384, 20)), o); err != nil {
+		t.Fatalf("Couldn't print image: %v", err)
+	}
+
+	if n := len(c.Labels()); n != 2 {
+		t.Errorf("Expected 2 labels, got %v", n)
+	}
+	if c.intensity != High {
+		t.Errorf("Expected laser intensity to be High, was %v", c.intensity)
+	}
+}
+
+func TestSimulatorUsesProfileWidth(t *testing.T) {
+	options := DefaultSimulatorOptions()
+	options.Name = "M02S"
+	options.PrintDuration = 300 * time.Millisecond
+	c := NewSimulatedConnection(options)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Couldn't connect to simulator: %v", err)
+	}
+	defer c.Disconnect()
+
+	if err := c.GetPrinter().WriteImage(context.Background(), image.NewGray(image.Rect(0, 0, 1000, 100)), DefaultPrintOptions()); err != nil {
+		t.Fatalf("Couldn't print image: %v", err)
+	}
+
+	labels := c.Labels()
+	if len(labels) != 1 {
+		t.Fatalf("Expected 1 label, got %v", len(labels))
+	}
+	if w := labels[0].Rect.Dx(); w != 576 {
+		t.Errorf("Expected image to be scaled to 576 dots wide, was %v", w)
+	}
+}
+
+func TestPrintTimeoutLeavesPrinterBusyUntilTheLabelFinishes(t *testing.T) {
+	options := DefaultSimulatorOptions()
+	options.PrintDuration = 500 * time.Millisecond
+	options.Timeouts.Print = 150 * time.Millisecond
+	c := NewSimulatedConnection(options)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Couldn't connect to simulator: %v", err)
+	}
+	defer c.Disconnect()
+
+	if err := c.GetPrinter().WriteImage(context.Background(), image.NewGray(image.Rect(0, 0, 384, 20)), DefaultPrintOptions()); err == nil {
+		t.Errorf("Expected printing to time out")
+	}
+	if state := c.GetPrinter().Info().State; state != Busy {
+		t.Errorf("Expected printer to be Busy while the label is still printing, was %v", state)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if state := c.GetPrinter().Info().State; state != Ready {
+		t.Errorf("Expected printer to be Ready once the label finished, was %v", state)
+	}
+}
+
+func TestCancellingPrintStopsSendingCopies(t *testing.T) {
+	c := aConnectedSimulator(t)
+	defer c.Disconnect()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(150 * time.Millisecond, cancel)
+	start := time.Now()
+	o := DefaultPrintOptions()
+	o.Copies = 3
+	if err := c.GetPrinter().WriteImage(ctx, image.NewGray(image.Rect(0, 0, 384, 20)), o); err == nil {
+		t.Errorf("Expected cancelled print to fail")
+	}
+	if d := time.Since(start); d > 250 * time.Millisecond {
+		t.Errorf("Expected WriteImage to return when cancelled, took %v", d)
+	}
+	if state := c.GetPrinter().Info().State; state != Busy {
+		t.Errorf("Expected printer to be Busy while the first copy is still printing, was %v", state)
+	}
+	if n := len(c.Labels()); n != 1 {
+		t.Errorf("Expected only the first copy to be printed, got %v labels", n)
+	}
+}
+
+func TestNextPrintWaitsForACancelledLabel(t *testing.T) {
+	c := aConnectedSimulator(t)
+	defer c.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150 * time.Millisecond)
+	defer cancel()
+	if err := c.GetPrinter().WriteImage(ctx, image.NewGray(image.Rect(0, 0, 384, 20)), DefaultPrintOptions()); err == nil {
+		t.Errorf("Expected cancelled print to fail")
+	}
+
+	// the cancelled label's finished signal arrives while this one is
+	// printing, and mustn't be taken for this one's
+	start := time.Now()
+	if err := c.GetPrinter().WriteImage(context.Background(), image.NewGray(image.Rect(0, 0, 384, 20)), DefaultPrintOptions()); err != nil {
+		t.Fatalf("Couldn't print after cancelling: %v", err)
+	}
+	if d := time.Since(start); d < 300 * time.Millisecond {
+		t.Errorf("Expected the second label to take as long as it takes to print, took %v", d)
+	}
+	if n := len(c.Labels()); n != 2 {
+		t.Errorf("Expected both labels to be printed, got %v", n)
+	}
+	if state := c.GetPrinter().Info().State; state != Ready {
+		t.Errorf("Expected printer to be Ready, was %v", state)
+	}
+}
+
+func TestNotificationsDontReviveADisconnectedPrinter(t *testing.T) {
+	c := aConnectedSimulator(t)
+	p := c.GetPrinter()
+	c.Disconnect()
+
+	c.SetPaperLoaded(true)
+	c.SetBatteryLevel(10)
+	time.Sleep(50 * time.Millisecond)
+	if p.IsConnected() {
+		t.Errorf("Expected printer to stay disconnected, was %v", p.Info().State)
+	}
+	if level := p.Info().BatteryLevel; level == 10 {
+		t.Errorf("Expected battery level to be ignored once disconnected")
+	}
+}
@@ -0,0 +1,202 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type SupervisorOptions struct {
+	// How long to wait after the first failed attempt to reconnect. This
+	// doubles after every failed attempt, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff time.Duration
+	// How long to scan for the printer by name after failing to connect to
+	// it, in case its address has changed
+	ScanTimeout time.Duration
+}
+
+func DefaultSupervisorOptions() SupervisorOptions {
+	return SupervisorOptions{
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff: 1 * time.Minute,
+		ScanTimeout: 10 * time.Second,
+	}
+}
+
+// Keeps a BluetoothConnection connected, by connecting as soon as it starts
+// and reconnecting whenever the printer drops out. Implements Connection, so
+// it can be used in place of the connection it supervises.
+type Supervisor struct {
+	log *slog.Logger
+	conn *BluetoothConnection
+	options SupervisorOptions
+	// signalled whenever the connection needs to be (re)established
+	lost chan struct{}
+	// cancelled when the supervisor is stopped, which stops any attempt to
+	// connect
+	ctx context.Context
+	stop context.CancelFunc
+
+	// guards everything below
+	mu sync.Mutex
+	reconnecting bool
+	stopped bool
+	attempts int
+	nextAttemptAt *time.Time
+	onConnect []func()
+}
+
+func NewSupervisor(log *slog.Logger, conn *BluetoothConnection, options SupervisorOptions) *Supervisor {
+	ctx, stop := context.WithCancel(context.Background())
+	s := &Supervisor{
+		log: log,
+		conn: conn,
+		options: options,
+		lost: make(chan struct{}, 1),
+		ctx: ctx,
+		stop: stop,
+	}
+	conn.onDisconnect = s.signal
+	return s
+}
+
+// Adds a function to call every time the printer is connected, e.g. to resume
+// printing anything which was waiting for it.
+func (s *Supervisor) OnConnect(f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onConnect = append(s.onConnect, f)
+}
+
+// Starts connecting to the printer in the background
+func (s *Supervisor) Start() {
+	go s.run()
+	s.signal()
+}
+
+func (s *Supervisor) signal() {
+	select {
+	case s.lost <- struct{}{}:
+	default:
+		// already reconnecting
+	}
+}
+
+func (s *Supervisor) run() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.lost:
+			s.reconnect()
+		}
+	}
+}
+
+func (s *Supervisor) reconnect() {
+	s.mu.Lock()
+	if s.stopped || s.conn.printer.Load().IsConnected() {
+		s.mu.Unlock()
+		return
+	}
+	s.reconnecting, s.attempts, s.nextAttemptAt = true, 0, nil
+	s.mu.Unlock()
+	s.conn.events.Publish(Event{Type: StateChanged, State: Reconnecting})
+
+	backoff := s.options.InitialBackoff
+	for {
+		s.log.Info("Connecting to printer", "address", s.conn.Address())
+		err := s.conn.Connect(s.ctx)
+		if err == nil {
+			break
+		}
+
+		s.log.Warn("Couldn't connect to printer", "error", err)
+		if s.conn.name != "" {
+			if err := s.conn.rescan(s.options.ScanTimeout); err != nil {
+				s.log.Warn("Couldn't find printer by name", "name", s.conn.name, "error", err)
+			}
+		}
+
+		next := time.Now().Add(backoff)
+		s.mu.Lock()
+		s.attempts++
+		s.nextAttemptAt = &next
+		s.mu.Unlock()
+
+		s.log.Info("Waiting to reconnect", "backoff", backoff)
+		if err := sleep(s.ctx, backoff); err != nil {
+			return
+		}
+		backoff = min(backoff * 2, s.options.MaxBackoff)
+	}
+
+	s.mu.Lock()
+	s.reconnecting, s.attempts, s.nextAttemptAt = false, 0, nil
+	handlers := append([]func(){}, s.onConnect...)
+	s.mu.Unlock()
+
+	s.log.Info("Connected to printer")
+	for _, f := range handlers {
+		f()
+	}
+}
+
+// Returns an error if the printer isn't connected, as the supervisor is
+// already trying to connect to it.
+func (s *Supervisor) Connect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reconnecting {
+		return fmt.Errorf("Printer is disconnected, reconnecting (attempt %d)", s.attempts + 1)
+	}
+	if s.stopped {
+		return fmt.Errorf("Printer was disconnected")
+	}
+	if !s.conn.printer.Load().IsConnected() {
+		return fmt.Errorf("Printer is disconnected")
+	}
+	return nil
+}
+
+// Stops supervising the connection and disconnects from the printer
+func (s *Supervisor) Disconnect() error {
+	s.mu.Lock()
+	s.stopped = true
+	s.stop()
+	s.mu.Unlock()
+	return s.conn.Disconnect()
+}
+
+func (s *Supervisor) GetPrinter() Printer {
+	return &supervisedPrinter{Printer: s.conn.GetPrinter(), s: s}
+}
+
+func (s *Supervisor) Profile() Profile {
+	return s.conn.Profile()
+}
+
+func (s *Supervisor) Events() *EventBus {
+	return s.conn.Events()
+}
+
+// Reports the supervisor's state along with the printer's
+type supervisedPrinter struct {
+	Printer
+	s *Supervisor
+}
+
+func (p *supervisedPrinter) Info() DeviceInfo {
+	info := p.Printer.Info()
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	if p.s.reconnecting && !p.Printer.IsConnected() {
+		info.State = Reconnecting
+		info.ReconnectAttempts = p.s.attempts
+		info.NextReconnectAt = p.s.nextAttemptAt
+	}
+	return info
+}
@@ -0,0 +1,233 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A Bluetooth adapter with printers which can be moved, switched off and on
+// again, and which answer just enough commands to connect and print.
+type fakeAdapter struct {
+	mu sync.Mutex
+	// name of the printer at each address which is switched on
+	devices map[string]string
+	// addresses of printers which have hung
+	silent map[string]bool
+	connects int
+	onDisconnect func(address string)
+}
+
+type fakeDevice struct {
+	adapter *fakeAdapter
+	address string
+	notify func([]byte)
+	// doesn't send any notifications, like a printer which has hung
+	silent bool
+}
+
+func (a *fakeAdapter) Scan(name string, timeout time.Duration) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for address, n := range a.devices {
+		if n == name {
+			return address, nil
+		}
+	}
+	return "", fmt.Errorf("No device called %s found", name)
+}
+
+func (a *fakeAdapter) Connect(address string) (Device, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.connects++
+	if _, ok := a.devices[address]; !ok {
+		return nil, fmt.Errorf("No device at %s", address)
+	}
+	return &fakeDevice{adapter: a, address: address, silent: a.silent[address]}, nil
+}
+
+func (a *fakeAdapter) SetDisconnectHandler(f func(address string)) {
+	a.onDisconnect = f
+}
+
+// Switches off the printer at an address, as if it went out of range
+func (a *fakeAdapter) switchOff(address string) {
+	a.mu.Lock()
+	delete(a.devices, address)
+	a.mu.Unlock()
+	a.onDisconnect(address)
+}
+
+func (a *fakeAdapter) switchOn(address string, name string) {
+	a.mu.Lock()
+	a.devices[address] = name
+	a.mu.Unlock()
+}
+
+func (d *fakeDevice) EnableNotifications(f func([]byte)) error {
+	d.notify = f
+	if d.silent {
+		return nil
+	}
+	go f([]byte{0x02, 0xb6, 0x00})
+	return nil
+}
+
+func (d *fakeDevice) Write(data []byte) error {
+	if d.silent {
+		return nil
+	}
+	if bytes.Contains(data, queryPaperStatus()) {
+		go d.notify([]byte{0x1a, 0x06, 0x89})
+	}
+	if bytes.Contains(data, []byte{Esc, 0x64}) {
+		time.AfterFunc(200 * time.Millisecond, func() {
+			d.notify([]byte{0x1a, 0x0f, 0x0c})
+		})
+	}
+	return nil
+}
+
+func (d *fakeDevice) Disconnect() error {
+	d.adapter.onDisconnect(d.address)
+	return nil
+}
+
+func aSupervisor(adapter *fakeAdapter, name string, address string) *Supervisor {
+	options := SupervisorOptions{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff: 40 * time.Millisecond,
+		ScanTimeout: 10 * time.Millisecond,
+	}
+	return NewSupervisor(slog.Default(), NewBluetoothConnection(adapter, name, address), options)
+}
+
+func waitForState(t *testing.T, c Connection, state DeviceState) DeviceInfo {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if info := c.GetPrinter().Info(); info.State == state {
+			return info
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Printer didn't become %v, was %v", state, c.GetPrinter().Info().State)
+	return DeviceInfo{}
+}
+
+func TestSupervisorReconnectsAfterDropout(t *testing.T) {
+	adapter := &fakeAdapter{devices: map[string]string{"addr-1": "T02"}}
+	s := aSupervisor(adapter, "T02", "addr-1")
+	connects := make(chan struct{}, 10)
+	s.OnConnect(func() { connects <- struct{}{} })
+	s.Start()
+	defer s.Disconnect()
+
+	waitForState(t, s, Ready)
+	<-connects
+
+	adapter.switchOff("addr-1")
+	info := waitForState(t, s, Reconnecting)
+	if err := s.Connect(context.Background()); err == nil {
+		t.Errorf("Expected Connect to fail while reconnecting")
+	}
+	if info.NextReconnectAt == nil {
+		t.Errorf("Expected time of next attempt to be reported while reconnecting")
+	}
+
+	adapter.switchOn("addr-1", "T02")
+	waitForState(t, s, Ready)
+	select {
+	case <-connects:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected OnConnect handler to be called after reconnecting")
+	}
+
+	if err := s.GetPrinter().WriteImage(context.Background(), image.NewGray(image.Rect(0, 0, 384, 20)), DefaultPrintOptions()); err != nil {
+		t.Errorf("Couldn't print after reconnecting: %v", err)
+	}
+}
+
+func TestSupervisorRescansWhenAddressChanges(t *testing.T) {
+	adapter := &fakeAdapter{devices: map[string]string{"addr-2": "T02"}}
+	s := aSupervisor(adapter, "T02", "addr-1")
+	s.Start()
+	defer s.Disconnect()
+
+	waitForState(t, s, Ready)
+	if a := s.conn.Address(); a != "addr-2" {
+		t.Errorf("Expected printer to be found at addr-2, was %v", a)
+	}
+}
+
+func TestSupervisorBacksOff(t *testing.T) {
+	adapter := &fakeAdapter{devices: map[string]string{}}
+	s := aSupervisor(adapter, "T02", "addr-1")
+	s.Start()
+
+	// waits of 10, 20, 40, 40... ms should give 5 or 6 attempts in 150ms,
+	// rather than the 15 there would be without backing off
+	time.Sleep(150 * time.Millisecond)
+	s.Disconnect()
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if adapter.connects < 3 || adapter.connects > 7 {
+		t.Errorf("Expected around 5 attempts to connect, was %v", adapter.connects)
+	}
+}
+
+func TestConnectTimesOutIfPrinterNeverReady(t *testing.T) {
+	adapter := &fakeAdapter{
+		devices: map[string]string{"addr-1": "T02"},
+		silent: map[string]bool{"addr-1": true},
+	}
+	c := NewBluetoothConnection(adapter, "T02", "addr-1")
+	c.Timeouts.Connect = 50 * time.Millisecond
+
+	if err := c.Connect(context.Background()); err == nil {
+		t.Fatalf("Expected connecting to time out")
+	}
+	if c.GetPrinter().IsConnected() {
+		t.Errorf("Expected printer to be disconnected after timing out")
+	}
+}
+
+func TestReconnectingWhileTheConnectionIsInUse(t *testing.T) {
+	adapter := &fakeAdapter{devices: map[string]string{"addr-1": "T02"}}
+	c := NewBluetoothConnection(adapter, "T02", "addr-1")
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// like requests being handled while the supervisor reconnects, which
+	// the race detector catches if the connection isn't guarded
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				c.Profile()
+				c.Write(nil)
+			}
+		}
+	}()
+	for range 5 {
+		c.Disconnect()
+		if err := c.Connect(context.Background()); err != nil {
+			t.Errorf("Couldn't reconnect: %v", err)
+		}
+	}
+	close(done)
+	<-stopped
+	c.Disconnect()
+}
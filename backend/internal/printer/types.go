@@ -1,7 +1,12 @@
 package printer
 
 import (
+	"context"
+	"fmt"
 	"image"
+	"time"
+
+	"tomgalvin.uk/phogoprint/internal/bitmap"
 )
 
 type DeviceState int
@@ -12,6 +17,8 @@ const (
 	Ready
 	Busy
 	OutOfPaper
+	// Lost connection to the printer, and trying to connect to it again
+	Reconnecting
 )
 
 func (s DeviceState) String() string {
@@ -21,6 +28,7 @@ func (s DeviceState) String() string {
 	case Ready: return "Ready"
 	case Busy: return "Busy"
 	case OutOfPaper: return "OutOfPaper"
+	case Reconnecting: return "Reconnecting"
 	default: return "Unknown"
 	}
 }
@@ -29,16 +37,77 @@ type DeviceInfo struct {
 	FirmwareVersion string
 	BatteryLevel int
 	State DeviceState
+	// Number of failed attempts to reconnect since the connection was lost
+	ReconnectAttempts int
+	// When the next attempt to reconnect will be made, if reconnecting
+	NextReconnectAt *time.Time
+}
+
+// Settings for how a single image gets printed
+type PrintOptions struct {
+	Intensity LaserIntensity
+	// Number of blank lines to feed through after the image
+	FeedLines int
+	Justify   Justify
+	Copies    int
+}
+
+func DefaultPrintOptions() PrintOptions {
+	return PrintOptions{
+		Intensity: Low,
+		FeedLines: 4,
+		Justify:   Centre,
+		Copies:    1,
+	}
+}
+
+func (o PrintOptions) Validate() error {
+	if o.Intensity != Low && o.Intensity != Medium && o.Intensity != High {
+		return fmt.Errorf("Unrecognised laser intensity %v", o.Intensity)
+	}
+	if o.FeedLines < 0 || o.FeedLines > 255 {
+		return fmt.Errorf("Feed lines must be between 0 and 255")
+	}
+	if o.Justify != Left && o.Justify != Centre && o.Justify != Right {
+		return fmt.Errorf("Unrecognised justification %v", o.Justify)
+	}
+	if o.Copies < 1 {
+		return fmt.Errorf("Copies must be at least 1")
+	}
+	return nil
+}
+
+// How long to wait for the printer at each step, and how often to poll it
+type Timeouts struct {
+	// How long to wait for the printer to become ready after connecting
+	Connect time.Duration
+	// How long to wait for each copy to finish printing
+	Print time.Duration
+	PollInterval time.Duration
+}
+
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Connect: 30 * time.Second,
+		Print: 30 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
 }
 
 type Printer interface {
-	WriteImage(image.Image) error
+	WriteImage(context.Context, image.Image, PrintOptions) error
+	WriteBitmap(context.Context, *bitmap.PackedBitmap, PrintOptions) error
 	Info() DeviceInfo
 	IsConnected() bool
 }
 
 type Connection interface {
 	GetPrinter() Printer
-	Connect() error
+	// The profile of the connected printer, or of the printer which will be
+	// connected to if it isn't connected yet
+	Profile() Profile
+	// Events from the printer, which carry on across reconnections
+	Events() *EventBus
+	Connect(context.Context) error
 	Disconnect() error
 }
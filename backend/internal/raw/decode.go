@@ -0,0 +1,350 @@
+// This package implements a raw print listener, like the JetDirect port on a
+// network printer, so tools and print queues which can send an image or
+// ESC/POS raster data to a TCP port can print without using the API.
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+	"strconv"
+
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// PNGs are scaled down to fit the print head from at most this many times
+// its width, e.g. a screenshot
+const maxPngScale = 4
+
+// Longest PNG which is accepted, which is about a metre at 203 DPI
+const maxPngHeight = 8192
+
+// Reads every label in a raw print job. A job is either a stream of PNG and
+// PBM images, each of which is a label, or ESC/POS commands, whose raster
+// blocks are stacked into one label. PNGs are dithered like any other image;
+// PBMs and rasters are already black and white, so they're printed as they
+// are, and have to fit on the print head. ESC/POS commands which change how
+// the label is printed, like justification, change the options.
+func Decode(r *bufio.Reader, maxWidth int, options *printer.PrintOptions) ([]*bitmap.PackedBitmap, error) {
+	pbs := []*bitmap.PackedBitmap{}
+	for {
+		if err := skipSpace(r); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		head, _ := r.Peek(len(pngSignature))
+
+		var pb *bitmap.PackedBitmap
+		var err error
+		switch {
+		case bytes.HasPrefix(head, pngSignature):
+			pb, err = decodePng(r, maxWidth)
+		case len(head) >= 2 && head[0] == 'P' && (head[1] == '1' || head[1] == '4'):
+			pb, err = decodePbm(r, maxWidth)
+		case head[0] == printer.Esc || head[0] == printer.GS || head[0] == printer.US:
+			pb, err = decodeEscPos(r, maxWidth, options)
+		default:
+			return nil, fmt.Errorf("Unrecognised data %x in job, expected a PNG or PBM image or ESC/POS raster", head)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read label %v:\n%w", len(pbs)+1, err)
+		}
+		if pb == nil {
+			// ESC/POS commands with no raster data
+			continue
+		}
+		if pb.Width() > maxWidth {
+			return nil, fmt.Errorf("Label %v is %v dots wide, but the print head is only %v dots wide", len(pbs)+1, pb.Width(), maxWidth)
+		}
+		pbs = append(pbs, pb)
+	}
+	if len(pbs) == 0 {
+		return nil, fmt.Errorf("Job didn't contain anything to print")
+	}
+	return pbs, nil
+}
+
+// Skips whitespace and NULs between images, e.g. the newline left at the end
+// of a file
+func skipSpace(r *bufio.Reader) error {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if !isSpace(b) && b != 0 {
+			return r.UnreadByte()
+		}
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n' || b == '\v' || b == '\f'
+}
+
+func decodePng(r *bufio.Reader, maxWidth int) (*bitmap.PackedBitmap, error) {
+	// the header gives the size, which is checked before the decoder makes
+	// room for the image
+	head, _ := r.Peek(64)
+	config, err := png.DecodeConfig(bytes.NewReader(head))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width > maxWidth*maxPngScale {
+		return nil, fmt.Errorf("PNG image is %v pixels wide, but at most %v are accepted", config.Width, maxWidth*maxPngScale)
+	}
+	if config.Height > maxPngHeight {
+		return nil, fmt.Errorf("PNG image is %v pixels long, but at most %v are accepted", config.Height, maxPngHeight)
+	}
+	// the PNG decoder stops reading at the end of the image, so the next
+	// image can be read after it
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return bitmap.PackImage(img, maxWidth, bitmap.DefaultRenderOptions())
+}
+
+// Reads a binary (P4) or plain (P1) portable bitmap, in which a 1 is black
+func decodePbm(r *bufio.Reader, maxWidth int) (*bitmap.PackedBitmap, error) {
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+	width, err := readPbmNumber(r)
+	if err != nil {
+		return nil, err
+	}
+	height, err := readPbmNumber(r)
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("PBM image has no pixels")
+	}
+	// checked before making room for the image, which the header could
+	// otherwise make as big as it likes
+	if width > maxWidth {
+		return nil, fmt.Errorf("PBM image is %v dots wide, but the print head is only %v dots wide", width, maxWidth)
+	}
+	if height > maxJobSize/((width+7)/8) {
+		return nil, fmt.Errorf("PBM image is bigger than %v bytes", maxJobSize)
+	}
+
+	b := &msbBitmap{width: width, height: height, stride: (width + 7) / 8}
+	b.data = make([]byte, b.stride*height)
+	if magic[1] == '4' {
+		// a single whitespace character separates the header from the data
+		if _, err := r.ReadByte(); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(r, b.data); err != nil {
+			return nil, fmt.Errorf("PBM image data is too short:\n%w", err)
+		}
+	} else {
+		for i := range width * height {
+			if err := skipPbmSpace(r); err != nil {
+				return nil, fmt.Errorf("PBM image data is too short:\n%w", err)
+			}
+			c, _ := r.ReadByte()
+			if c != '0' && c != '1' {
+				return nil, fmt.Errorf("Unexpected %q in PBM image data", c)
+			}
+			x, y := i%width, i/width
+			b.data[y*b.stride+x/8] |= (c - '0') << (7 - x%8)
+		}
+	}
+	return bitmap.PackBitmap(b), nil
+}
+
+// Skips whitespace and comments in a PBM image
+func skipPbmSpace(r *bufio.Reader) error {
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if c == '#' {
+			if _, err := r.ReadString('\n'); err != nil {
+				return err
+			}
+		} else if !isSpace(c) {
+			return r.UnreadByte()
+		}
+	}
+}
+
+func readPbmNumber(r *bufio.Reader) (int, error) {
+	if err := skipPbmSpace(r); err != nil {
+		return 0, err
+	}
+	digits := []byte{}
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if c < '0' || c > '9' {
+			r.UnreadByte()
+			break
+		}
+		digits = append(digits, c)
+	}
+	return strconv.Atoi(string(digits))
+}
+
+// Reads ESC/POS commands up to the end of the job. Only the commands which
+// make sense for a label printer are understood: anything which prints text
+// is turned away, since it can't be printed the way it was meant to be.
+func decodeEscPos(r *bufio.Reader, maxWidth int, options *printer.PrintOptions) (*bitmap.PackedBitmap, error) {
+	var data []byte
+	var widthBytes, height int
+	for {
+		c, err := r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		var command, args []byte
+		switch c {
+		case 0, '\r', '\n':
+			// line feeds only move paper, which the label's feed lines do
+			continue
+		case printer.Esc, printer.GS, printer.US:
+			command = []byte{c, 0}
+			if command[1], err = r.ReadByte(); err != nil {
+				return nil, fmt.Errorf("ESC/POS command %x was cut short", c)
+			}
+		default:
+			return nil, fmt.Errorf("Unexpected byte %x in ESC/POS data, which can only print raster images", c)
+		}
+
+		n, ok := escPosArgs[string(command)]
+		if !ok {
+			return nil, fmt.Errorf("Unsupported ESC/POS command %x", command)
+		}
+		args = make([]byte, n)
+		if _, err := io.ReadFull(r, args); err != nil {
+			return nil, fmt.Errorf("ESC/POS command %x was cut short", command)
+		}
+
+		switch string(command) {
+		case string([]byte{printer.Esc, 'a'}):
+			options.Justify = printer.Justify(escPosDigit(args[0]))
+			if options.Justify > printer.Right {
+				return nil, fmt.Errorf("Unrecognised justification %v", args[0])
+			}
+		case string([]byte{printer.US, 0x11}):
+			// Phomemo's own commands are mostly queries, apart from setting
+			// the laser intensity, which has a value as well
+			if args[0] == 0x02 {
+				intensity, err := r.ReadByte()
+				if err != nil {
+					return nil, fmt.Errorf("ESC/POS command %x was cut short", command)
+				}
+				options.Intensity = printer.LaserIntensity(intensity)
+			}
+		case string([]byte{printer.GS, 'v'}):
+			if args[0] != '0' {
+				return nil, fmt.Errorf("Unsupported raster command GS v %c", args[0])
+			}
+			// GS v 0 m xL xH yL yH: m is the scale, which isn't supported,
+			// then the width in bytes and the height in rows
+			if escPosDigit(args[1]) != 0 {
+				return nil, fmt.Errorf("Scaled rasters aren't supported")
+			}
+			w, h := int(args[2])|int(args[3])<<8, int(args[4])|int(args[5])<<8
+			if height > 0 && w != widthBytes {
+				return nil, fmt.Errorf("Raster block is %v bytes wide, but the one before it was %v", w, widthBytes)
+			}
+			if w*8 > maxWidth {
+				return nil, fmt.Errorf("Raster is %v dots wide, but the print head is only %v dots wide", w*8, maxWidth)
+			}
+			if len(data)+w*h > maxJobSize {
+				return nil, fmt.Errorf("Raster is bigger than %v bytes", maxJobSize)
+			}
+			block := make([]byte, w*h)
+			if _, err := io.ReadFull(r, block); err != nil {
+				return nil, fmt.Errorf("Raster data is too short:\n%w", err)
+			}
+			widthBytes, height, data = w, height+h, append(data, block...)
+		case string([]byte{printer.GS, 'V'}):
+			// cutting the paper, which has an extra argument for some modes
+			if args[0] >= 65 {
+				if _, err := r.ReadByte(); err != nil {
+					return nil, fmt.Errorf("ESC/POS command %x was cut short", command)
+				}
+			}
+		}
+	}
+	if height == 0 || widthBytes == 0 {
+		return nil, nil
+	}
+	// raster rows are whole bytes, which is the same as a packed bitmap
+	// which is a multiple of 8 dots wide
+	return bitmap.NewPackedBitmap(data, widthBytes*8, height)
+}
+
+// ESC/POS numeric arguments can be given as a byte or an ASCII digit, so 1
+// and '1' are the same
+func escPosDigit(b byte) byte {
+	if b >= '0' && b <= '9' {
+		return b - '0'
+	}
+	return b
+}
+
+// How many bytes of arguments each supported ESC/POS command has, not
+// counting raster data. Commands which only change how text looks are
+// accepted and ignored, since print drivers often send them anyway.
+var escPosArgs = map[string]int{
+	// initialise
+	string([]byte{printer.Esc, '@'}): 0,
+	// justification
+	string([]byte{printer.Esc, 'a'}): 1,
+	// feed lines and dots
+	string([]byte{printer.Esc, 'd'}): 1,
+	string([]byte{printer.Esc, 'J'}): 1,
+	// line spacing, bold, underline and print mode
+	string([]byte{printer.Esc, '2'}): 0,
+	string([]byte{printer.Esc, '3'}): 1,
+	string([]byte{printer.Esc, 'E'}): 1,
+	string([]byte{printer.Esc, '-'}): 1,
+	string([]byte{printer.Esc, '!'}): 1,
+	// Phomemo's commands, which have a type
+	string([]byte{printer.US, 0x11}): 1,
+	// raster: 0, m, xL, xH, yL, yH
+	string([]byte{printer.GS, 'v'}): 6,
+	// cut
+	string([]byte{printer.GS, 'V'}): 1,
+}
+
+// A bitmap in the layout used by PBM images and ESC/POS rasters, where each
+// row is padded to a whole number of bytes and the most significant bit is
+// the leftmost dot
+type msbBitmap struct {
+	data                  []byte
+	width, height, stride int
+}
+
+func (b *msbBitmap) Width() int {
+	return b.width
+}
+
+func (b *msbBitmap) Height() int {
+	return b.height
+}
+
+func (b *msbBitmap) GetBit(x int, y int) byte {
+	return (b.data[y*b.stride+x/8] >> (7 - x%8)) & 1
+}
@@ -0,0 +1,150 @@
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+func decode(t *testing.T, data []byte) ([]*bitmap.PackedBitmap, printer.PrintOptions, error) {
+	t.Helper()
+	options := printer.DefaultPrintOptions()
+	pbs, err := Decode(bufio.NewReader(bytes.NewReader(data)), 384, &options)
+	return pbs, options, err
+}
+
+func aPng(t *testing.T, width, height int) []byte {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.Set(0, 0, color.Black)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// A PNG whose header says it's the given size, whatever its data says
+func aPngClaiming(t *testing.T, width, height int) []byte {
+	data := aPng(t, 1, 1)
+	// the IHDR chunk's width and height, then its checksum
+	binary.BigEndian.PutUint32(data[16:], uint32(width))
+	binary.BigEndian.PutUint32(data[20:], uint32(height))
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestBinaryPbmIsPrintedAsItIs(t *testing.T) {
+	// 12 dots wide, so each row has 4 bits of padding
+	data := append([]byte("P4\n# a comment\n12 2\n"), 0xf0, 0x10, 0x0f, 0x80)
+	pbs, _, err := decode(t, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pbs) != 1 || pbs[0].Width() != 12 || pbs[0].Height() != 2 {
+		t.Fatalf("Expected one 12x2 bitmap, got %v", pbs)
+	}
+	expected := []string{"111100000001", "000011111000"}
+	for y, row := range expected {
+		for x, c := range row {
+			if bit := pbs[0].GetBit(x, y); bit != byte(c-'0') {
+				t.Errorf("Expected bit %v,%v to be %c, was %v", x, y, c, bit)
+			}
+		}
+	}
+}
+
+func TestPlainPbmIsPrintedAsItIs(t *testing.T) {
+	pbs, _, err := decode(t, []byte("P1 3 2\n1 0 1\n010\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pbs[0].GetBit(0, 0) != 1 || pbs[0].GetBit(1, 0) != 0 || pbs[0].GetBit(1, 1) != 1 {
+		t.Errorf("Unexpected bitmap %v", pbs[0])
+	}
+}
+
+func TestEachImageIsALabel(t *testing.T) {
+	data := append(aPng(t, 100, 20), aPng(t, 1000, 50)...)
+	data = append(data, []byte("\nP1 1 1 1\n")...)
+	pbs, _, err := decode(t, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pbs) != 3 {
+		t.Fatalf("Expected 3 labels, got %v", len(pbs))
+	}
+	if pbs[1].Width() != 384 {
+		t.Errorf("Expected wide PNG to be scaled to fit, was %v wide", pbs[1].Width())
+	}
+}
+
+func TestEscPosRasterBlocksAreStacked(t *testing.T) {
+	data := []byte{
+		printer.Esc, '@',
+		printer.Esc, 'a', 2,
+		printer.US, 0x11, 0x02, byte(printer.High),
+		printer.GS, 'v', '0', 0, 2, 0, 1, 0, 0xff, 0x00,
+		printer.GS, 'v', '0', 0, 2, 0, 2, 0, 0x00, 0xff, 0x80, 0x01,
+		printer.Esc, 'd', 4,
+		printer.GS, 'V', 66, 0,
+	}
+	pbs, options, err := decode(t, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pbs) != 1 || pbs[0].Width() != 16 || pbs[0].Height() != 3 {
+		t.Fatalf("Expected one 16x3 bitmap, got %v", pbs)
+	}
+	if pbs[0].GetBit(0, 0) != 1 || pbs[0].GetBit(8, 1) != 1 || pbs[0].GetBit(15, 2) != 1 || pbs[0].GetBit(1, 2) != 0 {
+		t.Errorf("Raster blocks weren't stacked in order")
+	}
+	if options.Justify != printer.Right || options.Intensity != printer.High {
+		t.Errorf("Expected commands to change options, were %+v", options)
+	}
+}
+
+func TestUnprintableJobsAreTurnedAway(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"text":         []byte("Hello, printer!"),
+		"empty":        []byte("\n"),
+		"too wide":     []byte("P4 400 1\n" + strings.Repeat("\x00", 50)),
+		"cut short":    []byte("P4 8 4\n\x00"),
+		"text command": {printer.Esc, 'R', 0},
+		"no raster":    {printer.Esc, '@'},
+		"mixed widths": {printer.GS, 'v', '0', 0, 1, 0, 1, 0, 0xff, printer.GS, 'v', '0', 0, 2, 0, 1, 0, 0xff, 0xff},
+		"huge pbm":     []byte("P4 9223372036854775807 2\n"),
+		"long pbm":     []byte("P4 8 999999999\n"),
+		"huge raster":  {printer.GS, 'v', '0', 0, 0xff, 0xff, 0xff, 0xff},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := decode(t, data); err == nil {
+				t.Errorf("Expected job to be turned away")
+			}
+		})
+	}
+}
+
+func TestHugePngsAreTurnedAwayBeforeTheyreDecoded(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"wide": aPngClaiming(t, 100000, 10),
+		"long": aPngClaiming(t, 384, 100000),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := decode(t, data); err == nil || !strings.Contains(err.Error(), "at most") {
+				t.Errorf("Expected PNG to be turned away for its size, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package raw
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"tomgalvin.uk/phogoprint/internal/job"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// Most a connection can send for one job
+const maxJobSize = 16 << 20
+
+// A job ends when the sender closes the connection, or when nothing has been
+// sent for this long, since some tools (like netcat, by default) leave the
+// connection open once they've finished sending
+const idleTimeout = 10 * time.Second
+
+// Accepts raw print jobs over TCP. Each connection is one job: everything
+// sent before the connection is closed (or goes quiet) is read, decoded and
+// queued, or nothing is if any of it can't be printed. Nothing is sent back.
+type Listener struct {
+	Log  *slog.Logger
+	Jobs *job.Queue
+	// counts connections, so the log lines for each one can be told apart
+	connections atomic.Int64
+}
+
+func NewListener(log *slog.Logger, jobs *job.Queue) *Listener {
+	return &Listener{Log: log, Jobs: jobs}
+}
+
+// Listens on a TCP address, e.g. ":9100", handling each connection in the
+// background. Only returns if listening fails.
+func (l *Listener) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("Couldn't listen for raw print jobs:\n%w", err)
+	}
+	l.Log.Info("Listening for raw print jobs", "addr", ln.Addr().String())
+	return l.Serve(ln)
+}
+
+func (l *Listener) Serve(ln net.Listener) error {
+	defer ln.Close()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go l.handle(conn)
+	}
+}
+
+func (l *Listener) handle(conn net.Conn) {
+	defer conn.Close()
+	log := l.Log.With("connection", l.connections.Add(1), "remote", conn.RemoteAddr().String())
+	log.Info("Receiving raw print job")
+	// a bad job mustn't take the rest of the server down with it
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Couldn't print raw print job", "error", r)
+		}
+	}()
+
+	js, err := l.receive(conn)
+	if err != nil {
+		log.Error("Couldn't print raw print job", "error", err)
+		return
+	}
+	for _, j := range js {
+		log.Info("Queued raw print job", "uuid", j.Uuid, "width", j.Bitmap.Width(), "height", j.Bitmap.Height())
+	}
+}
+
+func (l *Listener) receive(conn net.Conn) ([]*job.Job, error) {
+	r := &limitedReader{conn: conn, remaining: maxJobSize}
+	options := printer.DefaultPrintOptions()
+	pbs, err := Decode(bufio.NewReader(r), l.Jobs.Connection.Profile().DotsPerLine, &options)
+	if err != nil {
+		return nil, err
+	}
+	return l.Jobs.SubmitBitmaps(pbs, options)
+}
+
+// Reads from a connection until the job ends, failing if it's more than a
+// job can be
+type limitedReader struct {
+	conn      net.Conn
+	remaining int
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if err := r.conn.SetReadDeadline(time.Now().Add(idleTimeout)); err != nil {
+		return 0, err
+	}
+	if r.remaining <= 0 {
+		// check whether the job has actually finished, rather than failing
+		// a job which is exactly the maximum size
+		p = make([]byte, 1)
+	}
+	n, err := r.conn.Read(p[:min(len(p), max(r.remaining, 1))])
+	if r.remaining <= 0 && n > 0 {
+		return 0, fmt.Errorf("Job is bigger than %v bytes", maxJobSize)
+	}
+	r.remaining -= n
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return n, io.EOF
+	}
+	return n, err
+}
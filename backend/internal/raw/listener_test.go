@@ -0,0 +1,81 @@
+package raw
+
+import (
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"tomgalvin.uk/phogoprint/internal/database/dbtest"
+	"tomgalvin.uk/phogoprint/internal/job"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// A listener on a random port, with a queue which isn't started, so jobs stay
+// queued
+func aListener(t *testing.T) (*job.Queue, string) {
+	db := dbtest.Open(t)
+
+	conn := printer.NewSimulatedConnection(printer.DefaultSimulatorOptions())
+	q := job.NewQueue(slog.Default(), &job.JobRepository{Db: db}, conn)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go NewListener(slog.Default(), q).Serve(ln)
+	return q, ln.Addr().String()
+}
+
+func send(t *testing.T, addr string, data []byte) {
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.Write(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitForJobs(t *testing.T, q *job.Queue, n int) []job.Job {
+	for range 100 {
+		js, err := q.Repository.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(js) >= n {
+			return js
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("Expected %v jobs to be queued", n)
+	return nil
+}
+
+func TestEachConnectionIsAJob(t *testing.T) {
+	q, addr := aListener(t)
+	send(t, addr, []byte("P1 2 1 1 0\nP1 2 1 0 1\n"))
+	send(t, addr, []byte("not a label"))
+	send(t, addr, []byte("P1 1 1 1"))
+
+	js := waitForJobs(t, q, 3)
+	// both of the first connection's labels are printed together, and the
+	// second connection doesn't print anything. Connections are handled at
+	// the same time, so the jobs can be in any order.
+	batches := map[string]int{}
+	for _, j := range js {
+		if j.BatchUuid == nil {
+			batches[""]++
+		} else {
+			batches[j.BatchUuid.String()]++
+		}
+	}
+	if len(batches) != 2 || batches[""] != 1 {
+		t.Errorf("Expected one batch of 2 labels and 1 label on its own, got %v", batches)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if js, _ := q.Repository.List(); len(js) != 3 {
+		t.Errorf("Expected 3 jobs, got %v", len(js))
+	}
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/job"
+	"tomgalvin.uk/phogoprint/internal/printer"
+	"tomgalvin.uk/phogoprint/internal/template"
+)
+
+func (s *Server) PrintTemplateBatch(ctx context.Context, request api.PrintTemplateBatchRequestObject) (api.PrintTemplateBatchResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.PrintTemplateBatch400JSONResponse{Reason: "Invalid template UUID"}, nil
+	}
+	t, err := s.TemplateRepository.Get(s.TemplateRepository.Db, u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch template:\n%w", err)
+	}
+	if t == nil {
+		return api.PrintTemplateBatch404Response{}, nil
+	}
+
+	var rows []map[string]string
+	var jsonOptions *api.PrintOptions
+	switch {
+	case request.JSONBody != nil:
+		rows = make([]map[string]string, len(request.JSONBody.Rows))
+		for i, values := range request.JSONBody.Rows {
+			rows[i] = mapParameterValues(values)
+		}
+		jsonOptions = request.JSONBody.Options
+	case request.Body != nil:
+		rows, err = readCsvRows(t, request.Body)
+		if err != nil {
+			return api.PrintTemplateBatch400JSONResponse{Reason: err.Error()}, nil
+		}
+	default:
+		return api.PrintTemplateBatch400JSONResponse{Reason: "Expected a JSON or CSV body"}, nil
+	}
+	if len(rows) == 0 {
+		return api.PrintTemplateBatch400JSONResponse{Reason: "No rows to print"}, nil
+	}
+
+	printOptions, err := mapPrintOptionsFromJson(jsonOptions, t.PrintOptions)
+	if err != nil {
+		return api.PrintTemplateBatch400JSONResponse{Reason: err.Error()}, nil
+	}
+	if request.Params.Copies != nil {
+		printOptions.Copies = *request.Params.Copies
+	}
+	if err := printOptions.Validate(); err != nil {
+		return api.PrintTemplateBatch400JSONResponse{Reason: err.Error()}, nil
+	}
+
+	// every row is rendered before anything is queued, so a mistake halfway
+	// through a spreadsheet doesn't leave half of the labels printed
+	images := [][]byte{}
+	sources := []*job.Source{}
+	jobOptions := printOptions
+	rowErrors := []api.BatchRowError{}
+	for i, params := range rows {
+		var rowImages [][]byte
+		rowImages, jobOptions, err = s.renderCopies(t, params, printOptions)
+		if err == nil {
+			images = append(images, rowImages...)
+			for range rowImages {
+				sources = append(sources, templateSource(t, params))
+			}
+			continue
+		}
+		rowError := api.BatchRowError{Row: i + 1}
+		var paramErrs template.ParameterErrors
+		if errors.As(err, &paramErrs) {
+			for _, e := range paramErrs {
+				rowError.Errors = append(rowError.Errors, e.Error())
+			}
+		} else {
+			rowError.Errors = []string{err.Error()}
+		}
+		rowErrors = append(rowErrors, rowError)
+	}
+	if len(rowErrors) > 0 {
+		return api.PrintTemplateBatch422JSONResponse{Rows: rowErrors}, nil
+	}
+
+	if request.Params.DryRun != nil && *request.Params.DryRun {
+		return api.PrintTemplateBatch200JSONResponse{
+			RowCount: len(rows),
+			DryRun:   true,
+		}, nil
+	}
+
+	batchUuid, js, err := s.Jobs.SubmitBatch(images, sources, jobOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't queue batch:\n%w", err)
+	}
+	batchUuidJson := batchUuid.String()
+	jsJson := make([]api.PrintJob, len(js))
+	for i, j := range js {
+		jsJson[i] = *mapJobToJson(j)
+	}
+	return api.PrintTemplateBatch202JSONResponse{
+		Uuid:     &batchUuidJson,
+		RowCount: len(rows),
+		Jobs:     &jsJson,
+	}, nil
+}
+
+// Renders a label from one set of parameter values. Templates which print
+// the copy index need every copy rendering separately, so for those there's
+// an image for each copy, and the options returned print each image once.
+func (s *Server) renderCopies(t *template.Template, params map[string]string, options printer.PrintOptions) ([][]byte, printer.PrintOptions, error) {
+	copies := 1
+	if t.UsesCopyIndex() {
+		copies = options.Copies
+		options.Copies = 1
+	}
+	images := make([][]byte, copies)
+	for i := range images {
+		img, err := template.RenderCopy(t, params, s.Connection.Profile(), i+1, copies)
+		if err != nil {
+			return nil, options, err
+		}
+		if images[i], err = job.EncodeImage(img); err != nil {
+			return nil, options, err
+		}
+	}
+	return images, options, nil
+}
+
+// Where a job printed from a template came from, to be kept in the print history
+func templateSource(t *template.Template, params map[string]string) *job.Source {
+	return &job.Source{TemplateUuid: t.Uuid, Revision: t.Revision, Parameters: params}
+}
+
+func (s *Server) GetBatch(ctx context.Context, request api.GetBatchRequestObject) (api.GetBatchResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.GetBatch400Response{}, nil
+	}
+	js, err := s.Jobs.Repository.ListBatch(u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list jobs in batch:\n%w", err)
+	}
+	if len(js) == 0 {
+		return api.GetBatch404Response{}, nil
+	}
+
+	dest := api.GetBatch200JSONResponse{
+		Uuid:  u.String(),
+		Total: len(js),
+		Jobs:  make([]api.PrintJob, len(js)),
+	}
+	for i := range js {
+		dest.Jobs[i] = *mapJobToJson(&js[i])
+		switch js[i].Status {
+		case job.Queued:
+			dest.Queued++
+		case job.Printing:
+			dest.Printing++
+		case job.Done:
+			dest.Done++
+		case job.Failed:
+			dest.Failed++
+		case job.Cancelled:
+			dest.Cancelled++
+		}
+	}
+	return dest, nil
+}
+
+// Reads rows of parameter values from a CSV file, whose header row has the
+// names of the template's parameters. Columns which aren't parameters are
+// ignored, so a spreadsheet can be uploaded as it is, and columns for optional
+// parameters can be left out.
+func readCsvRows(t *template.Template, body io.Reader) ([]map[string]string, error) {
+	// spreadsheets, like Excel, start CSV files saved as UTF-8 with a byte
+	// order mark, which would otherwise be part of the first column's name
+	br := bufio.NewReader(body)
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\ufeff")) {
+		br.Discard(3)
+	}
+	r := csv.NewReader(br)
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read CSV header:\n%w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, p := range t.Parameters {
+		if _, ok := columns[p.Name]; !ok && p.Required {
+			return nil, fmt.Errorf(`No column for parameter "%s"`, p.Name)
+		}
+	}
+
+	rows := []map[string]string{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read CSV row %d:\n%w", len(rows)+1, err)
+		}
+		params := make(map[string]string, len(t.Parameters))
+		for _, p := range t.Parameters {
+			if i, ok := columns[p.Name]; ok {
+				params[p.Name] = record[i]
+			}
+		}
+		rows = append(rows, params)
+	}
+	return rows, nil
+}
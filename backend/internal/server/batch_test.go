@@ -0,0 +1,30 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"tomgalvin.uk/phogoprint/internal/template"
+)
+
+func TestCsvFromASpreadsheetIsRead(t *testing.T) {
+	tmpl := &template.Template{Parameters: []template.Parameter{
+		{Name: "name", Type: template.StringParameter, Required: true},
+		{Name: "size", Type: template.StringParameter, Required: true},
+	}}
+	for name, csv := range map[string]string{
+		"byte order mark":         "\ufeffname,size\r\nWidget,L\r\n",
+		"quoted after the mark":   "\ufeff\"name\",\"size\"\r\nWidget,L\r\n",
+		"spaces around the names": "name , size\nWidget,L\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rows, err := readCsvRows(tmpl, strings.NewReader(csv))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != 1 || rows[0]["name"] != "Widget" || rows[0]["size"] != "L" {
+				t.Errorf("Expected one row for a Widget in L, got %v", rows)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// How often to send a comment down an idle event stream, so proxies don't
+// close it
+const eventStreamKeepAlive = 15 * time.Second
+
+func (s *Server) GetPrinterEvents(ctx context.Context, request api.GetPrinterEventsRequestObject) (api.GetPrinterEventsResponseObject, error) {
+	events, unsubscribe := s.Connection.Events().Subscribe(32)
+	info := s.Connection.GetPrinter().Info()
+	current := printer.Event{
+		Type:            printer.StateChanged,
+		Time:            time.Now(),
+		State:           info.State,
+		BatteryLevel:    info.BatteryLevel,
+		FirmwareVersion: info.FirmwareVersion,
+	}
+	return &printerEventStream{
+		ctx:         ctx,
+		log:         s.Log,
+		current:     current,
+		events:      events,
+		unsubscribe: unsubscribe,
+	}, nil
+}
+
+// Streams printer events to the client as Server-Sent Events until it
+// disconnects. The generated response type copies a reader to the response
+// without flushing, so events would sit in a buffer; this flushes after
+// every event instead.
+type printerEventStream struct {
+	ctx         context.Context
+	log         *slog.Logger
+	current     printer.Event
+	events      <-chan printer.Event
+	unsubscribe func()
+}
+
+func (e *printerEventStream) VisitGetPrinterEventsResponse(w http.ResponseWriter) error {
+	defer e.unsubscribe()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("Response writer doesn't support streaming")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(200)
+
+	if err := writeEvent(w, e.current); err != nil {
+		return err
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-e.ctx.Done():
+			e.log.Debug("Event stream closed")
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+		case event := <-e.events:
+			if err := writeEvent(w, event); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e printer.Event) error {
+	data, err := json.Marshal(mapEventToJson(&e))
+	if err != nil {
+		return fmt.Errorf("Couldn't encode event:\n%w", err)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
+
+func mapEventToJson(e *printer.Event) *api.PrinterEvent {
+	dest := api.PrinterEvent{
+		Type: api.PrinterEventType(e.Type),
+		Time: e.Time,
+	}
+	switch e.Type {
+	case printer.StateChanged:
+		state := mapDeviceStateToJson(e.State)
+		dest.State = &state
+		if e.State != printer.Disconnected && e.FirmwareVersion != "" {
+			// only the first event on a stream has the rest of the printer's info
+			dest.BatteryLevel = &e.BatteryLevel
+			dest.FirmwareVersion = &e.FirmwareVersion
+		}
+	case printer.BatteryChanged:
+		dest.BatteryLevel = &e.BatteryLevel
+	case printer.PaperChanged:
+		dest.PaperLoaded = &e.PaperLoaded
+	case printer.FirmwareInfo:
+		dest.FirmwareVersion = &e.FirmwareVersion
+	case printer.PrintStarted, printer.PrintFinished, printer.PrintFailed:
+		dest.Copies = &e.Copies
+	case printer.JobChanged:
+		status := api.JobStatus(e.JobStatus)
+		dest.JobUuid = &e.JobUuid
+		dest.JobStatus = &status
+	}
+	if e.Error != "" {
+		dest.Error = &e.Error
+	}
+	return &dest
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/template"
+)
+
+// Largest font file which can be uploaded
+const maxFontSize = 32 << 20
+
+func (s *Server) ListFont(ctx context.Context, request api.ListFontRequestObject) (api.ListFontResponseObject, error) {
+	fs, err := s.TemplateRepository.ListFonts()
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list fonts:\n%w", err)
+	}
+	fsJson := make([]api.Font, len(fs))
+	for i := 0; i < len(fs); i++ {
+		fsJson[i] = mapFontToJson(&fs[i])
+	}
+	return api.ListFont200JSONResponse(fsJson), nil
+}
+
+func (s *Server) UploadFont(ctx context.Context, request api.UploadFontRequestObject) (api.UploadFontResponseObject, error) {
+	data, err := io.ReadAll(io.LimitReader(request.Body, maxFontSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read font file:\n%w", err)
+	}
+	if len(data) > maxFontSize {
+		return api.UploadFont422JSONResponse{Reason: fmt.Sprintf("Font file is bigger than %v MB", maxFontSize>>20)}, nil
+	}
+	var name string
+	if request.Params.Name != nil {
+		name = strings.TrimSpace(*request.Params.Name)
+	}
+	f, err := template.NewFont(name, data)
+	if err != nil {
+		return api.UploadFont422JSONResponse{Reason: err.Error()}, nil
+	}
+	if err := s.TemplateRepository.CreateFont(f); err != nil {
+		return nil, err
+	}
+	s.Log.Info("Uploaded font", "uuid", f.Uuid, "name", f.Name)
+	return api.UploadFont201JSONResponse(mapFontToJson(f)), nil
+}
+
+func (s *Server) RenameFont(ctx context.Context, request api.RenameFontRequestObject) (api.RenameFontResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.RenameFont400Response{}, nil
+	}
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return api.RenameFont400Response{}, nil
+	}
+	renamed, err := s.TemplateRepository.RenameFont(u, name)
+	if err != nil {
+		return nil, err
+	}
+	if !renamed {
+		return api.RenameFont404Response{}, nil
+	}
+	f, err := s.TemplateRepository.GetFont(u)
+	if err != nil {
+		return nil, err
+	}
+	return api.RenameFont200JSONResponse(mapFontToJson(f)), nil
+}
+
+func (s *Server) DeleteFont(ctx context.Context, request api.DeleteFontRequestObject) (api.DeleteFontResponseObject, error) {
+	r := s.TemplateRepository
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.DeleteFont400Response{}, nil
+	}
+	f, err := r.GetFont(u)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return api.DeleteFont404Response{}, nil
+	}
+	if f.BuiltinName != "" {
+		return api.DeleteFont409JSONResponse{Reason: "Built-in fonts can't be deleted", Templates: []string{}}, nil
+	}
+
+	// checked in the same transaction as the delete, so a template can't start
+	// using the font in between
+	var users []uuid.UUID
+	err = r.Transact(func(tx *sql.Tx) error {
+		if users, err = r.FontUsers(tx, u); err != nil || len(users) > 0 {
+			return err
+		}
+		return r.DeleteFont(tx, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > 0 {
+		dest := api.DeleteFont409JSONResponse{
+			Reason:    "Font is still used by templates",
+			Templates: make([]string, len(users)),
+		}
+		for i, user := range users {
+			dest.Templates[i] = user.String()
+		}
+		return dest, nil
+	}
+	s.Log.Info("Deleted font", "uuid", request.Uuid)
+	return api.DeleteFont204Response{}, nil
+}
+
+func mapFontToJson(f *template.Font) api.Font {
+	return api.Font{
+		Uuid:    f.Uuid.String(),
+		Name:    f.Name,
+		Family:  f.Family,
+		Style:   f.Style,
+		Builtin: f.BuiltinName != "",
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"sort"
+
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/job"
+)
+
+// Thumbnails are scaled down to this width unless asked for otherwise
+const defaultThumbnailWidth = 128
+
+func (s *Server) ListHistory(ctx context.Context, request api.ListHistoryRequestObject) (api.ListHistoryResponseObject, error) {
+	p := request.Params
+	f := job.HistoryFilter{From: p.From, To: p.To}
+	if p.Source != nil {
+		f.Source = job.SourceKind(*p.Source)
+	}
+	if p.TemplateUuid != nil {
+		u, err := uuid.Parse(*p.TemplateUuid)
+		if err != nil {
+			return api.ListHistory400Response{}, nil
+		}
+		f.TemplateUuid = &u
+	}
+	if p.Outcome != nil {
+		f.Outcome = job.Status(*p.Outcome)
+	}
+	if p.Limit != nil {
+		if *p.Limit < 1 {
+			return api.ListHistory400Response{}, nil
+		}
+		f.Limit = *p.Limit
+	}
+
+	es, err := s.Jobs.Repository.ListHistory(f)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list print history:\n%w", err)
+	}
+	esJson := make([]api.HistoryEntry, len(es))
+	for i := range es {
+		esJson[i] = *mapHistoryEntryToJson(&es[i])
+	}
+	return api.ListHistory200JSONResponse(esJson), nil
+}
+
+func (s *Server) GetHistory(ctx context.Context, request api.GetHistoryRequestObject) (api.GetHistoryResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.GetHistory400Response{}, nil
+	}
+	e, err := s.Jobs.Repository.GetHistory(u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch history entry:\n%w", err)
+	}
+	if e == nil {
+		return api.GetHistory404Response{}, nil
+	}
+	return api.GetHistory200JSONResponse(*mapHistoryEntryToJson(e)), nil
+}
+
+func (s *Server) GetHistoryThumbnail(ctx context.Context, request api.GetHistoryThumbnailRequestObject) (api.GetHistoryThumbnailResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.GetHistoryThumbnail400Response{}, nil
+	}
+	width := defaultThumbnailWidth
+	if request.Params.Width != nil {
+		if width = *request.Params.Width; width < 1 {
+			return api.GetHistoryThumbnail400Response{}, nil
+		}
+	}
+	e, err := s.Jobs.Repository.GetHistory(u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch history entry:\n%w", err)
+	}
+	if e == nil {
+		return api.GetHistoryThumbnail404Response{}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, thumbnail(e.Bitmap, width)); err != nil {
+		return nil, fmt.Errorf("Couldn't encode thumbnail:\n%w", err)
+	}
+	return api.GetHistoryThumbnail200ImagepngResponse{
+		Body:          bytes.NewReader(buf.Bytes()),
+		ContentLength: int64(buf.Len()),
+	}, nil
+}
+
+// Draws a printed bitmap scaled down to a width, in greys so the detail
+// which is lost by shrinking it is blurred rather than dropped
+func thumbnail(pb *bitmap.PackedBitmap, width int) image.Image {
+	img := bitmap.ToPaletted(pb)
+	if pb.Width() <= width {
+		return img
+	}
+	height := max(pb.Height()*width/pb.Width(), 1)
+	scaled := image.NewGray(image.Rect(0, 0, width, height))
+	draw.ApproxBiLinear.Scale(scaled, scaled.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return scaled
+}
+
+func (s *Server) Reprint(ctx context.Context, request api.ReprintRequestObject) (api.ReprintResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.Reprint400Response{}, nil
+	}
+	j, err := s.Jobs.Reprint(u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't queue reprint:\n%w", err)
+	}
+	if j == nil {
+		return api.Reprint404Response{}, nil
+	}
+	return api.Reprint202JSONResponse(*mapJobToJson(j)), nil
+}
+
+func mapHistoryEntryToJson(e *job.HistoryEntry) *api.HistoryEntry {
+	dest := api.HistoryEntry{
+		Uuid:      e.Uuid.String(),
+		JobUuid:   e.JobUuid.String(),
+		Source:    api.HistorySource(e.Source()),
+		Options:   *mapPrintOptionsToJson(&e.Options),
+		Outcome:   api.JobStatus(e.Outcome),
+		PrintedAt: e.PrintedAt,
+		Width:     e.Width,
+		Height:    e.Height,
+	}
+	if e.TemplateUuid != nil {
+		templateUuid := e.TemplateUuid.String()
+		dest.TemplateUuid = &templateUuid
+		dest.TemplateRevision = &e.TemplateRevision
+	}
+	if e.Parameters != nil {
+		// sorted so the same values always come out in the same order
+		names := make([]string, 0, len(e.Parameters))
+		for name := range e.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		values := make([]api.ParameterValue, len(names))
+		for i, name := range names {
+			values[i] = api.ParameterValue{ParameterName: name, Value: e.Parameters[name]}
+		}
+		dest.ParameterValues = &values
+	}
+	if e.ReprintOf != nil {
+		reprintOf := e.ReprintOf.String()
+		dest.ReprintOf = &reprintOf
+	}
+	if e.Reason != "" {
+		dest.Reason = &e.Reason
+	}
+	return &dest
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/job"
+)
+
+func mapJobToJson(j *job.Job) *api.PrintJob {
+	dest := api.PrintJob{
+		Uuid:       j.Uuid.String(),
+		Status:     api.JobStatus(j.Status),
+		Options:    mapPrintOptionsToJson(&j.Options),
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+	}
+	if j.Reason != "" {
+		dest.Reason = &j.Reason
+	}
+	if j.TemplateUuid != nil {
+		templateUuid := j.TemplateUuid.String()
+		dest.TemplateUuid = &templateUuid
+	}
+	if j.TemplateRevision != 0 {
+		dest.TemplateRevision = &j.TemplateRevision
+	}
+	if j.ReprintOf != nil {
+		reprintOf := j.ReprintOf.String()
+		dest.ReprintOf = &reprintOf
+	}
+	if j.BatchUuid != nil {
+		batchUuid := j.BatchUuid.String()
+		dest.BatchUuid = &batchUuid
+	}
+	return &dest
+}
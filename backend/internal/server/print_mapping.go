@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+// Fills in any dithering options which weren't given with the defaults
+func mapRenderOptionsFromJson(src *api.DitherOptions) (bitmap.RenderOptions, error) {
+	o := bitmap.DefaultRenderOptions()
+	if src != nil {
+		if src.Algorithm != nil {
+			o.Algorithm = bitmap.DitherAlgorithm(*src.Algorithm)
+		}
+		if src.Gamma != nil {
+			o.Gamma = float64(*src.Gamma)
+		}
+		if src.Brightness != nil {
+			o.Brightness = float64(*src.Brightness)
+		}
+		if src.Contrast != nil {
+			o.Contrast = float64(*src.Contrast)
+		}
+		if src.Invert != nil {
+			o.Invert = *src.Invert
+		}
+	}
+	return o, o.Validate()
+}
+
+func mapRenderOptionsToJson(src *bitmap.RenderOptions) *api.DitherOptions {
+	algorithm := api.DitherAlgorithm(src.Algorithm)
+	gamma, brightness, contrast := float32(src.Gamma), float32(src.Brightness), float32(src.Contrast)
+	return &api.DitherOptions{
+		Algorithm:  &algorithm,
+		Gamma:      &gamma,
+		Brightness: &brightness,
+		Contrast:   &contrast,
+		Invert:     &src.Invert,
+	}
+}
+
+var laserIntensities = map[api.LaserIntensity]printer.LaserIntensity{
+	api.LOW:    printer.Low,
+	api.MEDIUM: printer.Medium,
+	api.HIGH:   printer.High,
+}
+
+var justifications = map[api.Justify]printer.Justify{
+	api.JustifyLEFT:   printer.Left,
+	api.JustifyCENTRE: printer.Centre,
+	api.JustifyRIGHT:  printer.Right,
+}
+
+// Fills in any print options which weren't given with the defaults, which
+// come from the template when printing one
+func mapPrintOptionsFromJson(src *api.PrintOptions, defaults printer.PrintOptions) (printer.PrintOptions, error) {
+	o := defaults
+	if src != nil {
+		if src.Intensity != nil {
+			intensity, ok := laserIntensities[*src.Intensity]
+			if !ok {
+				return o, fmt.Errorf("Unrecognised laser intensity %s", *src.Intensity)
+			}
+			o.Intensity = intensity
+		}
+		if src.FeedLines != nil {
+			o.FeedLines = *src.FeedLines
+		}
+		if src.Justify != nil {
+			justify, ok := justifications[*src.Justify]
+			if !ok {
+				return o, fmt.Errorf("Unrecognised justification %s", *src.Justify)
+			}
+			o.Justify = justify
+		}
+		if src.Copies != nil {
+			o.Copies = *src.Copies
+		}
+	}
+	return o, o.Validate()
+}
+
+func mapPrintOptionsToJson(src *printer.PrintOptions) *api.PrintOptions {
+	dest := api.PrintOptions{
+		FeedLines: &src.FeedLines,
+		Copies:    &src.Copies,
+	}
+	for k, v := range laserIntensities {
+		if v == src.Intensity {
+			dest.Intensity = &k
+		}
+	}
+	for k, v := range justifications {
+		if v == src.Justify {
+			dest.Justify = &k
+		}
+	}
+	return &dest
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/template"
+)
+
+func (s *Server) ListTemplateRevisions(ctx context.Context, request api.ListTemplateRevisionsRequestObject) (api.ListTemplateRevisionsResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.ListTemplateRevisions400Response{}, nil
+	}
+	revs, err := s.TemplateRepository.ListRevisions(u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list template revisions:\n%w", err)
+	}
+	if revs == nil {
+		return api.ListTemplateRevisions404Response{}, nil
+	}
+	revsJson := make([]api.TemplateRevision, len(revs))
+	for i := range revs {
+		revsJson[i] = *mapRevisionToJson(&revs[i])
+	}
+	return api.ListTemplateRevisions200JSONResponse(revsJson), nil
+}
+
+func (s *Server) GetTemplateRevision(ctx context.Context, request api.GetTemplateRevisionRequestObject) (api.GetTemplateRevisionResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.GetTemplateRevision400Response{}, nil
+	}
+	rev, err := s.TemplateRepository.GetRevision(u, request.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch template revision:\n%w", err)
+	}
+	if rev == nil {
+		return api.GetTemplateRevision404Response{}, nil
+	}
+	return api.GetTemplateRevision200JSONResponse(*mapRevisionToJson(rev)), nil
+}
+
+func (s *Server) PreviewTemplateRevision(ctx context.Context, request api.PreviewTemplateRevisionRequestObject) (api.PreviewTemplateRevisionResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.PreviewTemplateRevision400Response{}, nil
+	}
+	rev, err := s.TemplateRepository.GetRevision(u, request.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch template revision:\n%w", err)
+	}
+	if rev == nil {
+		return api.PreviewTemplateRevision404Response{}, nil
+	}
+
+	preview, err := renderPreview(rev.Template, request.Body.ParameterValues, s.Connection.Profile())
+	if err != nil {
+		return api.PreviewTemplateRevision422JSONResponse(mapInvalidParameters(err)), nil
+	}
+	return api.PreviewTemplateRevision200ImagepngResponse{
+		Body:          bytes.NewReader(preview),
+		ContentLength: int64(len(preview)),
+	}, nil
+}
+
+func (s *Server) RestoreTemplateRevision(ctx context.Context, request api.RestoreTemplateRevisionRequestObject) (api.RestoreTemplateRevisionResponseObject, error) {
+	r := s.TemplateRepository
+
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.RestoreTemplateRevision400Response{}, nil
+	}
+	rev, err := r.GetRevision(u, request.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch template revision:\n%w", err)
+	}
+	if rev == nil {
+		return api.RestoreTemplateRevision404Response{}, nil
+	}
+	for _, text := range rev.Template.Texts {
+		// fonts which have been deleted since weren't found when the revision
+		// was read
+		if text.Font.Id == 0 {
+			return api.RestoreTemplateRevision409JSONResponse("Revision uses a font which has been deleted"), nil
+		}
+	}
+
+	note := mapRevisionNote(request.Params.Author, request.Params.Note)
+	if note.Note == "" {
+		note.Note = fmt.Sprintf("Restored revision %v", rev.Number)
+	}
+	t := rev.Template
+	t.Uuid = u
+	err = r.Transact(func(tx *sql.Tx) error {
+		return r.Update(tx, u, t, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.Log.Info("Restored template revision", "uuid", request.Uuid, "revision", rev.Number, "newRevision", t.Revision)
+	return api.RestoreTemplateRevision200JSONResponse(*mapTemplateToJson(t)), nil
+}
+
+func mapRevisionNote(author, note *string) template.RevisionNote {
+	var dest template.RevisionNote
+	if author != nil {
+		dest.Author = *author
+	}
+	if note != nil {
+		dest.Note = *note
+	}
+	return dest
+}
+
+func mapRevisionToJson(rev *template.Revision) *api.TemplateRevision {
+	dest := api.TemplateRevision{
+		Revision:  rev.Number,
+		CreatedAt: rev.CreatedAt,
+	}
+	if rev.Author != "" {
+		dest.Author = &rev.Author
+	}
+	if rev.Note != "" {
+		dest.Note = &rev.Note
+	}
+	if rev.Template != nil {
+		dest.Template = mapTemplateToJson(rev.Template)
+	}
+	return &dest
+}
@@ -4,15 +4,22 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"image"
+	"image/png"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	_ "image/jpeg"
 	_ "image/png"
 
 	"github.com/google/uuid"
 	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/job"
 	"tomgalvin.uk/phogoprint/internal/printer"
 	"tomgalvin.uk/phogoprint/internal/template"
 )
@@ -23,13 +30,15 @@ type Server struct {
 	Log                *slog.Logger
 	Connection         printer.Connection
 	TemplateRepository *template.TemplateRepository
+	Jobs               *job.Queue
 }
 
-func NewServer(log *slog.Logger, conn printer.Connection, repo *template.TemplateRepository) *Server {
+func NewServer(log *slog.Logger, conn printer.Connection, repo *template.TemplateRepository, jobs *job.Queue) *Server {
 	return &Server{
 		Log: log,
 		Connection: conn,
 		TemplateRepository: repo,
+		Jobs: jobs,
 	}
 }
 
@@ -38,25 +47,32 @@ func (s *Server) PrintImage(ctx context.Context, request api.PrintImageRequestOb
 	if err != nil {
 		return api.PrintImage422Response{}, nil
 	}
-	image, format, err := image.Decode(bytes.NewReader(imageData))
+	img, format, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return api.PrintImage422Response{}, nil
+	}
+	renderOptions, err := mapRenderOptionsFromJson(request.Body.Dither)
+	if err != nil {
+		return api.PrintImage422Response{}, nil
+	}
+	printOptions, err := mapPrintOptionsFromJson(request.Body.Options, printer.DefaultPrintOptions())
 	if err != nil {
 		return api.PrintImage422Response{}, nil
 	}
 
 	fmt.Printf("Received %s image\n", format)
 
-	if err := s.Connection.Connect(); err != nil {
-		s.Log.Error("Couldn't connect to printer", "error", err)
-		return api.PrintImage503Response{}, nil
-	} else {
-		err = s.Connection.GetPrinter().WriteImage(image)
-		if err != nil {
-			s.Log.Error("Couldn't write image to printer", "error", err)
-			return api.PrintImage503Response{}, nil
-		}
-
-		return api.PrintImage202Response{}, nil
+	// dither and pack the image now, so the job stores the bitmap which gets
+	// printed and not the options used to make it
+	pb, err := bitmap.PackImage(img, s.Connection.Profile().DotsPerLine, renderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't create bitmap from image:\n%w", err)
 	}
+	js, err := s.Jobs.SubmitBitmaps([]*bitmap.PackedBitmap{pb}, printOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't queue print job:\n%w", err)
+	}
+	return api.PrintImage202JSONResponse(*mapJobToJson(js[0])), nil
 }
 
 func (s *Server) PrintTemplate(ctx context.Context, request api.PrintTemplateRequestObject) (api.PrintTemplateResponseObject, error) {
@@ -65,7 +81,7 @@ func (s *Server) PrintTemplate(ctx context.Context, request api.PrintTemplateReq
 	if err != nil {
 		return api.PrintTemplate400Response{}, nil
 	}
-	t, err := r.Get(u)
+	t, err := r.Get(r.Db, u)
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't fetch template:\n%w", err)
 	}
@@ -73,69 +89,193 @@ func (s *Server) PrintTemplate(ctx context.Context, request api.PrintTemplateReq
 		return api.PrintTemplate404Response{}, nil
 	}
 
-	paramsMap := make(map[string]string, len(t.Parameters))
+	printOptions, err := mapPrintOptionsFromJson(request.Body.Options, t.PrintOptions)
+	if err != nil {
+		return api.PrintTemplate422JSONResponse{
+			Reason: err.Error(),
+		}, nil
+	}
 
-	// this part could be more clever
-	for _, param := range t.Parameters {
-		isPresent := false
-		for _, requestParam := range request.Body.ParameterValues {
-			if param.Name == requestParam.ParameterName {
-				paramsMap[param.Name] = requestParam.Value
-				isPresent = true
-				break
-			}
-		}
-		if !isPresent {
-			return api.PrintTemplate422JSONResponse{
-				Reason: fmt.Sprintf(`Missing parameter "%s"`, param.Name),
-			}, nil
+	params := mapParameterValues(request.Body.ParameterValues)
+	images, jobOptions, err := s.renderCopies(t, params, printOptions)
+	if err != nil {
+		return api.PrintTemplate422JSONResponse(mapInvalidParameters(err)), nil
+	}
+	source := templateSource(t, params)
+
+	if len(images) > 1 {
+		// each copy is a job of its own, and the rest can be followed through
+		// the first one's batch
+		_, js, err := s.Jobs.SubmitBatch(images, slices.Repeat([]*job.Source{source}, len(images)), jobOptions)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't queue print jobs:\n%w", err)
 		}
+		return api.PrintTemplate202JSONResponse(*mapJobToJson(js[0])), nil
+	}
+	j, err := s.Jobs.Submit(images[0], source, jobOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't queue print job:\n%w", err)
 	}
+	return api.PrintTemplate202JSONResponse(*mapJobToJson(j)), nil
+}
 
-	img, err := template.RenderTemplate(t, paramsMap)
+func (s *Server) PreviewTemplate(ctx context.Context, request api.PreviewTemplateRequestObject) (api.PreviewTemplateResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
 	if err != nil {
-		return api.PrintTemplate422JSONResponse{
+		return api.PreviewTemplate400Response{}, nil
+	}
+	t, err := s.TemplateRepository.Get(s.TemplateRepository.Db, u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch template:\n%w", err)
+	}
+	if t == nil {
+		return api.PreviewTemplate404Response{}, nil
+	}
+
+	preview, err := renderPreview(t, request.Body.ParameterValues, s.Connection.Profile())
+	if err != nil {
+		return api.PreviewTemplate422JSONResponse(mapInvalidParameters(err)), nil
+	}
+	return api.PreviewTemplate200ImagepngResponse{
+		Body:          bytes.NewReader(preview),
+		ContentLength: int64(len(preview)),
+	}, nil
+}
+
+func (s *Server) PreviewUnsavedTemplate(ctx context.Context, request api.PreviewUnsavedTemplateRequestObject) (api.PreviewUnsavedTemplateResponseObject, error) {
+	t, err := s.mapTemplateFromJson(&request.Body.Template)
+	if err != nil {
+		return api.PreviewUnsavedTemplate422JSONResponse{
 			Reason: err.Error(),
 		}, nil
 	}
 
-	if err := s.Connection.Connect(); err != nil {
-		s.Log.Error("Couldn't connect to printer", "error", err)
-		return api.PrintTemplate503Response{}, nil
-	} else {
-		err = s.Connection.GetPrinter().WriteImage(img)
-		if err != nil {
-			s.Log.Error("Couldn't write image to printer", "error", err)
-			return api.PrintTemplate503Response{}, nil
+	preview, err := renderPreview(t, request.Body.ParameterValues, s.Connection.Profile())
+	if err != nil {
+		return api.PreviewUnsavedTemplate422JSONResponse(mapInvalidParameters(err)), nil
+	}
+	return api.PreviewUnsavedTemplate200ImagepngResponse{
+		Body:          bytes.NewReader(preview),
+		ContentLength: int64(len(preview)),
+	}, nil
+}
+
+// Renders a template and dithers it exactly as it would be when printed, and
+// returns the resulting bitmap encoded as a PNG
+func renderPreview(t *template.Template, values []api.ParameterValue, profile printer.Profile) ([]byte, error) {
+	img, err := template.RenderTemplate(t, mapParameterValues(values), profile)
+	if err != nil {
+		return nil, err
+	}
+	pb, err := bitmap.PackImage(img, profile.DotsPerLine, bitmap.DefaultRenderOptions())
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't create bitmap from template:\n%w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, bitmap.ToPaletted(pb)); err != nil {
+		return nil, fmt.Errorf("Couldn't encode preview:\n%w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Picks out the values given for a template's parameters from a request.
+// Missing and invalid values are left for the template to report when it's
+// rendered, as optional parameters can be left out.
+func mapParameterValues(values []api.ParameterValue) map[string]string {
+	paramsMap := make(map[string]string, len(values))
+	for _, v := range values {
+		paramsMap[v.ParameterName] = v.Value
+	}
+	return paramsMap
+}
+
+// Explains why a template couldn't be rendered, listing what's wrong with each
+// parameter if that's the reason
+func mapInvalidParameters(err error) api.InvalidParameters {
+	dest := api.InvalidParameters{Reason: err.Error()}
+	var paramErrs template.ParameterErrors
+	if errors.As(err, &paramErrs) {
+		dest.Reason = "Invalid parameter values"
+		errsJson := make([]api.ParameterError, len(paramErrs))
+		for i, e := range paramErrs {
+			errsJson[i] = api.ParameterError{Parameter: e.Parameter, Message: e.Message}
 		}
+		dest.ParameterErrors = &errsJson
+	}
+	return dest
+}
 
-		return api.PrintTemplate202Response{}, nil
+func (s *Server) ListJob(ctx context.Context, request api.ListJobRequestObject) (api.ListJobResponseObject, error) {
+	js, err := s.Jobs.Repository.List()
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list jobs:\n%w", err)
+	}
+	jsJson := make([]api.PrintJob, len(js))
+	for i := 0; i < len(js); i++ {
+		jsJson[i] = *mapJobToJson(&js[i])
 	}
+	return api.ListJob200JSONResponse(jsJson), nil
 }
 
-func (s *Server) ListFont(ctx context.Context, request api.ListFontRequestObject) (api.ListFontResponseObject, error) {
-	fs, err := s.TemplateRepository.ListFonts()
+func (s *Server) GetJob(ctx context.Context, request api.GetJobRequestObject) (api.GetJobResponseObject, error) {
+	u, err := uuid.Parse(request.Uuid)
 	if err != nil {
-		panic(err)
+		return api.GetJob400Response{}, nil
+	}
+	j, err := s.Jobs.Repository.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch job:\n%w", err)
+	}
+	if j == nil {
+		return api.GetJob404Response{}, nil
+	}
+	return api.GetJob200JSONResponse(*mapJobToJson(j)), nil
+}
+
+func (s *Server) CancelJob(ctx context.Context, request api.CancelJobRequestObject) (api.CancelJobResponseObject, error) {
+	r := s.Jobs.Repository
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.CancelJob400Response{}, nil
+	}
+	cancelled, err := s.Jobs.Cancel(u)
+	if err != nil {
+		return nil, err
+	}
+	j, err := r.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't fetch job:\n%w", err)
 	}
-	fsJson := make([]api.Font, len(fs))
-	for i := 0; i < len(fs); i++ {
-		fsJson[i].Name = fs[i].Name
-		fsJson[i].Uuid = fs[i].Uuid.String()
+	if j == nil {
+		return api.CancelJob404Response{}, nil
 	}
-	return api.ListFont200JSONResponse(fsJson), nil
+	if !cancelled {
+		return api.CancelJob409JSONResponse(*mapJobToJson(j)), nil
+	}
+	s.Log.Info("Cancelled job", "uuid", request.Uuid)
+	return api.CancelJob200JSONResponse(*mapJobToJson(j)), nil
 }
 
 func (s *Server) GetPrinterInfo(ctx context.Context, request api.GetPrinterInfoRequestObject) (api.GetPrinterInfoResponseObject, error) {
-	if !s.Connection.GetPrinter().IsConnected() {
+	info := s.Connection.GetPrinter().Info()
+	if info.State == printer.Disconnected {
 		return api.GetPrinterInfo503Response{}, nil
 	} else {
-		info := s.Connection.GetPrinter().Info()
-		return api.GetPrinterInfo200JSONResponse{
+		profile := s.Connection.Profile()
+		dest := api.GetPrinterInfo200JSONResponse{
 			BatteryLevel:    info.BatteryLevel,
 			State:           mapDeviceStateToJson(info.State),
 			FirmwareVersion: info.FirmwareVersion,
-		}, nil
+			Model:           profile.Name,
+			DotsPerLine:     profile.DotsPerLine,
+			Dpi:             profile.DPI,
+			NextReconnectAt: info.NextReconnectAt,
+		}
+		if info.State == printer.Reconnecting {
+			dest.ReconnectAttempts = &info.ReconnectAttempts
+		}
+		return dest, nil
 	}
 }
 
@@ -151,6 +291,8 @@ func mapDeviceStateToJson(s printer.DeviceState) api.DeviceState {
 		return api.BUSY
 	case printer.OutOfPaper:
 		return api.OUTOFPAPER
+	case printer.Reconnecting:
+		return api.RECONNECTING
 	default:
 		panic(fmt.Errorf("Unknown device state %v", s))
 	}
@@ -163,7 +305,7 @@ func (s *Server) GetTemplate(ctx context.Context, request api.GetTemplateRequest
 	if err != nil {
 		return api.GetTemplate400Response{}, nil
 	}
-	t, err := r.Get(u)
+	t, err := r.Get(r.Db, u)
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't fetch template:\n%w", err)
 	}
@@ -195,20 +337,21 @@ func (s *Server) CreateOrUpdateTemplate(ctx context.Context, request api.CreateO
 	}
 	t, err := s.mapTemplateFromJson(request.Body)
 	if err != nil {
-		return nil, err
+		return api.CreateOrUpdateTemplate400JSONResponse(err.Error()), nil
 	}
 	if u != t.Uuid {
 		return api.CreateOrUpdateTemplate400JSONResponse("Cannot change UUID of template"), nil
 	}
+	note := mapRevisionNote(request.Params.Author, request.Params.Note)
 	var exists bool
 	err = r.Transact(func(tx *sql.Tx) error {
-		if exists, err = r.Exists(u); err == nil {
+		if exists, err = r.Exists(tx, u); err == nil {
 			if exists {
 				s.Log.Info("Updating template", "uuid", request.Uuid)
-				return r.Update(tx, u, t)
+				return r.Update(tx, u, t, note)
 			} else {
 				s.Log.Info("Creating template", "uuid", request.Uuid)
-				return r.Create(tx, t)
+				return r.Create(tx, t, note)
 			}
 		} else {
 			return err
@@ -223,3 +366,98 @@ func (s *Server) CreateOrUpdateTemplate(ctx context.Context, request api.CreateO
 		return api.CreateOrUpdateTemplate201JSONResponse(request.Uuid), nil
 	}
 }
+
+func (s *Server) RenameTemplate(ctx context.Context, request api.RenameTemplateRequestObject) (api.RenameTemplateResponseObject, error) {
+	r := s.TemplateRepository
+
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.RenameTemplate400Response{}, nil
+	}
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return api.RenameTemplate400Response{}, nil
+	}
+	// renaming is saved as a revision like any other change
+	var t *template.Template
+	err = r.Transact(func(tx *sql.Tx) error {
+		if t, err = r.Get(tx, u); err != nil || t == nil {
+			return err
+		}
+		t.Name = name
+		return r.Update(tx, u, t, template.RevisionNote{Note: fmt.Sprintf(`Renamed to "%s"`, name)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return api.RenameTemplate404Response{}, nil
+	}
+	return api.RenameTemplate200JSONResponse(*mapTemplateToJson(t)), nil
+}
+
+func (s *Server) DeleteTemplate(ctx context.Context, request api.DeleteTemplateRequestObject) (api.DeleteTemplateResponseObject, error) {
+	r := s.TemplateRepository
+
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.DeleteTemplate400Response{}, nil
+	}
+	var deleted bool
+	err = r.Transact(func(tx *sql.Tx) error {
+		deleted, err = r.Delete(tx, u)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return api.DeleteTemplate404Response{}, nil
+	}
+	s.Log.Info("Deleted template", "uuid", request.Uuid)
+	return api.DeleteTemplate204Response{}, nil
+}
+
+func (s *Server) DuplicateTemplate(ctx context.Context, request api.DuplicateTemplateRequestObject) (api.DuplicateTemplateResponseObject, error) {
+	r := s.TemplateRepository
+
+	u, err := uuid.Parse(request.Uuid)
+	if err != nil {
+		return api.DuplicateTemplate400Response{}, nil
+	}
+	copyUuid := uuid.New()
+	if request.Body.Uuid != nil {
+		if copyUuid, err = uuid.Parse(*request.Body.Uuid); err != nil {
+			return api.DuplicateTemplate400Response{}, nil
+		}
+	}
+
+	var t *template.Template
+	var exists bool
+	err = r.Transact(func(tx *sql.Tx) error {
+		if t, err = r.Get(tx, u); err != nil || t == nil {
+			return err
+		}
+		if exists, err = r.Exists(tx, copyUuid); err != nil || exists {
+			return err
+		}
+		t.Uuid = copyUuid
+		t.CreatedAt = time.Now()
+		t.Name += " (copy)"
+		if request.Body.Name != nil && strings.TrimSpace(*request.Body.Name) != "" {
+			t.Name = strings.TrimSpace(*request.Body.Name)
+		}
+		return r.Create(tx, t, template.RevisionNote{Note: fmt.Sprintf("Copied from %s", u)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return api.DuplicateTemplate404Response{}, nil
+	}
+	if exists {
+		return api.DuplicateTemplate409Response{}, nil
+	}
+	s.Log.Info("Duplicated template", "uuid", request.Uuid, "copy", copyUuid)
+	return api.DuplicateTemplate201JSONResponse(*mapTemplateToJson(t)), nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/printer"
 	"tomgalvin.uk/phogoprint/internal/template"
 )
 
@@ -17,11 +18,18 @@ func mapTemplateToJson(t *template.Template) *api.Template {
 		Landscape: t.Landscape,
 		MinSize: t.MinSize,
 		MaxSize: t.MaxSize,
+		PrintOptions: mapPrintOptionsToJson(&t.PrintOptions),
+	}
+	if t.Revision != 0 {
+		j.Revision = &t.Revision
 	}
 
 	parameters := make([]api.TemplateParameter, len(t.Parameters))
 	texts := make([]api.TemplateText, len(t.Texts))
 	images := make([]api.TemplateImage, len(t.Images))
+	barcodes := make([]api.TemplateBarcode, len(t.Barcodes))
+	matrixCodes := make([]api.TemplateMatrixCode, len(t.MatrixCodes))
+	shapes := make([]api.TemplateShape, len(t.Shapes))
 
 	for i := 0; i < len(parameters); i++ {
 	  mapParameterToJson(&t.Parameters[i], &parameters[i])
@@ -35,7 +43,20 @@ func mapTemplateToJson(t *template.Template) *api.Template {
 		mapImageToJson(&t.Images[i], &images[i])
 	}
 
-	j.Parameters, j.Images, j.Texts = &parameters, &images, &texts
+	for i := 0; i < len(barcodes); i++ {
+		mapBarcodeToJson(&t.Barcodes[i], &barcodes[i])
+	}
+
+	for i := 0; i < len(matrixCodes); i++ {
+		mapMatrixCodeToJson(&t.MatrixCodes[i], &matrixCodes[i])
+	}
+
+	for i := 0; i < len(shapes); i++ {
+		mapShapeToJson(&t.Shapes[i], &shapes[i])
+	}
+
+	j.Parameters, j.Images, j.Texts, j.Barcodes = &parameters, &images, &texts, &barcodes
+	j.MatrixCodes, j.Shapes = &matrixCodes, &shapes
 	return &j
 }
 
@@ -53,10 +74,18 @@ func (s *Server) mapTemplateFromJson(j *api.Template) (*template.Template, error
 		MaxSize: j.MaxSize,
 	}
 
+	printOptions, err := mapPrintOptionsFromJson(j.PrintOptions, printer.DefaultPrintOptions())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid print options:\n%w", err)
+	}
+	t.PrintOptions = printOptions
+
 	if j.Parameters != nil {
 		t.Parameters = make([]template.Parameter, len(*j.Parameters))
 		for i := 0; i < len(t.Parameters); i++ {
-			mapParameterFromJson(&(*j.Parameters)[i], &t.Parameters[i])
+			if err := mapParameterFromJson(&(*j.Parameters)[i], &t.Parameters[i]); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if j.Texts != nil {
@@ -75,20 +104,100 @@ func (s *Server) mapTemplateFromJson(j *api.Template) (*template.Template, error
 			}
 		}	
 	}
+	if j.Barcodes != nil {
+		t.Barcodes = make([]template.Barcode, len(*j.Barcodes))
+		for i := 0; i < len(t.Barcodes); i++ {
+			if err := mapBarcodeFromJson(&(*j.Barcodes)[i], &t.Barcodes[i]); err != nil {
+				return nil, fmt.Errorf("Invalid barcode at index %v:\n%w", i, err)
+			}
+		}
+	}
+	if j.MatrixCodes != nil {
+		t.MatrixCodes = make([]template.MatrixCode, len(*j.MatrixCodes))
+		for i := 0; i < len(t.MatrixCodes); i++ {
+			if err := mapMatrixCodeFromJson(&(*j.MatrixCodes)[i], &t.MatrixCodes[i]); err != nil {
+				return nil, fmt.Errorf("Invalid matrix code at index %v:\n%w", i, err)
+			}
+		}
+	}
+	if j.Shapes != nil {
+		t.Shapes = make([]template.Shape, len(*j.Shapes))
+		for i := 0; i < len(t.Shapes); i++ {
+			if err := mapShapeFromJson(&(*j.Shapes)[i], &t.Shapes[i]); err != nil {
+				return nil, fmt.Errorf("Invalid shape at index %v:\n%w", i, err)
+			}
+		}
+	}
+	if err := t.ValidateExpressions(); err != nil {
+		return nil, err
+	}
 
 	return &t, nil
 }
 
 func mapParameterToJson(src *template.Parameter, dest *api.TemplateParameter) {
+	parameterType := api.ParameterType(src.Type)
 	dest.Name = src.Name
+	dest.Type = &parameterType
 	dest.MaxLength = &src.MaxLength
+	dest.Required = &src.Required
+	if src.Pattern != "" {
+		dest.Pattern = &src.Pattern
+	}
+	if src.Min != "" {
+		dest.Min = &src.Min
+	}
+	if src.Max != "" {
+		dest.Max = &src.Max
+	}
+	if src.Default != "" {
+		dest.Default = &src.Default
+	}
+	if len(src.Choices) > 0 {
+		dest.Choices = &src.Choices
+	}
+	if src.Padding > 0 {
+		dest.Padding = &src.Padding
+	}
+	if src.DateLayout != "" {
+		dest.DateLayout = &src.DateLayout
+	}
 }
 
-func mapParameterFromJson(src *api.TemplateParameter, dest *template.Parameter) {
+func mapParameterFromJson(src *api.TemplateParameter, dest *template.Parameter) error {
 	dest.Name = src.Name
+	dest.Type = template.StringParameter
+	if src.Type != nil {
+		dest.Type = template.ParameterType(*src.Type)
+	}
 	if src.MaxLength != nil {
 		dest.MaxLength = *src.MaxLength
 	}
+	if src.Pattern != nil {
+		dest.Pattern = *src.Pattern
+	}
+	if src.Min != nil {
+		dest.Min = *src.Min
+	}
+	if src.Max != nil {
+		dest.Max = *src.Max
+	}
+	if src.Required != nil {
+		dest.Required = *src.Required
+	}
+	if src.Default != nil {
+		dest.Default = *src.Default
+	}
+	if src.Choices != nil {
+		dest.Choices = *src.Choices
+	}
+	if src.Padding != nil {
+		dest.Padding = *src.Padding
+	}
+	if src.DateLayout != nil {
+		dest.DateLayout = *src.DateLayout
+	}
+	return dest.Validate()
 }
 
 func mapTextToJson(src *template.Text, dest *api.TemplateText) {
@@ -103,6 +212,17 @@ func mapTextToJson(src *template.Text, dest *api.TemplateText) {
 		dest.Height = &src.Height
 	}
 	dest.FontUuid = src.Font.Uuid.String()
+	align := api.TextAlign(src.Align)
+	verticalAlign := api.VerticalAlign(src.VerticalAlign)
+	lineHeight := float32(src.LineHeight)
+	dest.Align = &align
+	dest.VerticalAlign = &verticalAlign
+	dest.LineHeight = &lineHeight
+	dest.AutoFit = &src.AutoFit
+	dest.MinFontSize = &src.MinFontSize
+	if src.MaxLines > 0 {
+		dest.MaxLines = &src.MaxLines
+	}
 }
 
 func (s *Server) mapTextFromJson(src *api.TemplateText, dest *template.Text) error {
@@ -116,6 +236,31 @@ func (s *Server) mapTextFromJson(src *api.TemplateText, dest *template.Text) err
 	if src.Height != nil {
 		dest.Height = *src.Height
 	}
+	dest.Align = template.AlignLeft
+	if src.Align != nil {
+		dest.Align = template.TextAlign(*src.Align)
+	}
+	dest.VerticalAlign = template.AlignTop
+	if src.VerticalAlign != nil {
+		dest.VerticalAlign = template.VerticalAlign(*src.VerticalAlign)
+	}
+	dest.LineHeight = 1
+	if src.LineHeight != nil {
+		dest.LineHeight = float64(*src.LineHeight)
+	}
+	if src.MaxLines != nil {
+		dest.MaxLines = *src.MaxLines
+	}
+	if src.AutoFit != nil {
+		dest.AutoFit = *src.AutoFit
+	}
+	dest.MinFontSize = 6
+	if src.MinFontSize != nil {
+		dest.MinFontSize = *src.MinFontSize
+	}
+	if err := dest.Validate(); err != nil {
+		return err
+	}
 
 	fontUuid, err := uuid.Parse(src.FontUuid)
 	if err != nil {
@@ -140,6 +285,7 @@ func mapImageToJson(src *template.Image, dest *api.TemplateImage) {
 	dest.Position.Y = src.Y
 	dest.Width = src.Width
 	dest.Height = src.Height
+	dest.Dither = mapRenderOptionsToJson(&src.Dither)
 }
 
 func mapImageFromJson(src *api.TemplateImage, dest *template.Image) error {
@@ -149,5 +295,104 @@ func mapImageFromJson(src *api.TemplateImage, dest *template.Image) error {
 	dest.Y = src.Position.Y
 	dest.Width = src.Width
 	dest.Height = src.Height
+	if err != nil {
+		return err
+	}
+	dest.Dither, err = mapRenderOptionsFromJson(src.Dither)
 	return err
 }
+
+func mapBarcodeToJson(src *template.Barcode, dest *api.TemplateBarcode) {
+	dest.Symbology = api.BarcodeSymbology(src.Symbology)
+	dest.Data = src.Data
+	dest.Position.X = src.X
+	dest.Position.Y = src.Y
+	dest.ModuleWidth = &src.ModuleWidth
+	dest.Height = src.Height
+	dest.ShowText = &src.ShowText
+}
+
+func mapBarcodeFromJson(src *api.TemplateBarcode, dest *template.Barcode) error {
+	dest.Symbology = template.Symbology(src.Symbology)
+	dest.Data = src.Data
+	dest.X = src.Position.X
+	dest.Y = src.Position.Y
+	dest.ModuleWidth = 2
+	if src.ModuleWidth != nil {
+		dest.ModuleWidth = *src.ModuleWidth
+	}
+	dest.Height = src.Height
+	if src.ShowText != nil {
+		dest.ShowText = *src.ShowText
+	}
+	return dest.Validate()
+}
+
+func mapMatrixCodeToJson(src *template.MatrixCode, dest *api.TemplateMatrixCode) {
+	dest.Symbology = api.MatrixCodeSymbology(src.Symbology)
+	dest.Data = src.Data
+	dest.Position.X = src.X
+	dest.Position.Y = src.Y
+	dest.ModuleSize = &src.ModuleSize
+	dest.QuietZone = &src.QuietZone
+	errorCorrection := api.ErrorCorrectionLevel(src.ErrorCorrection)
+	dest.ErrorCorrection = &errorCorrection
+}
+
+func mapMatrixCodeFromJson(src *api.TemplateMatrixCode, dest *template.MatrixCode) error {
+	dest.Symbology = template.Symbology(src.Symbology)
+	dest.Data = src.Data
+	dest.X = src.Position.X
+	dest.Y = src.Position.Y
+	dest.ModuleSize = 4
+	if src.ModuleSize != nil {
+		dest.ModuleSize = *src.ModuleSize
+	}
+	if src.QuietZone != nil {
+		dest.QuietZone = *src.QuietZone
+	} else if dest.Symbology == template.DataMatrix {
+		dest.QuietZone = 1
+	} else {
+		dest.QuietZone = 4
+	}
+	dest.ErrorCorrection = template.ErrorCorrectionM
+	if src.ErrorCorrection != nil {
+		dest.ErrorCorrection = template.ErrorCorrectionLevel(*src.ErrorCorrection)
+	}
+	return dest.Validate()
+}
+
+func mapShapeToJson(src *template.Shape, dest *api.TemplateShape) {
+	dest.Kind = api.ShapeKind(src.Kind)
+	dest.Position.X = src.X
+	dest.Position.Y = src.Y
+	dest.Width = src.Width
+	dest.Height = src.Height
+	dest.StrokeWidth = &src.StrokeWidth
+	dest.Fill = &src.Fill
+	dest.CornerRadius = &src.CornerRadius
+	dash := append([]int{}, src.Dash...)
+	dest.Dash = &dash
+}
+
+func mapShapeFromJson(src *api.TemplateShape, dest *template.Shape) error {
+	dest.Kind = template.ShapeKind(src.Kind)
+	dest.X = src.Position.X
+	dest.Y = src.Position.Y
+	dest.Width = src.Width
+	dest.Height = src.Height
+	dest.StrokeWidth = 1
+	if src.StrokeWidth != nil {
+		dest.StrokeWidth = *src.StrokeWidth
+	}
+	if src.Fill != nil {
+		dest.Fill = *src.Fill
+	}
+	if src.CornerRadius != nil {
+		dest.CornerRadius = *src.CornerRadius
+	}
+	if src.Dash != nil {
+		dest.Dash = *src.Dash
+	}
+	return dest.Validate()
+}
@@ -0,0 +1,269 @@
+package template
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"golang.org/x/image/draw"
+
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/code39"
+	"github.com/boombuler/barcode/datamatrix"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+)
+
+type Symbology string
+
+const (
+	Code128 Symbology = "CODE128"
+	EAN13   Symbology = "EAN13"
+	UPCA    Symbology = "UPCA"
+	Code39  Symbology = "CODE39"
+)
+
+const (
+	QRCode     Symbology = "QR"
+	DataMatrix Symbology = "DATAMATRIX"
+)
+
+type ErrorCorrectionLevel string
+
+const (
+	ErrorCorrectionL ErrorCorrectionLevel = "L"
+	ErrorCorrectionM ErrorCorrectionLevel = "M"
+	ErrorCorrectionQ ErrorCorrectionLevel = "Q"
+	ErrorCorrectionH ErrorCorrectionLevel = "H"
+)
+
+// Font size of the human readable text printed under a barcode
+const barcodeTextSize = 14
+
+// Gap between the bars of a barcode and the human readable text
+const barcodeTextGap = 2
+
+const code39Characters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"
+
+func encodeBarcodesForTemplate(t *Template) error {
+	for i := 0; i < len(t.Barcodes); i++ {
+		if err := encodeBarcode(&t.Barcodes[i]); err != nil {
+			return fmt.Errorf("Couldn't encode barcode at index %v:\n%w", i, err)
+		}
+	}
+	return nil
+}
+
+// Checks a barcode's settings, and its data too if there are no expressions
+// in it, so mistakes like a wrong check digit are reported when the template
+// is saved rather than when it's printed.
+func (b *Barcode) Validate() error {
+	if err := b.checkSettings(); err != nil {
+		return err
+	}
+	if strings.Contains(b.Data, "{") {
+		return nil
+	}
+	check := *b
+	check.FilledData, check.ShowText = b.Data, false
+	return encodeBarcode(&check)
+}
+
+func (b *Barcode) checkSettings() error {
+	if b.ModuleWidth <= 0 {
+		return fmt.Errorf("Module width must be positive")
+	}
+	if b.Height <= 0 {
+		return fmt.Errorf("Bar height must be positive")
+	}
+	switch b.Symbology {
+	case Code128, Code39, EAN13, UPCA:
+	default:
+		return fmt.Errorf(`Unrecognised barcode symbology "%s"`, b.Symbology)
+	}
+	return nil
+}
+
+func encodeBarcode(b *Barcode) error {
+	if err := b.checkSettings(); err != nil {
+		return err
+	}
+	if len(b.FilledData) == 0 {
+		return fmt.Errorf("Barcode data is empty")
+	}
+
+	var err error
+	switch b.Symbology {
+	case Code128:
+		b.Encoded, err = code128.Encode(b.FilledData)
+		b.HumanReadableText = b.FilledData
+	case Code39:
+		for _, r := range b.FilledData {
+			if !strings.ContainsRune(code39Characters, r) {
+				return fmt.Errorf(`Character %q can't be encoded in a Code 39 barcode`, r)
+			}
+		}
+		b.Encoded, err = code39.Encode(b.FilledData, false, false)
+		b.HumanReadableText = b.FilledData
+	case EAN13:
+		var data string
+		if data, err = withGS1CheckDigit(b.FilledData, 13, "EAN-13"); err == nil {
+			b.Encoded, err = ean.Encode(data)
+			b.HumanReadableText = data
+		}
+	case UPCA:
+		// a UPC-A barcode is an EAN-13 barcode with a leading zero
+		var data string
+		if data, err = withGS1CheckDigit(b.FilledData, 12, "UPC-A"); err == nil {
+			b.Encoded, err = ean.Encode("0" + data)
+			b.HumanReadableText = data
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Couldn't encode %s barcode data %q:\n%w", b.Symbology, b.FilledData, err)
+	}
+
+	if b.ShowText {
+		b.TextFace, err = loadFont(&Font{Name: "Go Mono", BuiltinName: "gomono"}, barcodeTextSize)
+		if err != nil {
+			return fmt.Errorf("Couldn't load font for barcode text:\n%w", err)
+		}
+	}
+	return nil
+}
+
+func encodeMatrixCodesForTemplate(t *Template) error {
+	for i := 0; i < len(t.MatrixCodes); i++ {
+		if err := encodeMatrixCode(&t.MatrixCodes[i]); err != nil {
+			return fmt.Errorf("Couldn't encode matrix code at index %v:\n%w", i, err)
+		}
+	}
+	return nil
+}
+
+// Checks a matrix code's settings, and its data too if there are no
+// expressions in it, so mistakes are reported when the template is saved
+// rather than when it's printed.
+func (c *MatrixCode) Validate() error {
+	if err := c.checkSettings(); err != nil {
+		return err
+	}
+	if strings.Contains(c.Data, "{") {
+		return nil
+	}
+	check := *c
+	check.FilledData = c.Data
+	return encodeMatrixCode(&check)
+}
+
+func (c *MatrixCode) checkSettings() error {
+	if c.ModuleSize <= 0 {
+		return fmt.Errorf("Module size must be positive")
+	}
+	if c.QuietZone < 0 {
+		return fmt.Errorf("Quiet zone can't be negative")
+	}
+	switch c.Symbology {
+	case QRCode:
+		if _, ok := qrErrorCorrection[c.ErrorCorrection]; !ok {
+			return fmt.Errorf(`Unrecognised error correction level "%s"`, c.ErrorCorrection)
+		}
+	case DataMatrix:
+	default:
+		return fmt.Errorf(`Unrecognised matrix code symbology "%s"`, c.Symbology)
+	}
+	return nil
+}
+
+var qrErrorCorrection = map[ErrorCorrectionLevel]qr.ErrorCorrectionLevel{
+	ErrorCorrectionL: qr.L,
+	ErrorCorrectionM: qr.M,
+	ErrorCorrectionQ: qr.Q,
+	ErrorCorrectionH: qr.H,
+}
+
+func encodeMatrixCode(c *MatrixCode) error {
+	if err := c.checkSettings(); err != nil {
+		return err
+	}
+	if len(c.FilledData) == 0 {
+		return fmt.Errorf("Matrix code data is empty")
+	}
+
+	var err error
+	switch c.Symbology {
+	case QRCode:
+		c.Encoded, err = qr.Encode(c.FilledData, qrErrorCorrection[c.ErrorCorrection], qr.Auto)
+	case DataMatrix:
+		// Data Matrix (ECC 200) has a fixed level of error correction
+		for _, r := range c.FilledData {
+			if r > 127 {
+				return fmt.Errorf("Character %q can't be encoded in a Data Matrix code", r)
+			}
+		}
+		c.Encoded, err = datamatrix.Encode(c.FilledData)
+	}
+	if err != nil {
+		return fmt.Errorf("Couldn't encode %s data %q:\n%w", c.Symbology, c.FilledData, err)
+	}
+	return nil
+}
+
+// Checks the data for a GS1 barcode (EAN-13, UPC-A) is all digits and has a
+// correct check digit. If the data is one digit short, the check digit is
+// calculated and added on.
+func withGS1CheckDigit(data string, length int, name string) (string, error) {
+	for _, r := range data {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%s barcode data must only contain digits, got %q", name, data)
+		}
+	}
+	switch len(data) {
+	case length - 1:
+		return data + string(gs1CheckDigit(data)), nil
+	case length:
+		expected := gs1CheckDigit(data[:length-1])
+		if actual := rune(data[length-1]); actual != expected {
+			return "", fmt.Errorf("Invalid check digit for %s barcode %s: expected %c but got %c", name, data, expected, actual)
+		}
+		return data, nil
+	default:
+		return "", fmt.Errorf("%s barcode data must be %v digits, or %v without the check digit, got %v digits", name, length, length-1, len(data))
+	}
+}
+
+// Calculates the GS1 mod 10 check digit: digits are weighted 3, 1, 3, 1...
+// starting from the rightmost digit.
+func gs1CheckDigit(digits string) rune {
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			sum += 3 * d
+		} else {
+			sum += d
+		}
+	}
+	return rune('0' + (10-sum%10)%10)
+}
+
+// Draws each module (the smallest bar or square) of an encoded barcode as a
+// block of whole pixels, so it isn't blurred by scaling.
+func drawModules(code image.Image, dst *image.RGBA64, x, y, moduleWidth, moduleHeight int) {
+	black := image.NewUniform(color.Black)
+	bounds := code.Bounds()
+	for my := bounds.Min.Y; my < bounds.Max.Y; my++ {
+		for mx := bounds.Min.X; mx < bounds.Max.X; mx++ {
+			if isDarkModule(code.At(mx, my)) {
+				px := x + (mx-bounds.Min.X)*moduleWidth
+				py := y + (my-bounds.Min.Y)*moduleHeight
+				draw.Draw(dst, image.Rect(px, py, px+moduleWidth, py+moduleHeight), black, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+func isDarkModule(c color.Color) bool {
+	return color.GrayModel.Convert(c).(color.Gray).Y < 128
+}
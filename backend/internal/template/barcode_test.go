@@ -0,0 +1,151 @@
+package template
+
+import (
+	"testing"
+
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+func TestGS1CheckDigit(t *testing.T) {
+	tests := []struct {
+		data   string
+		length int
+		want   string
+	}{
+		{"400638133393", 13, "4006381333931"},
+		{"4006381333931", 13, "4006381333931"},
+		{"03600029145", 12, "036000291452"},
+		{"036000291452", 12, "036000291452"},
+	}
+
+	for _, test := range tests {
+		got, err := withGS1CheckDigit(test.data, test.length, "test")
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", test.data, err)
+		} else if got != test.want {
+			t.Errorf("Expected %s for %s, got %s", test.want, test.data, got)
+		}
+	}
+}
+
+func TestGS1CheckDigitInvalid(t *testing.T) {
+	for _, data := range []string{"4006381333932", "40063813339", "400638133393A"} {
+		if _, err := withGS1CheckDigit(data, 13, "EAN-13"); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestBarcodeRendersAtModuleWidth(t *testing.T) {
+	tmpl := &Template{
+		MinSize: 100,
+		Barcodes: []Barcode{
+			{Symbology: EAN13, Data: "{code}", X: 10, Y: 10, ModuleWidth: 3, Height: 50},
+		},
+		Parameters: []Parameter{{Name: "code"}},
+	}
+
+	img, err := RenderTemplate(tmpl, map[string]string{"code": "400638133393"}, printer.DefaultProfile())
+	if err != nil {
+		t.Fatalf("Couldn't render template: %v", err)
+	}
+
+	// an EAN-13 barcode is 95 modules wide and starts with a 101 guard pattern
+	b := tmpl.Barcodes[0]
+	if w := measureAndDrawChildBarcode(&b, nil).Width; w != 95*3 {
+		t.Errorf("Expected barcode to be %v pixels wide, was %v", 95*3, w)
+	}
+	for x, dark := range []bool{true, true, true, false, false, false, true, true, true} {
+		c := img.At(10+x, 30)
+		if isDarkModule(c) != dark {
+			t.Errorf("Expected pixel at x=%v to be dark=%v", 10+x, dark)
+		}
+	}
+}
+
+func TestMatrixCodeRendersAtModuleSize(t *testing.T) {
+	for _, landscape := range []bool{false, true} {
+		tmpl := &Template{
+			Landscape: landscape,
+			MinSize:   100,
+			MatrixCodes: []MatrixCode{
+				{Symbology: QRCode, Data: "{url}", X: 5, Y: 5, ModuleSize: 3, QuietZone: 4, ErrorCorrection: ErrorCorrectionH},
+			},
+			Parameters: []Parameter{{Name: "url"}},
+		}
+
+		img, err := RenderTemplate(tmpl, map[string]string{"url": "https://example.com"}, printer.DefaultProfile())
+		if err != nil {
+			t.Fatalf("Couldn't render template: %v", err)
+		}
+
+		// the top left finder pattern starts after the quiet zone with a 7x7
+		// ring of dark modules
+		start := 5 + 4*3
+		for i := range 7 * 3 {
+			x, y := start+i, start
+			if landscape {
+				// rotated 90 degrees clockwise
+				x, y = img.Bounds().Dx()-1-start, start+i
+			}
+			if !isDarkModule(img.At(x, y)) {
+				t.Errorf("landscape=%v: expected finder pattern pixel at (%v, %v) to be dark", landscape, x, y)
+			}
+		}
+		x, y := start-1, start
+		if landscape {
+			x, y = img.Bounds().Dx()-start, start
+		}
+		if isDarkModule(img.At(x, y)) {
+			t.Errorf("landscape=%v: expected quiet zone pixel at (%v, %v) to be light", landscape, x, y)
+		}
+	}
+}
+
+func TestBarcodeValidation(t *testing.T) {
+	valid := []Barcode{
+		{Symbology: EAN13, Data: "4006381333931", ModuleWidth: 2, Height: 40},
+		// checked when it's printed, once the expression is filled in
+		{Symbology: EAN13, Data: "{sku}", ModuleWidth: 2, Height: 40},
+	}
+	for _, b := range valid {
+		if err := b.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid: %v", b, err)
+		}
+	}
+	invalid := []Barcode{
+		{Symbology: "PDF417", Data: "{sku}", ModuleWidth: 2, Height: 40},
+		{Symbology: Code128, Data: "ABC", ModuleWidth: 0, Height: 40},
+		{Symbology: Code128, Data: "ABC", ModuleWidth: 2, Height: -1},
+		{Symbology: EAN13, Data: "4006381333932", ModuleWidth: 2, Height: 40},
+	}
+	for _, b := range invalid {
+		if err := b.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", b)
+		}
+	}
+}
+
+func TestMatrixCodeValidation(t *testing.T) {
+	valid := []MatrixCode{
+		{Symbology: QRCode, Data: "https://example.com", ModuleSize: 4, QuietZone: 4, ErrorCorrection: ErrorCorrectionM},
+		{Symbology: DataMatrix, Data: "{sku}", ModuleSize: 4, QuietZone: 1},
+	}
+	for _, c := range valid {
+		if err := c.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid: %v", c, err)
+		}
+	}
+	invalid := []MatrixCode{
+		{Symbology: "AZTEC", Data: "{sku}", ModuleSize: 4},
+		{Symbology: QRCode, Data: "{sku}", ModuleSize: 4, ErrorCorrection: "X"},
+		{Symbology: QRCode, Data: "{sku}", ModuleSize: 0, ErrorCorrection: ErrorCorrectionM},
+		{Symbology: DataMatrix, Data: "{sku}", ModuleSize: 4, QuietZone: -1},
+		{Symbology: DataMatrix, Data: "café", ModuleSize: 4},
+	}
+	for _, c := range invalid {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
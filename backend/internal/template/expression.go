@@ -0,0 +1,509 @@
+package template
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Text and barcode content can use expressions in braces, which are filled in
+// when the template is printed:
+//
+//	{name}                          value of a parameter
+//	{name | upper | truncate:10}    filters: upper, lower, trim, truncate:n,
+//	                                pad:n or pad:n:"0", date:"DD/MM/YYYY",
+//	                                default:"fallback"
+//	{if name}...{else}...{end}      conditionals, true unless empty or false
+//	{if size == "L"}...{end}        comparisons with == and !=
+//	{now | date:"DD/MM/YY"}         built-ins: now, copy and copies
+//	{{ and }}                       literal braces
+//
+// Expressions are parsed when a template is saved, so mistakes are reported
+// then rather than when printing.
+type Expression struct {
+	nodes []node
+}
+
+// Values available to an expression besides the template's parameters
+type Scope struct {
+	Params map[string]string
+	Now    time.Time
+	// Which copy is being printed, counting from 1, out of how many
+	Copy, Copies int
+}
+
+const (
+	builtinNow    = "now"
+	builtinCopy   = "copy"
+	builtinCopies = "copies"
+)
+
+// How many arguments each filter takes, at least and at most
+var filterArgs = map[string][2]int{
+	"upper":    {0, 0},
+	"lower":    {0, 0},
+	"trim":     {0, 0},
+	"truncate": {1, 1},
+	"pad":      {1, 2},
+	"date":     {1, 1},
+	"default":  {1, 1},
+}
+
+type node interface {
+	eval(s *Scope, b *strings.Builder) error
+}
+
+type textNode string
+
+type outputNode struct {
+	value valueExpr
+}
+
+type conditionalNode struct {
+	cond      condition
+	then      []node
+	otherwise []node
+}
+
+type condition struct {
+	left, right valueExpr
+	// empty if the condition is just a value, otherwise == or !=
+	op          string
+}
+
+type valueExpr struct {
+	// the name of a parameter or built-in, or a literal if isLiteral is set
+	operand   string
+	isLiteral bool
+	filters   []filterCall
+}
+
+type filterCall struct {
+	name string
+	args []string
+}
+
+// A value while an expression is evaluated. Only now is a time, so that it
+// can be formatted without losing precision.
+type value struct {
+	text string
+	time *time.Time
+}
+
+func (v value) String() string {
+	if v.time != nil {
+		return v.time.Format("2006-01-02 15:04")
+	}
+	return v.text
+}
+
+func ParseExpression(s string) (*Expression, error) {
+	root := []node{}
+	// the enclosing conditionals, innermost last
+	var open []*conditionalNode
+	var inElse []bool
+	appendNode := func(n node) {
+		if len(open) == 0 {
+			root = append(root, n)
+		} else if c := open[len(open)-1]; inElse[len(inElse)-1] {
+			c.otherwise = append(c.otherwise, n)
+		} else {
+			c.then = append(c.then, n)
+		}
+	}
+
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			appendNode(textNode(text.String()))
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "{{"):
+			text.WriteByte('{')
+			i += 2
+		case strings.HasPrefix(s[i:], "}}"):
+			text.WriteByte('}')
+			i += 2
+		case s[i] == '{':
+			end, err := findTagEnd(s, i)
+			if err != nil {
+				return nil, err
+			}
+			flushText()
+			tag := strings.TrimSpace(s[i+1 : end])
+			keyword, rest, _ := strings.Cut(tag, " ")
+			switch keyword {
+			case "if":
+				c, err := parseCondition(rest)
+				if err != nil {
+					return nil, fmt.Errorf("Invalid condition {%s}:\n%w", tag, err)
+				}
+				n := &conditionalNode{cond: c}
+				appendNode(n)
+				open, inElse = append(open, n), append(inElse, false)
+			case "else":
+				if len(open) == 0 || inElse[len(inElse)-1] || rest != "" {
+					return nil, fmt.Errorf("Unexpected {%s} at position %d", tag, i)
+				}
+				inElse[len(inElse)-1] = true
+			case "end":
+				if len(open) == 0 || rest != "" {
+					return nil, fmt.Errorf("Unexpected {%s} at position %d", tag, i)
+				}
+				open, inElse = open[:len(open)-1], inElse[:len(inElse)-1]
+			default:
+				v, err := parseValue(tag)
+				if err != nil {
+					return nil, fmt.Errorf("Invalid expression {%s}:\n%w", tag, err)
+				}
+				appendNode(&outputNode{v})
+			}
+			i = end + 1
+		default:
+			text.WriteByte(s[i])
+			i++
+		}
+	}
+	if len(open) > 0 {
+		return nil, fmt.Errorf("Missing {end} for {if}")
+	}
+	flushText()
+	return &Expression{nodes: root}, nil
+}
+
+// Finds the closing brace of the tag starting at start, skipping any inside
+// quoted strings
+func findTagEnd(s string, start int) (int, error) {
+	inString := false
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case inString && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			inString = !inString
+		case !inString && s[i] == '}':
+			return i, nil
+		case !inString && s[i] == '{':
+			return 0, fmt.Errorf("Unexpected { inside expression at position %d; write {{ for a literal brace", i)
+		}
+	}
+	return 0, fmt.Errorf("Unclosed { at position %d; write {{ for a literal brace", start)
+}
+
+// Names of the parameters and built-ins an expression refers to
+func (e *Expression) Variables() []string {
+	vars := []string{}
+	var visit func(ns []node)
+	addValue := func(v valueExpr) {
+		if !v.isLiteral {
+			vars = append(vars, v.operand)
+		}
+	}
+	visit = func(ns []node) {
+		for _, n := range ns {
+			switch n := n.(type) {
+			case *outputNode:
+				addValue(n.value)
+			case *conditionalNode:
+				addValue(n.cond.left)
+				if n.cond.op != "" {
+					addValue(n.cond.right)
+				}
+				visit(n.then)
+				visit(n.otherwise)
+			}
+		}
+	}
+	visit(e.nodes)
+	return vars
+}
+
+func (e *Expression) Evaluate(s *Scope) (string, error) {
+	var b strings.Builder
+	if err := evalNodes(e.nodes, s, &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func evalNodes(ns []node, s *Scope, b *strings.Builder) error {
+	for _, n := range ns {
+		if err := n.eval(s, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n textNode) eval(s *Scope, b *strings.Builder) error {
+	b.WriteString(string(n))
+	return nil
+}
+
+func (n *outputNode) eval(s *Scope, b *strings.Builder) error {
+	v, err := n.value.eval(s)
+	if err != nil {
+		return err
+	}
+	b.WriteString(v.String())
+	return nil
+}
+
+func (n *conditionalNode) eval(s *Scope, b *strings.Builder) error {
+	left, err := n.cond.left.eval(s)
+	if err != nil {
+		return err
+	}
+	var result bool
+	switch n.cond.op {
+	case "":
+		result = left.String() != "" && left.String() != "false"
+	case "==", "!=":
+		right, err := n.cond.right.eval(s)
+		if err != nil {
+			return err
+		}
+		result = (left.String() == right.String()) == (n.cond.op == "==")
+	}
+	if result {
+		return evalNodes(n.then, s, b)
+	}
+	return evalNodes(n.otherwise, s, b)
+}
+
+func (e valueExpr) eval(s *Scope) (value, error) {
+	var v value
+	param, isParam := s.Params[e.operand]
+	switch {
+	case e.isLiteral:
+		v.text = e.operand
+	case isParam:
+		// parameters take precedence over built-ins with the same name
+		v.text = param
+	case e.operand == builtinNow:
+		now := s.Now
+		v.time = &now
+	case e.operand == builtinCopy:
+		v.text = strconv.Itoa(s.Copy)
+	case e.operand == builtinCopies:
+		v.text = strconv.Itoa(s.Copies)
+	default:
+		return value{}, fmt.Errorf("Unknown parameter %v", e.operand)
+	}
+
+	for _, f := range e.filters {
+		var err error
+		if v, err = f.apply(v); err != nil {
+			return value{}, fmt.Errorf("Couldn't apply %v filter to %v:\n%w", f.name, e.operand, err)
+		}
+	}
+	return v, nil
+}
+
+func (f filterCall) apply(v value) (value, error) {
+	switch f.name {
+	case "upper":
+		return value{text: strings.ToUpper(v.String())}, nil
+	case "lower":
+		return value{text: strings.ToLower(v.String())}, nil
+	case "trim":
+		return value{text: strings.TrimSpace(v.String())}, nil
+	case "truncate":
+		n, _ := strconv.Atoi(f.args[0])
+		runes := []rune(v.String())
+		if len(runes) > n {
+			runes = runes[:n]
+		}
+		return value{text: string(runes)}, nil
+	case "pad":
+		n, _ := strconv.Atoi(f.args[0])
+		padding := " "
+		if len(f.args) > 1 {
+			padding = f.args[1]
+		}
+		text := v.String()
+		if count := n - utf8.RuneCountInString(text); count > 0 {
+			text = strings.Repeat(padding, count) + text
+		}
+		return value{text: text}, nil
+	case "date":
+		t := v.time
+		if t == nil {
+			parsed, err := time.Parse(dateInputLayout, v.text)
+			if err != nil {
+				return value{}, fmt.Errorf("%q isn't a date written as YYYY-MM-DD", v.text)
+			}
+			t = &parsed
+		}
+		return value{text: formatDate(*t, f.args[0])}, nil
+	case "default":
+		if v.String() == "" {
+			return value{text: f.args[0]}, nil
+		}
+		return v, nil
+	}
+	return value{}, fmt.Errorf("Unknown filter %v", f.name)
+}
+
+func parseCondition(s string) (condition, error) {
+	var c condition
+	t := &tokenizer{s: s}
+	var err error
+	if c.left, err = t.parseValue(); err != nil {
+		return c, err
+	}
+	if op := t.peek(); op == "==" || op == "!=" {
+		t.next()
+		c.op = op
+		if c.right, err = t.parseValue(); err != nil {
+			return c, err
+		}
+	}
+	if tok := t.peek(); tok != "" {
+		return c, fmt.Errorf("Unexpected %q", tok)
+	}
+	return c, nil
+}
+
+func parseValue(s string) (valueExpr, error) {
+	t := &tokenizer{s: s}
+	v, err := t.parseValue()
+	if err != nil {
+		return v, err
+	}
+	if tok := t.peek(); tok != "" {
+		return v, fmt.Errorf("Unexpected %q", tok)
+	}
+	return v, nil
+}
+
+// Splits the inside of a tag into names, quoted strings, numbers and symbols
+type tokenizer struct {
+	s   string
+	pos int
+}
+
+func (t *tokenizer) parseValue() (valueExpr, error) {
+	var v valueExpr
+	var err error
+	if v.operand, v.isLiteral, err = t.operand(); err != nil {
+		return v, err
+	}
+	for t.peek() == "|" {
+		t.next()
+		name := t.next()
+		if !isName(name) {
+			return v, fmt.Errorf("Expected a filter name after |")
+		}
+		f := filterCall{name: name}
+		for t.peek() == ":" {
+			t.next()
+			arg, _, err := t.operand()
+			if err != nil {
+				return v, err
+			}
+			f.args = append(f.args, arg)
+		}
+		if err := f.check(); err != nil {
+			return v, err
+		}
+		v.filters = append(v.filters, f)
+	}
+	return v, nil
+}
+
+func (f filterCall) check() error {
+	counts, ok := filterArgs[f.name]
+	if !ok {
+		return fmt.Errorf("Unknown filter %v", f.name)
+	}
+	if len(f.args) < counts[0] || len(f.args) > counts[1] {
+		return fmt.Errorf("Wrong number of arguments for %v filter", f.name)
+	}
+	if f.name == "truncate" || f.name == "pad" {
+		if n, err := strconv.Atoi(f.args[0]); err != nil || n < 0 {
+			return fmt.Errorf("%v filter needs a number of characters", f.name)
+		}
+	}
+	if f.name == "pad" && len(f.args) > 1 && utf8.RuneCountInString(f.args[1]) != 1 {
+		return fmt.Errorf("pad filter can only pad with a single character")
+	}
+	return nil
+}
+
+// Reads a name, or a literal string or number
+func (t *tokenizer) operand() (string, bool, error) {
+	tok := t.next()
+	switch {
+	case tok == "":
+		return "", false, fmt.Errorf("Expected a value")
+	case tok[0] == '"':
+		s, err := strconv.Unquote(tok)
+		if err != nil {
+			return "", false, fmt.Errorf("Invalid string %s", tok)
+		}
+		return s, true, nil
+	case tok[0] >= '0' && tok[0] <= '9':
+		return tok, true, nil
+	case isName(tok):
+		return tok, false, nil
+	}
+	return "", false, fmt.Errorf("Unexpected %q", tok)
+}
+
+func (t *tokenizer) peek() string {
+	pos := t.pos
+	tok := t.next()
+	t.pos = pos
+	return tok
+}
+
+func (t *tokenizer) next() string {
+	for t.pos < len(t.s) && t.s[t.pos] == ' ' {
+		t.pos++
+	}
+	if t.pos >= len(t.s) {
+		return ""
+	}
+	start := t.pos
+	switch {
+	case t.s[t.pos] == '"':
+		for t.pos++; t.pos < len(t.s) && t.s[t.pos] != '"'; t.pos++ {
+			if t.s[t.pos] == '\\' {
+				t.pos++
+			}
+		}
+		t.pos = min(t.pos+1, len(t.s))
+	case strings.HasPrefix(t.s[t.pos:], "==") || strings.HasPrefix(t.s[t.pos:], "!="):
+		t.pos += 2
+	default:
+		r, size := utf8.DecodeRuneInString(t.s[t.pos:])
+		t.pos += size
+		if isNameChar(r) {
+			for t.pos < len(t.s) {
+				r, size := utf8.DecodeRuneInString(t.s[t.pos:])
+				if !isNameChar(r) {
+					break
+				}
+				t.pos += size
+			}
+		}
+	}
+	return t.s[start:t.pos]
+}
+
+func isName(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return s != "" && (unicode.IsLetter(r) || r == '_') && strings.IndexFunc(s, func(c rune) bool { return !isNameChar(c) }) == -1
+}
+
+func isNameChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-' || c == '.'
+}
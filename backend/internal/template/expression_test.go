@@ -0,0 +1,102 @@
+package template
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateExpressions(t *testing.T) {
+	scope := &Scope{
+		Params: map[string]string{"name": "Widget", "size": "L", "notes": "", "fragile": "false", "made": "2025-03-07"},
+		Now:    time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC),
+		Copy:   2,
+		Copies: 5,
+	}
+	tests := []struct{ expr, expected string }{
+		{"Name: {name}", "Name: Widget"},
+		{"{name | upper}", "WIDGET"},
+		{"{ name | lower | truncate:3 }", "wid"},
+		{"{size | pad:3:\"0\"}", "00L"},
+		{"{size | pad:3}", "  L"},
+		{"{notes | default:\"none\"}", "none"},
+		{"{made | date:\"DD/MM/YYYY\"}", "07/03/2025"},
+		{"{now | date:\"D MMM YY HH:mm\"}", "1 Jun 25 09:30"},
+		{"{now}", "2025-06-01 09:30"},
+		{"{now | date:\"Week 1, 2 Jan pm _2: D/M\"}", "Week 1, 2 Jan pm _2: 1/6"},
+		{"{copy} of {copies}", "2 of 5"},
+		{"{if notes}Notes{else}No notes{end}", "No notes"},
+		{"{if fragile}FRAGILE{end}", ""},
+		{"{if size == \"L\"}Large{end}{if size != \"L\"}Small{end}", "Large"},
+		{"{if name}{if size == \"S\"}small {else}big {end}{name}{end}", "big Widget"},
+		{"{{name}} costs {\"£5 {each}\"}", "{name} costs £5 {each}"},
+		{"a}b", "a}b"},
+	}
+	for _, test := range tests {
+		e, err := ParseExpression(test.expr)
+		if err != nil {
+			t.Errorf("Couldn't parse %q: %v", test.expr, err)
+			continue
+		}
+		result, err := e.Evaluate(scope)
+		if err != nil {
+			t.Errorf("Couldn't evaluate %q: %v", test.expr, err)
+		} else if result != test.expected {
+			t.Errorf("Expected %q to give %q, got %q", test.expr, test.expected, result)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	invalid := []string{
+		"{name",
+		"{}",
+		"{name | shout}",
+		"{name | truncate}",
+		"{name | truncate:\"x\"}",
+		"{name | pad:3:\"ab\"}",
+		"{if name}unfinished",
+		"{else}",
+		"{end}",
+		"{if name}{else}{else}{end}",
+		"{name size}",
+		"{\"unterminated}",
+	}
+	for _, s := range invalid {
+		if _, err := ParseExpression(s); err == nil {
+			t.Errorf("Expected %q not to parse", s)
+		}
+	}
+}
+
+func TestValidateExpressionsChecksNames(t *testing.T) {
+	tmpl := &Template{
+		Parameters: []Parameter{{Name: "sku"}},
+		Texts:      []Text{{Text: "{sku} {copy}/{copies} {now | date:\"YYYY\"}"}},
+		Barcodes:   []Barcode{{Data: "{sku}"}},
+	}
+	if err := tmpl.ValidateExpressions(); err != nil {
+		t.Errorf("Expected template to be valid: %v", err)
+	}
+	if !tmpl.UsesCopyIndex() {
+		t.Errorf("Expected template to use the copy index")
+	}
+
+	tmpl.Barcodes[0].Data = "{skus}"
+	if err := tmpl.ValidateExpressions(); err == nil {
+		t.Errorf("Expected unknown parameter to be reported")
+	}
+}
+
+func TestContentFromBeforeExpressionsIsStillFilledIn(t *testing.T) {
+	tmpl := &Template{
+		Parameters: []Parameter{{Name: "First name", Type: StringParameter}},
+		Texts:      []Text{{Text: "Hello {First name} :-{"}},
+		Barcodes:   []Barcode{{Data: "{First name}"}},
+	}
+	if err := insertParamsIntoTemplateChildren(tmpl, map[string]string{"First name": "Ada"}, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Texts[0].FilledText != "Hello Ada :-{" || tmpl.Barcodes[0].FilledData != "Ada" {
+		t.Errorf("Expected parameters to be filled in as they used to be, got %q and %q", tmpl.Texts[0].FilledText, tmpl.Barcodes[0].FilledData)
+	}
+}
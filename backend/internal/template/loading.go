@@ -3,6 +3,7 @@ package template
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"image"
 	_ "image/jpeg"
 	_ "image/png"
@@ -11,7 +12,9 @@ import (
 	"golang.org/x/image/font/gofont/gomono"
 	"golang.org/x/image/font/gofont/goregular"
 
+	"github.com/google/uuid"
 	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
 )
 
 func loadImagesForTemplate(t *Template) error {
@@ -37,6 +40,49 @@ func loadFontsForTemplate(t *Template) error {
 	return nil
 }
 
+// Checks that an uploaded TrueType or OpenType file can be used to draw text,
+// and reads its family and style. If no name is given, the font is named
+// after the full name in the file.
+func NewFont(name string, data []byte) (*Font, error) {
+	parsedFont, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't parse font file:\n%w", err)
+	}
+	face, err := newFace(parsedFont, 12)
+	if err != nil {
+		return nil, err
+	}
+	face.Close()
+
+	f := &Font{
+		Uuid:     uuid.New(),
+		Name:     name,
+		Family:   fontName(parsedFont, sfnt.NameIDFamily),
+		Style:    fontName(parsedFont, sfnt.NameIDSubfamily),
+		FontData: data,
+	}
+	if f.Name == "" {
+		f.Name = fontName(parsedFont, sfnt.NameIDFull)
+	}
+	if f.Name == "" {
+		f.Name = strings.TrimSpace(f.Family + " " + f.Style)
+	}
+	if f.Name == "" {
+		return nil, fmt.Errorf("Font file has no name, so one has to be given")
+	}
+	return f, nil
+}
+
+// Reads one of the names in a font file, or returns an empty string if the
+// file doesn't have it
+func fontName(f *opentype.Font, id sfnt.NameID) string {
+	name, err := f.Name(nil, id)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
 func getFontData(f *Font) ([]byte, error) {
 	if len(f.BuiltinName) > 0 {
 		switch f.BuiltinName {
@@ -53,6 +99,14 @@ func getFontData(f *Font) ([]byte, error) {
 }
 
 func loadFont(f *Font, size int) (font.Face, error) {
+	parsedFont, err := parseFont(f)
+	if err != nil {
+		return nil, err
+	}
+	return newFace(parsedFont, size)
+}
+
+func parseFont(f *Font) (*opentype.Font, error) {
 	fontData, err := getFontData(f)
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't get font data:\n%w", err)
@@ -61,8 +115,11 @@ func loadFont(f *Font, size int) (font.Face, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't parse font %s (%s):\n%w", f.Name, f.Uuid.String(), err)
 	}
+	return parsedFont, nil
+}
 
-	fontFace, err := opentype.NewFace(parsedFont, &opentype.FaceOptions{
+func newFace(f *opentype.Font, size int) (font.Face, error) {
+	fontFace, err := opentype.NewFace(f, &opentype.FaceOptions{
 		Size:    float64(size),
 		DPI:     72,
 		Hinting: font.HintingFull,
@@ -73,3 +130,32 @@ func loadFont(f *Font, size int) (font.Face, error) {
 
 	return fontFace, nil
 }
+
+// Shrinks the font of any text set to auto-fit, a point at a time, until the
+// text fits in its box or the font can't get any smaller. Text which still
+// doesn't fit fails the bounds check as usual.
+func fitTextsForTemplate(t *Template) error {
+	for i := 0; i < len(t.Texts); i++ {
+		text := &t.Texts[i]
+		if !text.AutoFit || (text.Width == 0 && text.Height == 0) {
+			continue
+		}
+		if layoutText(text, text.FontFace).fits(text) {
+			continue
+		}
+		parsedFont, err := parseFont(&text.Font)
+		if err != nil {
+			return fmt.Errorf("Couldn't load font for template text at index %v:\n%w", i, err)
+		}
+		minSize := max(text.MinFontSize, 1)
+		for size := text.FontSize - 1; size >= minSize; size-- {
+			if text.FontFace, err = newFace(parsedFont, size); err != nil {
+				return err
+			}
+			if layoutText(text, text.FontFace).fits(text) {
+				break
+			}
+		}
+	}
+	return nil
+}
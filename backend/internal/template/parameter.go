@@ -0,0 +1,263 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type ParameterType string
+
+const (
+	StringParameter  ParameterType = "STRING"
+	IntegerParameter ParameterType = "INTEGER"
+	DecimalParameter ParameterType = "DECIMAL"
+	// Given as YYYY-MM-DD, and printed using the parameter's date layout
+	DateParameter    ParameterType = "DATE"
+	// One of a fixed list of choices
+	EnumParameter    ParameterType = "ENUM"
+	// Given as true/false, yes/no or 1/0, and printed as true or false
+	BooleanParameter ParameterType = "BOOLEAN"
+)
+
+// How dates are given in parameter values and min/max bounds
+const dateInputLayout = "2006-01-02"
+
+type Parameter struct {
+	Id        int `json:"-"`
+	Name      string
+	Type      ParameterType
+	// Maximum number of characters in the value as given, or 0 for no limit
+	MaxLength int
+	// Regular expression the whole value has to match, or empty for any value
+	Pattern   string
+	// Inclusive bounds for integers, decimals and dates, written as values of
+	// the parameter's type. Empty for no bound.
+	Min, Max  string
+	// Optional parameters with no value given use Default instead
+	Required  bool
+	Default   string
+	// The values an enum parameter can take
+	Choices   []string
+	// Integers are padded with zeroes to at least this many digits
+	Padding   int
+	// How dates are printed, e.g. "DD/MM/YYYY". See dateLayoutTokens for the tokens
+	// which can be used. Dates are printed as they're given if empty.
+	DateLayout string
+}
+
+// A problem with the value given for one of a template's parameters
+type ParameterError struct {
+	Parameter string
+	Message   string
+}
+
+func (e *ParameterError) Error() string {
+	return fmt.Sprintf("Parameter %v: %v", e.Parameter, e.Message)
+}
+
+// Checks that a parameter's rules make sense, so mistakes are reported when
+// the template is saved rather than when it's printed.
+func (p *Parameter) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("Parameter has no name")
+	}
+	// so it can be referred to in expressions
+	if !isName(p.Name) {
+		return fmt.Errorf("Parameter name %q has to start with a letter or _, and can only contain letters, digits, _, - and .", p.Name)
+	}
+	switch p.Type {
+	case StringParameter, IntegerParameter, DecimalParameter, DateParameter, EnumParameter, BooleanParameter:
+	default:
+		return fmt.Errorf(`Parameter %v has unrecognised type "%s"`, p.Name, p.Type)
+	}
+	if p.MaxLength < 0 || p.Padding < 0 {
+		return fmt.Errorf("Parameter %v can't have a negative max length or padding", p.Name)
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("Parameter %v has invalid pattern:\n%w", p.Name, err)
+		}
+	}
+	if p.Type == EnumParameter && len(p.Choices) == 0 {
+		return fmt.Errorf("Enum parameter %v has no choices", p.Name)
+	}
+
+	for _, bound := range []string{p.Min, p.Max} {
+		if bound == "" {
+			continue
+		}
+		if p.Type != IntegerParameter && p.Type != DecimalParameter && p.Type != DateParameter {
+			return fmt.Errorf("Parameter %v can only have a min or max if it's a number or date", p.Name)
+		}
+		if _, err := p.parseOrdered(bound); err != nil {
+			return fmt.Errorf("Parameter %v has invalid min or max %q", p.Name, bound)
+		}
+	}
+	if !p.Required && p.Default != "" {
+		if _, err := p.resolve(p.Default); err != nil {
+			return fmt.Errorf("Default value for parameter %v is invalid:\n%w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// Checks a value given for the parameter and formats it ready to be inserted
+// into the template, or says why the value isn't allowed.
+func (p *Parameter) Resolve(value string, given bool) (string, *ParameterError) {
+	if !given || value == "" {
+		if p.Required {
+			return "", &ParameterError{p.Name, "No value given"}
+		}
+		if p.Default == "" {
+			return "", nil
+		}
+		value = p.Default
+	}
+	formatted, err := p.resolve(value)
+	if err != nil {
+		return "", &ParameterError{p.Name, err.Error()}
+	}
+	return formatted, nil
+}
+
+func (p *Parameter) resolve(value string) (string, error) {
+	if p.MaxLength > 0 && utf8.RuneCountInString(value) > p.MaxLength {
+		return "", fmt.Errorf("Longer than %v characters", p.MaxLength)
+	}
+	if p.Pattern != "" {
+		// anchored, so the pattern has to match the whole value
+		if matched, _ := regexp.MatchString(`^(?:`+p.Pattern+`)$`, value); !matched {
+			return "", fmt.Errorf("Doesn't match pattern %v", p.Pattern)
+		}
+	}
+
+	switch p.Type {
+	case EnumParameter:
+		for _, c := range p.Choices {
+			if value == c {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("Must be one of %v", strings.Join(p.Choices, ", "))
+	case BooleanParameter:
+		switch strings.ToLower(value) {
+		case "true", "yes", "1":
+			return "true", nil
+		case "false", "no", "0":
+			return "false", nil
+		}
+		return "", fmt.Errorf("Must be true or false")
+	case IntegerParameter, DecimalParameter, DateParameter:
+		v, err := p.parseOrdered(value)
+		if err != nil {
+			return "", err
+		}
+		if err := p.checkBounds(v); err != nil {
+			return "", err
+		}
+		return p.format(value, v), nil
+	}
+	return value, nil
+}
+
+// Parses a value of a parameter whose values can be compared with min and max
+func (p *Parameter) parseOrdered(value string) (float64, error) {
+	switch p.Type {
+	case IntegerParameter:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Must be a whole number")
+		}
+		return float64(n), nil
+	case DecimalParameter:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Must be a number")
+		}
+		return n, nil
+	case DateParameter:
+		d, err := time.Parse(dateInputLayout, value)
+		if err != nil {
+			return 0, fmt.Errorf("Must be a date written as YYYY-MM-DD")
+		}
+		return float64(d.Unix()), nil
+	}
+	return 0, fmt.Errorf("Parameter type %v can't be compared", p.Type)
+}
+
+func (p *Parameter) checkBounds(v float64) error {
+	// the bounds were checked when the template was saved
+	if p.Min != "" {
+		if min, _ := p.parseOrdered(p.Min); v < min {
+			return fmt.Errorf("Must be at least %v", p.Min)
+		}
+	}
+	if p.Max != "" {
+		if max, _ := p.parseOrdered(p.Max); v > max {
+			return fmt.Errorf("Must be at most %v", p.Max)
+		}
+	}
+	return nil
+}
+
+func (p *Parameter) format(value string, v float64) string {
+	switch p.Type {
+	case IntegerParameter:
+		n, _ := strconv.ParseInt(value, 10, 64)
+		if n < 0 {
+			// as a uint64, since -n overflows for the smallest int64
+			return fmt.Sprintf("-%0*d", p.Padding, uint64(-(n+1))+1)
+		}
+		return fmt.Sprintf("%0*d", p.Padding, n)
+	case DateParameter:
+		if p.DateLayout != "" {
+			return formatDate(time.Unix(int64(v), 0).UTC(), p.DateLayout)
+		}
+	}
+	return value
+}
+
+// Tokens which can be used in date layouts, longest first so that e.g. YYYY
+// isn't read as two YYs
+var dateLayoutTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"},
+	{"MMMM", "January"},
+	{"dddd", "Monday"},
+	{"MMM", "Jan"},
+	{"ddd", "Mon"},
+	{"YY", "06"},
+	{"MM", "01"},
+	{"DD", "02"},
+	{"HH", "15"},
+	{"mm", "04"},
+	{"ss", "05"},
+	{"M", "1"},
+	{"D", "2"},
+}
+
+// Formats a time with a layout written with tokens like DD/MM/YYYY. Each token
+// is formatted on its own, so that anything which isn't a token is printed as
+// it is rather than being taken as part of a Go time layout.
+func formatDate(t time.Time, layout string) string {
+	var b strings.Builder
+	for len(layout) > 0 {
+		matched := false
+		for _, token := range dateLayoutTokens {
+			if strings.HasPrefix(layout, token.token) {
+				b.WriteString(t.Format(token.layout))
+				layout = layout[len(token.token):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(layout[0])
+			layout = layout[1:]
+		}
+	}
+	return b.String()
+}
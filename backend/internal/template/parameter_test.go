@@ -0,0 +1,104 @@
+package template
+
+import "testing"
+
+func TestResolveParamsReportsEveryProblem(t *testing.T) {
+	tmpl := &Template{
+		Parameters: []Parameter{
+			{Name: "sku", Type: StringParameter, MaxLength: 4, Required: true},
+			{Name: "name", Type: StringParameter, Required: true},
+			{Name: "price", Type: DecimalParameter, MaxLength: 6, Required: true},
+		},
+	}
+
+	_, errs := ResolveParams(tmpl, map[string]string{"sku": "12345", "price": "£12.50"})
+	if len(errs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", errs)
+	}
+	expected := []string{
+		"Parameter sku: Longer than 4 characters",
+		"Parameter name: No value given",
+		"Parameter price: Must be a number",
+	}
+	for i, e := range expected {
+		if errs[i].Error() != e {
+			t.Errorf("Expected error %q, got %q", e, errs[i])
+		}
+	}
+}
+
+func TestResolveParamsFormatsValues(t *testing.T) {
+	tmpl := &Template{
+		Parameters: []Parameter{
+			{Name: "code", Type: IntegerParameter, Padding: 5, Required: true},
+			{Name: "date", Type: DateParameter, DateLayout: "DD MMM YYYY", Required: true},
+			{Name: "fragile", Type: BooleanParameter, Required: true},
+			{Name: "size", Type: EnumParameter, Choices: []string{"S", "M", "L"}, Default: "M"},
+			{Name: "notes", Type: StringParameter},
+			{Name: "below", Type: IntegerParameter, Padding: 3},
+			{Name: "lowest", Type: IntegerParameter, Padding: 3},
+			{Name: "week", Type: DateParameter, DateLayout: "Week 1 of YYYY, printed 2 Jan: DD/MM _2 pm"},
+		},
+	}
+
+	values, errs := ResolveParams(tmpl, map[string]string{
+		"code": "42", "date": "2025-03-07", "fragile": "yes", "notes": "",
+		"below": "-7", "lowest": "-9223372036854775808", "week": "2025-03-07",
+	})
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	expected := map[string]string{
+		"code": "00042", "date": "07 Mar 2025", "fragile": "true", "size": "M", "notes": "",
+		"below": "-007", "lowest": "-9223372036854775808", "week": "Week 1 of 2025, printed 2 Jan: 07/03 _2 pm",
+	}
+	for name, v := range expected {
+		if values[name] != v {
+			t.Errorf("Expected %v to be %q, was %q", name, v, values[name])
+		}
+	}
+}
+
+func TestResolveParamsChecksRules(t *testing.T) {
+	tests := []struct {
+		parameter Parameter
+		value     string
+		message   string
+	}{
+		{Parameter{Type: IntegerParameter, Min: "1", Max: "10"}, "0", "Must be at least 1"},
+		{Parameter{Type: IntegerParameter, Min: "1", Max: "10"}, "11", "Must be at most 10"},
+		{Parameter{Type: IntegerParameter}, "1.5", "Must be a whole number"},
+		{Parameter{Type: DateParameter, Max: "2025-12-31"}, "2026-01-01", "Must be at most 2025-12-31"},
+		{Parameter{Type: DateParameter}, "07/03/2025", "Must be a date written as YYYY-MM-DD"},
+		{Parameter{Type: EnumParameter, Choices: []string{"S", "M"}}, "XL", "Must be one of S, M"},
+		{Parameter{Type: BooleanParameter}, "maybe", "Must be true or false"},
+		{Parameter{Type: StringParameter, Pattern: "[A-Z]{3}"}, "ABCD", "Doesn't match pattern [A-Z]{3}"},
+	}
+	for _, test := range tests {
+		test.parameter.Name = "p"
+		_, err := test.parameter.Resolve(test.value, true)
+		if err == nil {
+			t.Errorf("Expected %q to be rejected", test.value)
+		} else if err.Message != test.message {
+			t.Errorf("Expected %q for %q, got %q", test.message, test.value, err.Message)
+		}
+	}
+}
+
+func TestParameterValidation(t *testing.T) {
+	invalid := []Parameter{
+		{Name: "p", Type: "COLOUR"},
+		{Name: "First name", Type: StringParameter},
+		{Name: "1st", Type: StringParameter},
+		{Name: "p", Type: StringParameter, Pattern: "[A-Z"},
+		{Name: "p", Type: StringParameter, Min: "1"},
+		{Name: "p", Type: IntegerParameter, Max: "ten"},
+		{Name: "p", Type: EnumParameter},
+		{Name: "p", Type: IntegerParameter, Min: "1", Default: "0"},
+	}
+	for _, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", p)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"golang.org/x/image/draw"
 	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
 )
 
 type Measure struct {
@@ -48,28 +49,80 @@ func wrapText(text string, maxWidth int, face font.Face) []string {
 	return lines
 }
 
-func measureAndDrawChildText(text *Text, i *image.RGBA64) Measure {
-	wrappedText := wrapText(text.FilledText, text.Width, text.FontFace)
-	var width, height int
-	for _, line := range wrappedText {
-		lineWidth := font.MeasureString(text.FontFace, line).Ceil()
-		if lineWidth > width {
-			width = lineWidth
+// How a text's lines are laid out, before it's positioned in its box
+type textLayout struct {
+	lines      []string
+	// the width of each line, and of the widest one
+	widths     []int
+	width      int
+	lineHeight fixed.Int26_6
+	height     int
+}
+
+func layoutText(text *Text, face font.Face) textLayout {
+	lines := wrapText(text.FilledText, text.Width, face)
+	if text.MaxLines > 0 && len(lines) > text.MaxLines {
+		lines = lines[:text.MaxLines]
+		lines[len(lines)-1] = addEllipsis(lines[len(lines)-1], text.Width, face)
+	}
+
+	l := textLayout{
+		lines:      lines,
+		widths:     make([]int, len(lines)),
+		lineHeight: face.Metrics().Height,
+	}
+	if text.LineHeight > 0 {
+		l.lineHeight = fixed.Int26_6(float64(l.lineHeight) * text.LineHeight)
+	}
+	for i, line := range lines {
+		l.widths[i] = font.MeasureString(face, line).Ceil()
+		if l.widths[i] > l.width {
+			l.width = l.widths[i]
 		}
-		height += text.FontFace.Metrics().Height.Ceil()
 	}
+	l.height = (l.lineHeight * fixed.Int26_6(len(lines))).Ceil()
+	return l
+}
+
+// Whether the text fits in its box, if it has one
+func (l textLayout) fits(text *Text) bool {
+	return (text.Width == 0 || l.width <= text.Width) && (text.Height == 0 || l.height <= text.Height)
+}
+
+// Marks a line as cut short, taking characters off the end if the ellipsis
+// wouldn't fit otherwise
+func addEllipsis(line string, maxWidth int, face font.Face) string {
+	ellipsis := "…"
+	if _, ok := face.GlyphAdvance('…'); !ok {
+		ellipsis = "..."
+	}
+	runes := []rune(line)
+	for len(runes) > 0 && maxWidth > 0 && font.MeasureString(face, string(runes)+ellipsis).Ceil() > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ") + ellipsis
+}
+
+func measureAndDrawChildText(text *Text, i *image.RGBA64) Measure {
+	l := layoutText(text, text.FontFace)
 
 	m := Measure{
 		X:      text.X,
 		Y:      text.Y,
-		Width:  width,
-		Height: height,
+		Width:  l.width,
+		Height: l.height,
 	}
-
-	if height > text.Height && text.Height > 0 {
+	if text.Height > 0 && l.height > text.Height {
 		m.OutOfBounds = true
 		return m
 	}
+	// aligned text takes up the whole of its box
+	if text.Width > 0 && text.Align != AlignLeft && text.Align != "" {
+		m.Width = text.Width
+	}
+	if text.Height > 0 && text.VerticalAlign != AlignTop && text.VerticalAlign != "" {
+		m.Height = text.Height
+	}
 
   if i != nil {
     d := &font.Drawer{
@@ -77,17 +130,57 @@ func measureAndDrawChildText(text *Text, i *image.RGBA64) Measure {
       Src:  image.NewUniform(color.Black),
       Face: text.FontFace,
     }
-    d.Dot = fixed.Point26_6{X: fixed.I(m.X), Y: fixed.I(m.Y)}
-    for _, line := range wrappedText {
-      d.Dot.X = fixed.I(m.X)
-      d.Dot.Y += text.FontFace.Metrics().Ascent
+    boxWidth := l.width
+    if text.Width > 0 {
+      boxWidth = text.Width
+    }
+    top := m.Y
+    switch text.VerticalAlign {
+    case AlignMiddle:
+      top += (m.Height - l.height) / 2
+    case AlignBottom:
+      top += m.Height - l.height
+    }
+    for n, line := range l.lines {
+      d.Dot.Y = fixed.I(top) + text.FontFace.Metrics().Ascent + l.lineHeight * fixed.Int26_6(n)
+      x := m.X
+      switch text.Align {
+      case AlignCentre:
+        x += (boxWidth - l.widths[n]) / 2
+      case AlignRight:
+        x += boxWidth - l.widths[n]
+      case AlignJustify:
+        if n < len(l.lines) - 1 && text.Width > 0 {
+          drawJustified(d, line, x, boxWidth)
+          continue
+        }
+      }
+      d.Dot.X = fixed.I(x)
       d.DrawString(line)
-      d.Dot.Y += text.FontFace.Metrics().Descent
     }
   }
 	return m
 }
 
+// Draws a line with the space between words stretched so it fills the width
+func drawJustified(d *font.Drawer, line string, x int, width int) {
+	words := strings.Fields(line)
+	d.Dot.X = fixed.I(x)
+	if len(words) < 2 {
+		d.DrawString(line)
+		return
+	}
+	var wordsWidth fixed.Int26_6
+	for _, word := range words {
+		wordsWidth += d.MeasureString(word)
+	}
+	gap := (fixed.I(width) - wordsWidth) / fixed.Int26_6(len(words) - 1)
+	for _, word := range words {
+		d.DrawString(word)
+		d.Dot.X += gap
+	}
+}
+
 func measureAndDrawChildImage(img *Image, i *image.RGBA64) Measure {
 	m := Measure{
 		X:      img.X,
@@ -96,15 +189,84 @@ func measureAndDrawChildImage(img *Image, i *image.RGBA64) Measure {
 		Height: img.Height,
 	}
   if i != nil {
+    // The image is dithered on its own with its own options, rather than
+    // along with the rest of the template, so e.g. a logo can be thresholded
+    // while a photo is diffused. Once it's black and white, dithering the
+    // whole template afterward leaves it unchanged.
+    scaled := image.NewRGBA(image.Rect(0, 0, m.Width, m.Height))
+    draw.Draw(scaled, scaled.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+    draw.CatmullRom.Scale(scaled, scaled.Bounds(), img.LoadedImage, img.LoadedImage.Bounds(), draw.Over, nil)
+    dithered := bitmap.Dither(scaled, img.Dither)
     bounds := image.Rect(m.X, m.Y, m.X+m.Width, m.Y+m.Height)
-    draw.CatmullRom.Scale(i, bounds, img.LoadedImage, img.LoadedImage.Bounds(), draw.Over, nil)
+    draw.Draw(i, bounds, dithered, image.Point{}, draw.Over)
   }
   return m
 }
 
+func measureAndDrawChildBarcode(b *Barcode, i *image.RGBA64) Measure {
+	m := Measure{
+		X:      b.X,
+		Y:      b.Y,
+		Width:  b.Encoded.Bounds().Dx() * b.ModuleWidth,
+		Height: b.Height,
+	}
+	var textWidth int
+	if b.ShowText {
+		textWidth = font.MeasureString(b.TextFace, b.HumanReadableText).Ceil()
+		if textWidth > m.Width {
+			m.Width = textWidth
+		}
+		m.Height += barcodeTextGap + b.TextFace.Metrics().Height.Ceil()
+	}
+
+	if i != nil {
+		drawModules(b.Encoded, i, b.X, b.Y, b.ModuleWidth, b.Height)
+		if b.ShowText {
+			d := &font.Drawer{
+				Dst:  i,
+				Src:  image.NewUniform(color.Black),
+				Face: b.TextFace,
+			}
+			// centre the text under the bars
+			textX := b.X + (b.Encoded.Bounds().Dx() * b.ModuleWidth - textWidth) / 2
+			if textX < b.X {
+				textX = b.X
+			}
+			d.Dot = fixed.Point26_6{
+				X: fixed.I(textX),
+				Y: fixed.I(b.Y + b.Height + barcodeTextGap) + b.TextFace.Metrics().Ascent,
+			}
+			d.DrawString(b.HumanReadableText)
+		}
+	}
+	return m
+}
+
+// Matrix codes are drawn with each module as an exact square of pixels, and
+// the quiet zone is cleared to white so nothing else drawn nearby can stop
+// the code from scanning.
+func measureAndDrawChildMatrixCode(c *MatrixCode, i *image.RGBA64) Measure {
+	codeBounds := c.Encoded.Bounds()
+	m := Measure{
+		X:      c.X,
+		Y:      c.Y,
+		Width:  (codeBounds.Dx() + 2 * c.QuietZone) * c.ModuleSize,
+		Height: (codeBounds.Dy() + 2 * c.QuietZone) * c.ModuleSize,
+	}
+
+	if i != nil {
+		bounds := image.Rect(m.X, m.Y, m.X+m.Width, m.Y+m.Height)
+		draw.Draw(i, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+		offset := c.QuietZone * c.ModuleSize
+		drawModules(c.Encoded, i, m.X + offset, m.Y + offset, c.ModuleSize, c.ModuleSize)
+	}
+	return m
+}
+
 // Measure the elements to be drawn for the template and determine the boundaries
-// of the image. If the elements exceed the template bounds then return an error
-func measureAndCheckBounds(t *Template) (int, int, error) {
+// of the image. If the elements exceed the template bounds, or the width of the
+// print head (deviceWidth), then return an error
+func measureAndCheckBounds(t *Template, deviceWidth int) (int, int, error) {
   var width, height int
   if t.Landscape {
     width = t.MinSize
@@ -134,6 +296,33 @@ func measureAndCheckBounds(t *Template) (int, int, error) {
       height = bounds.Y + bounds.Height
     }
   }
+  for _, bc := range t.Barcodes {
+    bounds := measureAndDrawChildBarcode(&bc, nil)
+    if bounds.X + bounds.Width > width {
+      width = bounds.X + bounds.Width
+    }
+    if bounds.Y + bounds.Height > height {
+      height = bounds.Y + bounds.Height
+    }
+  }
+  for _, mc := range t.MatrixCodes {
+    bounds := measureAndDrawChildMatrixCode(&mc, nil)
+    if bounds.X + bounds.Width > width {
+      width = bounds.X + bounds.Width
+    }
+    if bounds.Y + bounds.Height > height {
+      height = bounds.Y + bounds.Height
+    }
+  }
+  for _, s := range t.Shapes {
+    bounds := measureAndDrawChildShape(&s, nil)
+    if bounds.X + bounds.Width > width {
+      width = bounds.X + bounds.Width
+    }
+    if bounds.Y + bounds.Height > height {
+      height = bounds.Y + bounds.Height
+    }
+  }
   if t.Landscape {
     if width > t.MaxSize && t.MaxSize > 0 {
       return 0, 0, fmt.Errorf("Out of width bounds")
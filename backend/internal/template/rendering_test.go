@@ -0,0 +1,101 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"tomgalvin.uk/phogoprint/internal/printer"
+)
+
+func TestTemplateMeasuredAgainstProfileWidth(t *testing.T) {
+	// 95 modules at 5 dots each is too wide for a T02 but fits on an M02S
+	aWideTemplate := func() *Template {
+		return &Template{
+			MinSize: 100,
+			Barcodes: []Barcode{
+				{Symbology: EAN13, Data: "400638133393", X: 10, Y: 10, ModuleWidth: 5, Height: 50},
+			},
+		}
+	}
+
+	if _, err := RenderTemplate(aWideTemplate(), map[string]string{}, printer.DefaultProfile()); err == nil {
+		t.Errorf("Expected template to be too wide for a T02")
+	}
+
+	m02s, _ := printer.ProfileForName("M02S")
+	img, err := RenderTemplate(aWideTemplate(), map[string]string{}, m02s)
+	if err != nil {
+		t.Fatalf("Couldn't render template for M02S: %v", err)
+	}
+	if w := img.Bounds().Dx(); w != 576 {
+		t.Errorf("Expected image to be 576 pixels wide, was %v", w)
+	}
+}
+
+func aTextTemplate(text Text) *Template {
+	text.Font = Font{Name: "Go Regular", BuiltinName: "goregular"}
+	if text.LineHeight == 0 {
+		text.LineHeight = 1
+	}
+	return &Template{MinSize: 50, Texts: []Text{text}}
+}
+
+func TestAutoFitShrinksTextUntilItFits(t *testing.T) {
+	tmpl := aTextTemplate(Text{
+		Text: "A fairly long line of text", FontSize: 40, X: 0, Y: 0,
+		Width: 200, Height: 30, AutoFit: true, MinFontSize: 6,
+	})
+	if _, err := RenderTemplate(tmpl, map[string]string{}, printer.DefaultProfile()); err != nil {
+		t.Fatalf("Expected text to be shrunk to fit, got %v", err)
+	}
+	text := &tmpl.Texts[0]
+	if l := layoutText(text, text.FontFace); !l.fits(text) {
+		t.Errorf("Expected text to fit in 200x30, was %vx%v", l.width, l.height)
+	}
+
+	tmpl = aTextTemplate(Text{
+		Text: "A fairly long line of text", FontSize: 40, X: 0, Y: 0,
+		Width: 200, Height: 30, AutoFit: true, MinFontSize: 30,
+	})
+	if _, err := RenderTemplate(tmpl, map[string]string{}, printer.DefaultProfile()); err == nil {
+		t.Errorf("Expected text not to fit without going below its minimum size")
+	}
+}
+
+func TestMaxLinesCutsTextShortWithEllipsis(t *testing.T) {
+	tmpl := aTextTemplate(Text{
+		Text: "one two three four five six seven eight nine ten", FontSize: 16,
+		Width: 80, MaxLines: 2,
+	})
+	if err := loadFontsForTemplate(tmpl); err != nil {
+		t.Fatal(err)
+	}
+	text := &tmpl.Texts[0]
+	text.FilledText = text.Text
+	l := layoutText(text, text.FontFace)
+	if len(l.lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", l.lines)
+	}
+	if last := l.lines[1]; !strings.HasSuffix(last, "…") {
+		t.Errorf("Expected last line to end with an ellipsis, was %q", last)
+	}
+	if l.width > text.Width {
+		t.Errorf("Expected lines to fit in %v, widest was %v", text.Width, l.width)
+	}
+}
+
+func TestAlignedTextTakesUpItsBox(t *testing.T) {
+	tmpl := aTextTemplate(Text{
+		Text: "Hi", FontSize: 16, Width: 100, Height: 60,
+		Align: AlignCentre, VerticalAlign: AlignMiddle,
+	})
+	if err := loadFontsForTemplate(tmpl); err != nil {
+		t.Fatal(err)
+	}
+	text := &tmpl.Texts[0]
+	text.FilledText = text.Text
+	m := measureAndDrawChildText(text, nil)
+	if m.Width != 100 || m.Height != 60 {
+		t.Errorf("Expected centred text to measure 100x60, was %vx%v", m.Width, m.Height)
+	}
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -16,14 +17,23 @@ func (r *TemplateRepository) Close() error {
 	return r.Db.Close()
 }
 
-func (r *TemplateRepository) readTemplateBase(u uuid.UUID) (*Template, error) {
-  row := r.Db.QueryRow(`
-    SELECT id, name, created_at, landscape, min_size, max_size
+// The database, or a transaction, so templates can be read as part of the
+// same transaction they're changed in
+type Querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func (r *TemplateRepository) readTemplateBase(q Querier, u uuid.UUID) (*Template, error) {
+  row := q.QueryRow(`
+    SELECT id, name, created_at, revision, landscape, min_size, max_size,
+      intensity, feed_lines, justify, copies
     FROM template
     WHERE uuid = ?`, u.String())
 
 	t := Template{Uuid: u}
-  if err := row.Scan(&t.Id, &t.Name, &t.CreatedAt, &t.Landscape, &t.MinSize, &t.MaxSize); err != nil {
+  if err := row.Scan(&t.Id, &t.Name, &t.CreatedAt, &t.Revision, &t.Landscape, &t.MinSize, &t.MaxSize,
+    &t.PrintOptions.Intensity, &t.PrintOptions.FeedLines, &t.PrintOptions.Justify, &t.PrintOptions.Copies); err != nil {
     if errors.Is(err, sql.ErrNoRows) {
       return nil, nil
     } else {
@@ -34,10 +44,12 @@ func (r *TemplateRepository) readTemplateBase(u uuid.UUID) (*Template, error) {
   return &t, nil
 }
 
+// Lists every font, without the font files themselves
 func (r *TemplateRepository) ListFonts() ([]Font, error) {
 	rows, err := r.Db.Query(`
-	  SELECT uuid, name, builtin_name, font_data
-		FROM font`)
+	  SELECT id, uuid, name, family, style, COALESCE(builtin_name, '')
+		FROM font
+		ORDER BY id`)
 
 	if err != nil {
 		return nil, fmt.Errorf("Query execution failed:\n%w", err)
@@ -48,7 +60,7 @@ func (r *TemplateRepository) ListFonts() ([]Font, error) {
   for count := 0; rows.Next(); count++ {
 		f := Font{}
 		var uuidString string
-		if err := rows.Scan(&uuidString, &f.Name, &f.BuiltinName, &f.FontData); err != nil {
+		if err := rows.Scan(&f.Id, &uuidString, &f.Name, &f.Family, &f.Style, &f.BuiltinName); err != nil {
 			return nil, fmt.Errorf("Row scanning failed:\n%w", err)
 		}
 		f.Uuid = uuid.MustParse(uuidString)
@@ -64,14 +76,14 @@ func (r *TemplateRepository) ListFonts() ([]Font, error) {
 
 func (r *TemplateRepository) GetFont(u uuid.UUID) (*Font, error) {
 	row := r.Db.QueryRow(`
-	  SELECT uuid, name, builtin_name, font_data
+	  SELECT id, uuid, name, family, style, COALESCE(builtin_name, ''), font_data
 		FROM font
 		WHERE uuid = ?`, u.String())
 
 	var f Font
 
 	var uuidString string
-	if err := row.Scan(&uuidString, &f.Name, &f.BuiltinName, &f.FontData); err != nil {
+	if err := row.Scan(&f.Id, &uuidString, &f.Name, &f.Family, &f.Style, &f.BuiltinName, &f.FontData); err != nil {
     if errors.Is(err, sql.ErrNoRows) {
       return nil, nil
     } else {
@@ -83,8 +95,63 @@ func (r *TemplateRepository) GetFont(u uuid.UUID) (*Font, error) {
 	return &f, nil
 }
 
+func (r *TemplateRepository) CreateFont(f *Font) error {
+	row := r.Db.QueryRow(`
+	  INSERT INTO font(uuid, name, family, style, font_data)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id`, f.Uuid.String(), f.Name, f.Family, f.Style, f.FontData)
+	if err := row.Scan(&f.Id); err != nil {
+		return fmt.Errorf("Failed to insert font:\n%w", err)
+	}
+	return nil
+}
+
+// Returns false if there's no font with the UUID
+func (r *TemplateRepository) RenameFont(u uuid.UUID, name string) (bool, error) {
+	result, err := r.Db.Exec("UPDATE font SET name = ? WHERE uuid = ?", name, u.String())
+	if err != nil {
+		return false, fmt.Errorf("Failed to rename font:\n%w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// Lists the templates with text which uses a font
+func (r *TemplateRepository) FontUsers(tx *sql.Tx, u uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := tx.Query(`
+	  SELECT DISTINCT t.uuid
+		FROM template t
+		JOIN template_text tt ON tt.template_id = t.id
+		JOIN font f ON f.id = tt.font_id
+		WHERE f.uuid = ?
+		ORDER BY t.uuid`, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("Query execution failed:\n%w", err)
+	}
+	defer rows.Close()
+
+	users := []uuid.UUID{}
+	for rows.Next() {
+		var uuidString string
+		if err := rows.Scan(&uuidString); err != nil {
+			return nil, fmt.Errorf("Row scanning failed:\n%w", err)
+		}
+		users = append(users, uuid.MustParse(uuidString))
+	}
+	return users, rows.Err()
+}
+
+func (r *TemplateRepository) DeleteFont(tx *sql.Tx, u uuid.UUID) error {
+	if _, err := tx.Exec("DELETE FROM font WHERE uuid = ?", u.String()); err != nil {
+		return fmt.Errorf("Failed to delete font:\n%w", err)
+	}
+	return nil
+}
+
 func (r *TemplateRepository) List() ([]Template, error) {
-	rows, err := r.Db.Query(`SELECT uuid, id, name, landscape, min_size, max_size FROM template`)
+	rows, err := r.Db.Query(`SELECT uuid, id, name, revision, landscape, min_size, max_size,
+	  intensity, feed_lines, justify, copies
+		FROM template`)
 	if err != nil {
 		return nil, fmt.Errorf("Query execution failed:\n%w", err)
 	}
@@ -94,7 +161,8 @@ func (r *TemplateRepository) List() ([]Template, error) {
   for count := 0; rows.Next(); count++ {
 		t := Template{}
 		var uuidString string
-		if err := rows.Scan(&uuidString, &t.Id, &t.Name, &t.Landscape, &t.MinSize, &t.MaxSize); err != nil {
+		if err := rows.Scan(&uuidString, &t.Id, &t.Name, &t.Revision, &t.Landscape, &t.MinSize, &t.MaxSize,
+			&t.PrintOptions.Intensity, &t.PrintOptions.FeedLines, &t.PrintOptions.Justify, &t.PrintOptions.Copies); err != nil {
 			return nil, fmt.Errorf("row scanning failed:\n%w", err)
 		}
 		t.Uuid = uuid.MustParse(uuidString)
@@ -108,16 +176,16 @@ func (r *TemplateRepository) List() ([]Template, error) {
 	return templates, nil
 }
 
-func (r *TemplateRepository) Exists(u uuid.UUID) (bool, error) {
-  t, err := r.readTemplateBase(u)
+func (r *TemplateRepository) Exists(q Querier, u uuid.UUID) (bool, error) {
+  t, err := r.readTemplateBase(q, u)
   if err != nil {
     return false, err
   }
 	return (t != nil), nil
 }
 
-func (r *TemplateRepository) Get(u uuid.UUID) (*Template, error) {
-  t, err := r.readTemplateBase(u)
+func (r *TemplateRepository) Get(q Querier, u uuid.UUID) (*Template, error) {
+  t, err := r.readTemplateBase(q, u)
   if err != nil {
     return nil, err
   }
@@ -125,50 +193,62 @@ func (r *TemplateRepository) Get(u uuid.UUID) (*Template, error) {
     return nil, nil
   }
 
-  var paramCount, imageCount, textCount int
-  row := r.Db.QueryRow(`
+  var paramCount, imageCount, textCount, barcodeCount, matrixCodeCount, shapeCount int
+  row := q.QueryRow(`
     SELECT
       (SELECT COUNT(1) FROM template_parameter WHERE template_id = ?) AS param_count,
       (SELECT COUNT(1) FROM template_image WHERE template_id = ?) AS image_count,
-      (SELECT COUNT(1) FROM template_text WHERE template_id = ?) AS text_count
-    `, t.Id, t.Id, t.Id)
+      (SELECT COUNT(1) FROM template_text WHERE template_id = ?) AS text_count,
+      (SELECT COUNT(1) FROM template_barcode WHERE template_id = ?) AS barcode_count,
+      (SELECT COUNT(1) FROM template_matrix_code WHERE template_id = ?) AS matrix_code_count,
+      (SELECT COUNT(1) FROM template_shape WHERE template_id = ?) AS shape_count
+    `, t.Id, t.Id, t.Id, t.Id, t.Id, t.Id)
 
-  if err := row.Scan(&paramCount, &imageCount, &textCount); err != nil {
+  if err := row.Scan(&paramCount, &imageCount, &textCount, &barcodeCount, &matrixCodeCount, &shapeCount); err != nil {
     return nil, fmt.Errorf("Failed to query template child count:\n%w", err)
   }
 
   t.Parameters = make([]Parameter, paramCount)
-  if err := QueryAndScanRows(r.Db, `
-    SELECT id, name, max_length
+  if err := QueryAndScanRows(q, `
+    SELECT id, name, type, max_length, pattern, min, max, required, default_value, choices, padding, date_layout
     FROM template_parameter
     WHERE template_id = ?`, t.Id, t.Parameters, func(r *sql.Rows, x *Parameter) error {
-      return r.Scan(&x.Id, &x.Name, &x.MaxLength)
+      var choices string
+      if err := r.Scan(&x.Id, &x.Name, &x.Type, &x.MaxLength, &x.Pattern, &x.Min, &x.Max,
+        &x.Required, &x.Default, &choices, &x.Padding, &x.DateLayout); err != nil {
+        return err
+      }
+      return json.Unmarshal([]byte(choices), &x.Choices)
     },
   ); err != nil {
     return nil, fmt.Errorf("Failed to read parameters for template:\n%w", err)
   }
 
   t.Images = make([]Image, imageCount)
-  if err := QueryAndScanRows(r.Db, `
-    SELECT id, image, x, y, width, height
+  if err := QueryAndScanRows(q, `
+    SELECT id, image, x, y, width, height, dither_algorithm, gamma, brightness, contrast, invert
     FROM template_image
     WHERE template_id = ?`, t.Id, t.Images, func(r *sql.Rows, i *Image) error {
-      return r.Scan(&i.Id, &i.Image, &i.X, &i.Y, &i.Width, &i.Height)
+      return r.Scan(&i.Id, &i.Image, &i.X, &i.Y, &i.Width, &i.Height,
+        &i.Dither.Algorithm, &i.Dither.Gamma, &i.Dither.Brightness, &i.Dither.Contrast, &i.Dither.Invert)
     },
   ); err != nil {
     return nil, fmt.Errorf("Failed to read child images for template:\n%w", err)
   }
 
   t.Texts = make([]Text, textCount)
-  if err := QueryAndScanRows(r.Db, `
-    SELECT t.id, t.text, t.x, t.y, t.width, t.height, t.font_size, f.uuid, f.name, f.builtin_name, f.font_data
+  if err := QueryAndScanRows(q, `
+    SELECT t.id, t.text, t.x, t.y, t.width, t.height, t.font_size,
+      t.align, t.vertical_align, t.line_height, t.max_lines, t.auto_fit, t.min_font_size,
+      f.id, f.uuid, f.name, f.family, f.style, COALESCE(f.builtin_name, ''), f.font_data
     FROM template_text t
 		JOIN font f ON f.id = t.font_id
     WHERE t.template_id = ?`, t.Id, t.Texts, func(r *sql.Rows, i *Text) error {
 			var uuidString string
 			err := r.Scan(
 				&i.Id, &i.Text, &i.X, &i.Y, &i.Width, &i.Height, &i.FontSize,
-				&uuidString, &i.Font.Name, &i.Font.BuiltinName, &i.Font.FontData)
+				&i.Align, &i.VerticalAlign, &i.LineHeight, &i.MaxLines, &i.AutoFit, &i.MinFontSize,
+				&i.Font.Id, &uuidString, &i.Font.Name, &i.Font.Family, &i.Font.Style, &i.Font.BuiltinName, &i.Font.FontData)
 			i.Font.Uuid = uuid.MustParse(uuidString)
 			return err
     },
@@ -176,10 +256,48 @@ func (r *TemplateRepository) Get(u uuid.UUID) (*Template, error) {
     return nil, fmt.Errorf("Failed to read child texts for image:\n%w", err)
   }
 
+  t.Barcodes = make([]Barcode, barcodeCount)
+  if err := QueryAndScanRows(q, `
+    SELECT id, symbology, data, x, y, module_width, height, show_text
+    FROM template_barcode
+    WHERE template_id = ?`, t.Id, t.Barcodes, func(r *sql.Rows, b *Barcode) error {
+      return r.Scan(&b.Id, &b.Symbology, &b.Data, &b.X, &b.Y, &b.ModuleWidth, &b.Height, &b.ShowText)
+    },
+  ); err != nil {
+    return nil, fmt.Errorf("Failed to read child barcodes for template:\n%w", err)
+  }
+
+  t.MatrixCodes = make([]MatrixCode, matrixCodeCount)
+  if err := QueryAndScanRows(q, `
+    SELECT id, symbology, data, x, y, module_size, quiet_zone, error_correction
+    FROM template_matrix_code
+    WHERE template_id = ?`, t.Id, t.MatrixCodes, func(r *sql.Rows, c *MatrixCode) error {
+      return r.Scan(&c.Id, &c.Symbology, &c.Data, &c.X, &c.Y, &c.ModuleSize, &c.QuietZone, &c.ErrorCorrection)
+    },
+  ); err != nil {
+    return nil, fmt.Errorf("Failed to read child matrix codes for template:\n%w", err)
+  }
+
+  t.Shapes = make([]Shape, shapeCount)
+  if err := QueryAndScanRows(q, `
+    SELECT id, kind, x, y, width, height, stroke_width, fill, corner_radius, dash
+    FROM template_shape
+    WHERE template_id = ?`, t.Id, t.Shapes, func(r *sql.Rows, s *Shape) error {
+      var dash string
+      if err := r.Scan(&s.Id, &s.Kind, &s.X, &s.Y, &s.Width, &s.Height,
+        &s.StrokeWidth, &s.Fill, &s.CornerRadius, &dash); err != nil {
+        return err
+      }
+      return json.Unmarshal([]byte(dash), &s.Dash)
+    },
+  ); err != nil {
+    return nil, fmt.Errorf("Failed to read child shapes for template:\n%w", err)
+  }
+
   return t, nil
 }
 
-func QueryAndScanRows[T any](db *sql.DB, query string, id int, results []T, scanRow func(*sql.Rows, *T) error) error {
+func QueryAndScanRows[T any](db Querier, query string, id int, results []T, scanRow func(*sql.Rows, *T) error) error {
 	rows, err := db.Query(query, id)
 	if err != nil {
 		return fmt.Errorf("Query execution failed:\n%w", err)
@@ -236,22 +354,27 @@ func (r *TemplateRepository) Multi(tx *sql.Tx, param any, qs ...string) error {
 	return nil
 }
 
-func (r *TemplateRepository) Create(tx *sql.Tx, t *Template) error {
+// Saves a new template, as its first revision
+func (r *TemplateRepository) Create(tx *sql.Tx, t *Template, note RevisionNote) error {
   row := tx.QueryRow(`
-    INSERT INTO template(uuid, name, created_at, landscape, min_size, max_size)
-    VALUES (?, ?, ?, ?, ?, ?)
-    RETURNING id`, t.Uuid.String(), t.Name, t.CreatedAt, t.Landscape, t.MinSize, t.MaxSize)
+    INSERT INTO template(uuid, name, created_at, landscape, min_size, max_size,
+      intensity, feed_lines, justify, copies)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    RETURNING id`, t.Uuid.String(), t.Name, t.CreatedAt, t.Landscape, t.MinSize, t.MaxSize,
+    t.PrintOptions.Intensity, t.PrintOptions.FeedLines, t.PrintOptions.Justify, t.PrintOptions.Copies)
   if err := row.Scan(&t.Id); err != nil {
     return fmt.Errorf("Failed to insert into template:\n%w", err)
   }
 
-  r.insertChildren(tx, t)
-
-  return nil
+  if err := r.insertChildren(tx, t); err != nil {
+    return err
+  }
+  return r.addRevision(tx, t, note)
 }
 
-func (r *TemplateRepository) Update(tx *sql.Tx, u uuid.UUID, t *Template) error {
-  tFromDb, err := r.readTemplateBase(t.Uuid)
+// Saves changes to a template, as a new revision
+func (r *TemplateRepository) Update(tx *sql.Tx, u uuid.UUID, t *Template, note RevisionNote) error {
+  tFromDb, err := r.readTemplateBase(tx, t.Uuid)
   if err != nil {
     return err
   }
@@ -260,15 +383,25 @@ func (r *TemplateRepository) Update(tx *sql.Tx, u uuid.UUID, t *Template) error
   }
 
 	t.Id = tFromDb.Id
+	t.CreatedAt = tFromDb.CreatedAt
 	if err := r.Multi(tx, t.Id,
 		  "DELETE FROM template_parameter WHERE template_id = ?",
       "DELETE FROM template_image WHERE template_id = ?",
-      "DELETE FROM template_text WHERE template_id = ?"); err != nil {
+      "DELETE FROM template_text WHERE template_id = ?",
+      "DELETE FROM template_barcode WHERE template_id = ?",
+      "DELETE FROM template_matrix_code WHERE template_id = ?",
+      "DELETE FROM template_shape WHERE template_id = ?"); err != nil {
 		return err
   }
 
-  _, err = tx.Exec(`UPDATE template SET name = ?, landscape = ? WHERE id = ?`,
-    t.Name, t.Landscape, t.Id)
+  _, err = tx.Exec(`
+    UPDATE template
+    SET name = ?, landscape = ?, min_size = ?, max_size = ?,
+      intensity = ?, feed_lines = ?, justify = ?, copies = ?
+    WHERE id = ?`,
+    t.Name, t.Landscape, t.MinSize, t.MaxSize,
+    t.PrintOptions.Intensity, t.PrintOptions.FeedLines, t.PrintOptions.Justify, t.PrintOptions.Copies,
+    t.Id)
   if err != nil {
     return fmt.Errorf("Couldn't update template data:\n%w", err)
   }
@@ -276,27 +409,44 @@ func (r *TemplateRepository) Update(tx *sql.Tx, u uuid.UUID, t *Template) error
     return err
   }
 
-  return nil
+  return r.addRevision(tx, t, note)
+}
+
+// Deletes a template along with its parameters, texts, images and other
+// children, which the schema deletes with it. Jobs printed from the template
+// are kept. Returns false if there's no template with the UUID.
+func (r *TemplateRepository) Delete(tx *sql.Tx, u uuid.UUID) (bool, error) {
+  result, err := tx.Exec("DELETE FROM template WHERE uuid = ?", u.String())
+  if err != nil {
+    return false, fmt.Errorf("Couldn't delete template:\n%w", err)
+  }
+  n, err := result.RowsAffected()
+  return n > 0, err
 }
 
 func (r *TemplateRepository) insertChildren(tx *sql.Tx, t *Template) error {
   pStmt, err := tx.Prepare(`
-    INSERT INTO template_parameter(template_id, name, max_length)
-    VALUES (?, ?, ?)`)
+    INSERT INTO template_parameter(template_id, name, type, max_length, pattern, min, max, required, default_value, choices, padding, date_layout)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
   if err != nil {
     return fmt.Errorf("Failed to prepare statement to insert template parameter:\n%w", err)
   }
   defer pStmt.Close()
   for i, p := range t.Parameters {
-    _, err := pStmt.Exec(t.Id, p.Name, p.MaxLength)
+    choices, err := json.Marshal(p.Choices)
+    if err != nil {
+      return fmt.Errorf("Couldn't encode choices for parameter %v:\n%w", i, err)
+    }
+    _, err = pStmt.Exec(t.Id, p.Name, p.Type, p.MaxLength, p.Pattern, p.Min, p.Max,
+      p.Required, p.Default, string(choices), p.Padding, p.DateLayout)
     if err != nil {
       return fmt.Errorf("Failed to insert parameter %v of template:\n%w", i, err)
     }
   }
 
   iStmt, err := tx.Prepare(`
-    INSERT INTO template_image(template_id, image, x, y, width, height)
-    VALUES (?, ?, ?, ?, ?, ?)`)
+    INSERT INTO template_image(template_id, image, x, y, width, height, dither_algorithm, gamma, brightness, contrast, invert)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
   if err != nil {
     return fmt.Errorf("Failed to prepare statement to insert template image:\n%w", err)
   }
@@ -307,6 +457,11 @@ func (r *TemplateRepository) insertChildren(tx *sql.Tx, t *Template) error {
       img.X, img.Y,
       img.Width,
       img.Height,
+      img.Dither.Algorithm,
+      img.Dither.Gamma,
+      img.Dither.Brightness,
+      img.Dither.Contrast,
+      img.Dither.Invert,
     )
     if err != nil {
       return fmt.Errorf("Failed to insert parameter %v of image:\n%w", i, err)
@@ -314,8 +469,9 @@ func (r *TemplateRepository) insertChildren(tx *sql.Tx, t *Template) error {
   }
 
   tStmt, err := tx.Prepare(`
-    INSERT INTO template_text(template_id, text, x, y, width, height, font_size, font_id)
-    VALUES (?, ?, ?, ?, ?, ?, ?, (SELECT id FROM font WHERE uuid = ?))`)
+    INSERT INTO template_text(template_id, text, x, y, width, height, font_size,
+      align, vertical_align, line_height, max_lines, auto_fit, min_font_size, font_id)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT id FROM font WHERE uuid = ?))`)
   if err != nil {
     return fmt.Errorf("Failed to prepare statement to insert template text:\n%w", err)
   }
@@ -327,6 +483,7 @@ func (r *TemplateRepository) insertChildren(tx *sql.Tx, t *Template) error {
       txt.Width,
       txt.Height,
 			txt.FontSize,
+			txt.Align, txt.VerticalAlign, txt.LineHeight, txt.MaxLines, txt.AutoFit, txt.MinFontSize,
 			txt.Font.Uuid.String(),
     )
     if err != nil {
@@ -334,5 +491,74 @@ func (r *TemplateRepository) insertChildren(tx *sql.Tx, t *Template) error {
     }
   }
 
+  bStmt, err := tx.Prepare(`
+    INSERT INTO template_barcode(template_id, symbology, data, x, y, module_width, height, show_text)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+  if err != nil {
+    return fmt.Errorf("Failed to prepare statement to insert template barcode:\n%w", err)
+  }
+  defer bStmt.Close()
+  for i, b := range t.Barcodes {
+    _, err := bStmt.Exec(t.Id,
+      b.Symbology,
+      b.Data,
+      b.X, b.Y,
+      b.ModuleWidth,
+      b.Height,
+      b.ShowText,
+    )
+    if err != nil {
+      return fmt.Errorf("Failed to insert barcode %v of template:\n%w", i, err)
+    }
+  }
+
+  mStmt, err := tx.Prepare(`
+    INSERT INTO template_matrix_code(template_id, symbology, data, x, y, module_size, quiet_zone, error_correction)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+  if err != nil {
+    return fmt.Errorf("Failed to prepare statement to insert template matrix code:\n%w", err)
+  }
+  defer mStmt.Close()
+  for i, c := range t.MatrixCodes {
+    _, err := mStmt.Exec(t.Id,
+      c.Symbology,
+      c.Data,
+      c.X, c.Y,
+      c.ModuleSize,
+      c.QuietZone,
+      c.ErrorCorrection,
+    )
+    if err != nil {
+      return fmt.Errorf("Failed to insert matrix code %v of template:\n%w", i, err)
+    }
+  }
+
+  sStmt, err := tx.Prepare(`
+    INSERT INTO template_shape(template_id, kind, x, y, width, height, stroke_width, fill, corner_radius, dash)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+  if err != nil {
+    return fmt.Errorf("Failed to prepare statement to insert template shape:\n%w", err)
+  }
+  defer sStmt.Close()
+  for i, s := range t.Shapes {
+    dash, err := json.Marshal(s.Dash)
+    if err != nil {
+      return fmt.Errorf("Couldn't encode dash pattern for shape %v:\n%w", i, err)
+    }
+    _, err = sStmt.Exec(t.Id,
+      s.Kind,
+      s.X, s.Y,
+      s.Width,
+      s.Height,
+      s.StrokeWidth,
+      s.Fill,
+      s.CornerRadius,
+      string(dash),
+    )
+    if err != nil {
+      return fmt.Errorf("Failed to insert shape %v of template:\n%w", i, err)
+    }
+  }
+
   return nil
 }
@@ -0,0 +1,188 @@
+package template
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/image/font/gofont/goregular"
+	"tomgalvin.uk/phogoprint/internal/database/dbtest"
+)
+
+func aRepository(t *testing.T) *TemplateRepository {
+	return &TemplateRepository{Db: dbtest.Open(t)}
+}
+
+func TestUploadedFontCantBeDeletedWhileUsed(t *testing.T) {
+	r := aRepository(t)
+	f, err := NewFont("", goregular.TTF)
+	if err != nil {
+		t.Fatalf("Couldn't read font: %v", err)
+	}
+	if f.Name != "Go Regular" || f.Family != "Go" || f.Style != "Regular" {
+		t.Errorf("Unexpected font names %q, %q, %q", f.Name, f.Family, f.Style)
+	}
+	if err := r.CreateFont(f); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &Template{
+		Uuid:      uuid.New(),
+		Name:      "Uses font",
+		CreatedAt: time.Now(),
+		Texts:     []Text{{Text: "Hello", FontSize: 12, Font: *f, Align: AlignLeft, VerticalAlign: AlignTop, LineHeight: 1}},
+	}
+	if err := r.Transact(func(tx *sql.Tx) error { return r.Create(tx, tmpl, RevisionNote{}) }); err != nil {
+		t.Fatal(err)
+	}
+
+	var users []uuid.UUID
+	r.Transact(func(tx *sql.Tx) (err error) {
+		users, err = r.FontUsers(tx, f.Uuid)
+		return
+	})
+	if len(users) != 1 || users[0] != tmpl.Uuid {
+		t.Errorf("Expected font to be used by %v, was used by %v", tmpl.Uuid, users)
+	}
+
+	saved, err := r.Get(r.Db, tmpl.Uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Texts[0].Font.Uuid != f.Uuid || len(saved.Texts[0].Font.FontData) != len(goregular.TTF) {
+		t.Errorf("Expected template text to use the uploaded font")
+	}
+}
+
+func TestNewFontRejectsFilesWhichArentFonts(t *testing.T) {
+	if _, err := NewFont("Not a font", []byte("<html></html>")); err == nil {
+		t.Errorf("Expected file to be rejected")
+	}
+}
+
+func TestDeletingTemplateDeletesItsChildren(t *testing.T) {
+	r := aRepository(t)
+	tmpl := &Template{
+		Uuid:       uuid.New(),
+		Name:       "To delete",
+		CreatedAt:  time.Now(),
+		Parameters: []Parameter{{Name: "sku", Type: StringParameter, Required: true}},
+		Texts: []Text{{
+			Text: "{sku}", FontSize: 12, Font: Font{Uuid: uuid.MustParse("4d98c9b6-8bb5-492d-9789-a2bb5ea8ab21")},
+			Align: AlignLeft, VerticalAlign: AlignTop, LineHeight: 1,
+		}},
+		Shapes: []Shape{{Kind: Rect, Width: 10, Height: 10, StrokeWidth: 1}},
+	}
+	if err := r.Transact(func(tx *sql.Tx) error { return r.Create(tx, tmpl, RevisionNote{}) }); err != nil {
+		t.Fatal(err)
+	}
+
+	var deleted bool
+	if err := r.Transact(func(tx *sql.Tx) (err error) {
+		deleted, err = r.Delete(tx, tmpl.Uuid)
+		return
+	}); err != nil || !deleted {
+		t.Fatalf("Expected template to be deleted, got %v, %v", deleted, err)
+	}
+	for _, table := range []string{"template_parameter", "template_text", "template_shape"} {
+		var count int
+		r.Db.QueryRow("SELECT COUNT(1) FROM " + table).Scan(&count)
+		if count != 0 {
+			t.Errorf("Expected %v to be empty, had %v rows", table, count)
+		}
+	}
+}
+
+func TestUpdateChangesTemplateSize(t *testing.T) {
+	r := aRepository(t)
+	tmpl := &Template{Uuid: uuid.New(), Name: "Sized", CreatedAt: time.Now(), MinSize: 100, MaxSize: 200}
+	if err := r.Transact(func(tx *sql.Tx) error { return r.Create(tx, tmpl, RevisionNote{}) }); err != nil {
+		t.Fatal(err)
+	}
+	tmpl.MinSize, tmpl.MaxSize = 150, 300
+	if err := r.Transact(func(tx *sql.Tx) error { return r.Update(tx, tmpl.Uuid, tmpl, RevisionNote{}) }); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := r.Get(r.Db, tmpl.Uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.MinSize != 150 || saved.MaxSize != 300 {
+		t.Errorf("Expected size to be 150 to 300, was %v to %v", saved.MinSize, saved.MaxSize)
+	}
+}
+
+func TestTemplatesAreReadInTheTransactionGiven(t *testing.T) {
+	r := aRepository(t)
+	tmpl := &Template{Uuid: uuid.New(), Name: "Before", CreatedAt: time.Now()}
+	err := r.Transact(func(tx *sql.Tx) error {
+		if exists, err := r.Exists(tx, tmpl.Uuid); err != nil || exists {
+			t.Errorf("Expected template not to exist yet, got %v, %v", exists, err)
+		}
+		if err := r.Create(tx, tmpl, RevisionNote{}); err != nil {
+			return err
+		}
+		tmpl.Name = "After"
+		if err := r.Update(tx, tmpl.Uuid, tmpl, RevisionNote{}); err != nil {
+			return err
+		}
+		saved, err := r.Get(tx, tmpl.Uuid)
+		if err != nil {
+			return err
+		}
+		if saved == nil || saved.Name != "After" {
+			t.Errorf("Expected the transaction's own changes to be read, got %+v", saved)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEverySaveKeepsARevision(t *testing.T) {
+	r := aRepository(t)
+	tmpl := &Template{
+		Uuid:      uuid.New(),
+		Name:      "First",
+		CreatedAt: time.Now(),
+		Texts: []Text{{
+			Text: "Hello", FontSize: 12, Font: Font{Uuid: uuid.MustParse("4d98c9b6-8bb5-492d-9789-a2bb5ea8ab21")},
+			Align: AlignLeft, VerticalAlign: AlignTop, LineHeight: 1,
+		}},
+	}
+	if err := r.Transact(func(tx *sql.Tx) error { return r.Create(tx, tmpl, RevisionNote{Author: "Sam"}) }); err != nil {
+		t.Fatal(err)
+	}
+	tmpl.Name = "Second"
+	tmpl.Texts = nil
+	if err := r.Transact(func(tx *sql.Tx) error { return r.Update(tx, tmpl.Uuid, tmpl, RevisionNote{Note: "Removed text"}) }); err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Revision != 2 {
+		t.Errorf("Expected template to be at revision 2, was at %v", tmpl.Revision)
+	}
+
+	revs, err := r.ListRevisions(tmpl.Uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 2 || revs[0].Number != 2 || revs[0].Note != "Removed text" || revs[1].Author != "Sam" {
+		t.Fatalf("Unexpected revisions %+v", revs)
+	}
+
+	first, err := r.GetRevision(tmpl.Uuid, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Template.Name != "First" || len(first.Template.Texts) != 1 {
+		t.Fatalf("Expected revision 1 to be as first saved, was %+v", first.Template)
+	}
+	if first.Template.Texts[0].Font.Id == 0 {
+		t.Errorf("Expected revision's font to be loaded")
+	}
+	if missing, err := r.GetRevision(tmpl.Uuid, 3); err != nil || missing != nil {
+		t.Errorf("Expected no revision 3, got %v, %v", missing, err)
+	}
+}
@@ -0,0 +1,116 @@
+package template
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A template as it was when it was saved. Revisions are never changed once
+// they're made: restoring an old one saves it again as the latest revision.
+type Revision struct {
+	Number    int
+	Author    string
+	Note      string
+	CreatedAt time.Time
+	// Only loaded when getting a single revision
+	Template  *Template
+}
+
+// Who saved a template and why, which is kept with the revision the save
+// makes. Both are optional.
+type RevisionNote struct {
+	Author string
+	Note   string
+}
+
+// Keeps the template as it's just been saved as its next revision
+func (r *TemplateRepository) addRevision(tx *sql.Tx, t *Template, note RevisionNote) error {
+	row := tx.QueryRow(`UPDATE template SET revision = revision + 1 WHERE id = ? RETURNING revision`, t.Id)
+	if err := row.Scan(&t.Revision); err != nil {
+		return fmt.Errorf("Couldn't update template revision:\n%w", err)
+	}
+	content, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("Couldn't encode template revision:\n%w", err)
+	}
+	_, err = tx.Exec(`
+		INSERT INTO template_revision(template_id, revision, author, note, created_at, content)
+		VALUES (?, ?, ?, ?, ?, ?)`, t.Id, t.Revision, note.Author, note.Note, time.Now(), string(content))
+	if err != nil {
+		return fmt.Errorf("Couldn't save template revision:\n%w", err)
+	}
+	return nil
+}
+
+// Lists a template's revisions, newest first, without the templates
+// themselves. Returns nil if there's no template with the UUID.
+func (r *TemplateRepository) ListRevisions(u uuid.UUID) ([]Revision, error) {
+	t, err := r.readTemplateBase(r.Db, u)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	rows, err := r.Db.Query(`
+		SELECT revision, author, note, created_at
+		FROM template_revision
+		WHERE template_id = ?
+		ORDER BY revision DESC`, t.Id)
+	if err != nil {
+		return nil, fmt.Errorf("Query execution failed:\n%w", err)
+	}
+	defer rows.Close()
+
+	revisions := []Revision{}
+	for rows.Next() {
+		var rev Revision
+		if err := rows.Scan(&rev.Number, &rev.Author, &rev.Note, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("Row scanning failed:\n%w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Error iterating rows:\n%w", err)
+	}
+	return revisions, nil
+}
+
+// Gets one revision of a template, ready to be rendered, or nil if there's no
+// such revision
+func (r *TemplateRepository) GetRevision(u uuid.UUID, number int) (*Revision, error) {
+	row := r.Db.QueryRow(`
+		SELECT r.revision, r.author, r.note, r.created_at, r.content
+		FROM template_revision r
+		JOIN template t ON t.id = r.template_id
+		WHERE t.uuid = ? AND r.revision = ?`, u.String(), number)
+
+	var rev Revision
+	var content string
+	if err := row.Scan(&rev.Number, &rev.Author, &rev.Note, &rev.CreatedAt, &content); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to read template revision:\n%w", err)
+	}
+	rev.Template = &Template{}
+	if err := json.Unmarshal([]byte(content), rev.Template); err != nil {
+		return nil, fmt.Errorf("Couldn't decode template revision %v:\n%w", number, err)
+	}
+
+	// only the fonts' UUIDs are kept, so the fonts themselves are looked up.
+	// Fonts which have been deleted since are left as they are, and fail to
+	// load if the revision is rendered.
+	for i := range rev.Template.Texts {
+		f, err := r.GetFont(rev.Template.Texts[i].Font.Uuid)
+		if err != nil {
+			return nil, err
+		}
+		if f != nil {
+			rev.Template.Texts[i].Font = *f
+		}
+	}
+	return &rev, nil
+}
@@ -0,0 +1,287 @@
+package template
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+type ShapeKind string
+
+const (
+	// Drawn from X, Y to X+Width, Y+Height. Either can be negative or 0.
+	Line        ShapeKind = "LINE"
+	Rect        ShapeKind = "RECT"
+	RoundedRect ShapeKind = "ROUNDED_RECT"
+	Ellipse     ShapeKind = "ELLIPSE"
+)
+
+// A line or outline drawn as solid black pixels. The stroke is drawn inside
+// the shape's box, so rectangles and ellipses take up exactly Width×Height.
+type Shape struct {
+	Id            int `json:"-"`
+	Kind          ShapeKind
+	X, Y          int
+	Width, Height int
+	// Thickness of the outline in pixels, or 0 for a filled shape with no
+	// outline
+	StrokeWidth   int
+	Fill          bool
+	// Only used by rounded rectangles
+	CornerRadius  int
+	// Lengths in pixels of alternating dashes and gaps along the outline, or
+	// empty for a solid outline. An odd number of lengths is repeated, so
+	// [4] is the same as [4, 4].
+	Dash          []int
+}
+
+func (s *Shape) Validate() error {
+	switch s.Kind {
+	case Line:
+		if s.Fill {
+			return fmt.Errorf("Lines can't be filled")
+		}
+		if s.Width == 0 && s.Height == 0 {
+			return fmt.Errorf("Line has no length")
+		}
+	case Rect, RoundedRect, Ellipse:
+		if s.Width <= 0 || s.Height <= 0 {
+			return fmt.Errorf("Shape width and height must be positive")
+		}
+	default:
+		return fmt.Errorf(`Unrecognised shape kind "%s"`, s.Kind)
+	}
+	if s.StrokeWidth < 0 || s.CornerRadius < 0 {
+		return fmt.Errorf("Shape can't have a negative stroke width or corner radius")
+	}
+	if s.StrokeWidth == 0 && !s.Fill {
+		return fmt.Errorf("Shape needs a stroke width or fill, or it won't be drawn")
+	}
+	for _, d := range s.Dash {
+		if d <= 0 {
+			return fmt.Errorf("Dash lengths must be positive")
+		}
+	}
+	return nil
+}
+
+// A point along the middle of a shape's outline, and how far along the
+// outline it is, which is used to work out where the dashes go
+type pathPoint struct {
+	x, y, distance float64
+}
+
+// Points along a path are this many pixels apart, at most, so the brush
+// stamped at each one leaves no gaps
+const pathStep = 0.25
+
+func measureAndDrawChildShape(s *Shape, i *image.RGBA64) Measure {
+	m := Measure{
+		X:      s.X,
+		Y:      s.Y,
+		Width:  s.Width,
+		Height: s.Height,
+	}
+	if s.Kind == Line {
+		// the box runs from whichever end is top left, and is as thick as the
+		// stroke
+		m.X, m.Width = min(s.X, s.X+s.Width), abs(s.Width)+s.StrokeWidth
+		m.Y, m.Height = min(s.Y, s.Y+s.Height), abs(s.Height)+s.StrokeWidth
+	}
+
+	if i != nil {
+		if s.Fill {
+			fillShape(s, i)
+		}
+		if s.StrokeWidth > 0 {
+			strokePath(i, shapePath(s), float64(s.StrokeWidth), s.Kind == Rect || s.Kind == Line && (s.Width == 0 || s.Height == 0), s.Dash)
+		}
+	}
+	return m
+}
+
+// Sets every pixel whose centre is inside the shape, with no antialiasing, so
+// the edges stay sharp when the label is dithered
+func fillShape(s *Shape, i *image.RGBA64) {
+	x0, y0 := float64(s.X), float64(s.Y)
+	x1, y1 := x0+float64(s.Width), y0+float64(s.Height)
+	radius := float64(s.CornerRadius)
+	if s.Kind == Rect {
+		radius = 0
+	}
+	for py := s.Y; py < s.Y+s.Height; py++ {
+		for px := s.X; px < s.X+s.Width; px++ {
+			cx, cy := float64(px)+0.5, float64(py)+0.5
+			var inside bool
+			if s.Kind == Ellipse {
+				inside = insideEllipse(cx, cy, x0, y0, x1, y1)
+			} else {
+				inside = insideRoundedRect(cx, cy, x0, y0, x1, y1, radius)
+			}
+			if inside {
+				i.Set(px, py, color.Black)
+			}
+		}
+	}
+}
+
+func insideEllipse(x, y, x0, y0, x1, y1 float64) bool {
+	rx, ry := (x1-x0)/2, (y1-y0)/2
+	dx, dy := (x-x0-rx)/rx, (y-y0-ry)/ry
+	return dx*dx+dy*dy <= 1
+}
+
+func insideRoundedRect(x, y, x0, y0, x1, y1, radius float64) bool {
+	radius = min(radius, (x1-x0)/2, (y1-y0)/2)
+	// distance from the rectangle shrunk by the radius
+	dx := max(x0+radius-x, 0, x-(x1-radius))
+	dy := max(y0+radius-y, 0, y-(y1-radius))
+	return dx*dx+dy*dy <= radius*radius || (dx == 0 && dy == 0)
+}
+
+// The middle of the shape's outline, inset by half the stroke width so the
+// stroke stays inside the shape's box
+func shapePath(s *Shape) []pathPoint {
+	half := float64(s.StrokeWidth) / 2
+	x0, y0 := float64(s.X)+half, float64(s.Y)+half
+	switch s.Kind {
+	case Line:
+		return linePath(nil, x0, y0, x0+float64(s.Width), y0+float64(s.Height))
+	case Ellipse:
+		rx, ry := float64(s.Width)/2-half, float64(s.Height)/2-half
+		return ellipsePath(x0+rx, y0+ry, max(rx, 0), max(ry, 0))
+	}
+	radius := 0.0
+	if s.Kind == RoundedRect {
+		radius = max(float64(s.CornerRadius)-half, 0)
+	}
+	return roundedRectPath(x0, y0, float64(s.X+s.Width)-half, float64(s.Y+s.Height)-half, radius)
+}
+
+// Adds a straight line to a path
+func linePath(path []pathPoint, x0, y0, x1, y1 float64) []pathPoint {
+	distance := 0.0
+	if len(path) > 0 {
+		distance = path[len(path)-1].distance
+	}
+	length := math.Hypot(x1-x0, y1-y0)
+	steps := max(int(math.Ceil(length/pathStep)), 1)
+	for n := 0; n <= steps; n++ {
+		f := float64(n) / float64(steps)
+		path = append(path, pathPoint{x0 + (x1-x0)*f, y0 + (y1-y0)*f, distance + length*f})
+	}
+	return path
+}
+
+// Adds a quarter of a circle to a path, going clockwise from the angle given
+func arcPath(path []pathPoint, cx, cy, radius, from float64) []pathPoint {
+	distance := path[len(path)-1].distance
+	length := radius * math.Pi / 2
+	steps := max(int(math.Ceil(length/pathStep)), 1)
+	for n := 1; n <= steps; n++ {
+		f := float64(n) / float64(steps)
+		angle := from + f*math.Pi/2
+		path = append(path, pathPoint{cx + radius*math.Cos(angle), cy + radius*math.Sin(angle), distance + length*f})
+	}
+	return path
+}
+
+// Goes clockwise around a rectangle from its top left corner
+func roundedRectPath(x0, y0, x1, y1, radius float64) []pathPoint {
+	radius = min(radius, (x1-x0)/2, (y1-y0)/2)
+	path := linePath(nil, x0+radius, y0, x1-radius, y0)
+	path = arcPath(path, x1-radius, y0+radius, radius, -math.Pi/2)
+	path = linePath(path, x1, y0+radius, x1, y1-radius)
+	path = arcPath(path, x1-radius, y1-radius, radius, 0)
+	path = linePath(path, x1-radius, y1, x0+radius, y1)
+	path = arcPath(path, x0+radius, y1-radius, radius, math.Pi/2)
+	path = linePath(path, x0, y1-radius, x0, y0+radius)
+	return arcPath(path, x0+radius, y0+radius, radius, math.Pi)
+}
+
+func ellipsePath(cx, cy, rx, ry float64) []pathPoint {
+	// steps small enough for the wider radius, measuring the distance
+	// between each point so dashes are spaced evenly
+	steps := max(int(math.Ceil(2*math.Pi*max(rx, ry)/pathStep)), 4)
+	path := make([]pathPoint, 0, steps+1)
+	distance := 0.0
+	for n := 0; n <= steps; n++ {
+		angle := 2*math.Pi*float64(n)/float64(steps) - math.Pi/2
+		p := pathPoint{x: cx + rx*math.Cos(angle), y: cy + ry*math.Sin(angle)}
+		if n > 0 {
+			prev := path[n-1]
+			distance += math.Hypot(p.x-prev.x, p.y-prev.y)
+		}
+		p.distance = distance
+		path = append(path, p)
+	}
+	return path
+}
+
+// Stamps a brush as wide as the stroke at each point on the path. Straight
+// edges use a square brush so corners stay square, and curves a round one so
+// the stroke is just as thick all the way around. Each pixel's distance along
+// the path decides whether it's in a dash or a gap, so dashes end squarely
+// rather than being made longer by the brush.
+func strokePath(i *image.RGBA64, path []pathPoint, width float64, square bool, dash []int) {
+	if len(dash)%2 == 1 {
+		dash = append(append([]int{}, dash...), dash...)
+	}
+	dashLength := 0
+	for _, d := range dash {
+		dashLength += d
+	}
+
+	half := width / 2
+	for n, p := range path {
+		// direction of the path at this point, to measure how far along the
+		// path each pixel of the brush is
+		prev, next := path[max(n-1, 0)], path[min(n+1, len(path)-1)]
+		tx, ty := next.x-prev.x, next.y-prev.y
+		if l := math.Hypot(tx, ty); l > 0 {
+			tx, ty = tx/l, ty/l
+		}
+		for py := int(math.Floor(p.y - half)); float64(py) < p.y+half; py++ {
+			for px := int(math.Floor(p.x - half)); float64(px) < p.x+half; px++ {
+				dx, dy := float64(px)+0.5-p.x, float64(py)+0.5-p.y
+				if square {
+					if math.Abs(dx) > half || math.Abs(dy) > half {
+						continue
+					}
+				} else if dx*dx+dy*dy > half*half+0.01 {
+					continue
+				}
+				if dashLength > 0 {
+					if !inDash(p.distance+dx*tx+dy*ty, dash, dashLength) {
+						continue
+					}
+				}
+				i.Set(px, py, color.Black)
+			}
+		}
+	}
+}
+
+// Whether a distance along a dash pattern is in a dash, rather than a gap
+func inDash(distance float64, dash []int, dashLength int) bool {
+	distance = math.Mod(distance, float64(dashLength))
+	if distance < 0 {
+		// before the start of the path counts as the end of the pattern
+		distance += float64(dashLength)
+	}
+	for n, d := range dash {
+		if distance < float64(d) {
+			return n%2 == 0
+		}
+		distance -= float64(d)
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
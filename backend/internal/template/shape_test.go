@@ -0,0 +1,70 @@
+package template
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func aBlankImage(width, height int) *image.RGBA64 {
+	img := image.NewRGBA64(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	return img
+}
+
+func isBlack(img image.Image, x, y int) bool {
+	return img.At(x, y) == color.RGBA64Model.Convert(color.Black)
+}
+
+func TestRectStrokeIsDrawnInsideItsBox(t *testing.T) {
+	img := aBlankImage(30, 20)
+	measureAndDrawChildShape(&Shape{Kind: Rect, X: 5, Y: 5, Width: 20, Height: 10, StrokeWidth: 2}, img)
+
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 30; x++ {
+			inBox := x >= 5 && x < 25 && y >= 5 && y < 15
+			inHole := x >= 7 && x < 23 && y >= 7 && y < 13
+			if expected := inBox && !inHole; isBlack(img, x, y) != expected {
+				t.Fatalf("Expected pixel %v,%v black to be %v", x, y, expected)
+			}
+		}
+	}
+}
+
+func TestShapesAreBlackAndWhite(t *testing.T) {
+	img := aBlankImage(60, 60)
+	for _, s := range []Shape{
+		{Kind: Ellipse, X: 2, Y: 2, Width: 50, Height: 30, StrokeWidth: 3},
+		{Kind: RoundedRect, X: 2, Y: 35, Width: 50, Height: 20, StrokeWidth: 1, Fill: true, CornerRadius: 6},
+		{Kind: Line, X: 55, Y: 2, Width: -50, Height: 50, StrokeWidth: 2},
+	} {
+		measureAndDrawChildShape(&s, img)
+	}
+	white := color.RGBA64Model.Convert(color.White)
+	for y := 0; y < 60; y++ {
+		for x := 0; x < 60; x++ {
+			if c := img.At(x, y); c != white && !isBlack(img, x, y) {
+				t.Fatalf("Expected pixel %v,%v to be black or white, was %v", x, y, c)
+			}
+		}
+	}
+}
+
+func TestDashedLineLeavesGaps(t *testing.T) {
+	img := aBlankImage(40, 1)
+	measureAndDrawChildShape(&Shape{Kind: Line, X: 0, Y: 0, Width: 39, StrokeWidth: 1, Dash: []int{5}}, img)
+
+	for x := 0; x < 40; x++ {
+		if expected := (x/5)%2 == 0; isBlack(img, x, 0) != expected {
+			t.Errorf("Expected pixel %v black to be %v", x, expected)
+		}
+	}
+}
+
+func TestLineMeasuredFromEitherEnd(t *testing.T) {
+	m := measureAndDrawChildShape(&Shape{Kind: Line, X: 50, Y: 10, Width: -40, Height: 20, StrokeWidth: 3}, nil)
+	if m.X != 10 || m.Y != 10 || m.Width != 43 || m.Height != 23 {
+		t.Errorf("Unexpected measure %+v", m)
+	}
+}
@@ -8,71 +8,185 @@ import (
 	"strings"
 	"time"
 
+	"github.com/boombuler/barcode"
 	"github.com/google/uuid"
 	"golang.org/x/image/font"
+	"tomgalvin.uk/phogoprint/internal/bitmap"
+	"tomgalvin.uk/phogoprint/internal/printer"
 )
 
+// Each time a template is saved it's kept as JSON, as a revision. Fields
+// tagged json:"-" are only filled in while rendering, so they're left out.
 type Template struct {
-	Id               int
+	Id               int `json:"-"`
 	Uuid             uuid.UUID
 	Name             string
 	CreatedAt        time.Time
+	// Goes up by one each time the template is saved. See Revision.
+	Revision         int
 	Landscape        bool
 	MinSize, MaxSize int
+	// Used for jobs printed from this template unless the request overrides them
+	PrintOptions     printer.PrintOptions
 	Parameters       []Parameter
 	Images           []Image
 	Texts            []Text
-}
-
-type Parameter struct {
-	Id        int
-	Name      string
-	MaxLength int
+	Barcodes         []Barcode
+	MatrixCodes      []MatrixCode
+	Shapes           []Shape
 }
 
 type Image struct {
-	Id            int
+	Id            int `json:"-"`
 	Image         []byte
-	LoadedImage   image.Image
+	LoadedImage   image.Image `json:"-"`
 	X, Y          int
 	Width, Height int
+	Dither        bitmap.RenderOptions
 }
 
 type Text struct {
-	Id            int
+	Id            int `json:"-"`
 	Text          string
-	FilledText    string
+	FilledText    string `json:"-"`
 	X, Y          int
 	Width, Height int
 	Font          Font
 	FontSize      int
-	FontFace      font.Face
+	FontFace      font.Face `json:"-"`
+	// Lines are aligned within Width, or within the widest line if there's no
+	// width, and the text is aligned vertically within Height
+	Align         TextAlign
+	VerticalAlign VerticalAlign
+	// Multiplies the font's natural line height
+	LineHeight    float64
+	// Text which wraps onto more lines than this is cut short with an
+	// ellipsis. 0 for no limit.
+	MaxLines      int
+	// Shrinks the font, down to MinFontSize, until the text fits in its box
+	AutoFit       bool
+	MinFontSize   int
+}
+
+type TextAlign string
+
+const (
+	AlignLeft    TextAlign = "LEFT"
+	AlignCentre  TextAlign = "CENTRE"
+	AlignRight   TextAlign = "RIGHT"
+	// Spreads words out to fill the width, except on the last line
+	AlignJustify TextAlign = "JUSTIFY"
+)
+
+type VerticalAlign string
+
+const (
+	AlignTop    VerticalAlign = "TOP"
+	AlignMiddle VerticalAlign = "MIDDLE"
+	AlignBottom VerticalAlign = "BOTTOM"
+)
+
+func (t *Text) Validate() error {
+	switch t.Align {
+	case AlignLeft, AlignCentre, AlignRight, AlignJustify:
+	default:
+		return fmt.Errorf(`Unrecognised text alignment "%s"`, t.Align)
+	}
+	switch t.VerticalAlign {
+	case AlignTop, AlignMiddle, AlignBottom:
+	default:
+		return fmt.Errorf(`Unrecognised vertical alignment "%s"`, t.VerticalAlign)
+	}
+	if t.LineHeight <= 0 {
+		return fmt.Errorf("Line height has to be more than 0")
+	}
+	if t.MaxLines < 0 {
+		return fmt.Errorf("Max lines can't be negative")
+	}
+	if t.AutoFit && (t.MinFontSize < 1 || t.MinFontSize > t.FontSize) {
+		return fmt.Errorf("Minimum font size has to be between 1 and the font size")
+	}
+	if t.AutoFit && t.Width == 0 && t.Height == 0 {
+		return fmt.Errorf("Text can only fit itself to a box with a width or height")
+	}
+	return nil
+}
+
+type Barcode struct {
+	Id                int `json:"-"`
+	Symbology         Symbology
+	Data              string
+	FilledData        string `json:"-"`
+	X, Y              int
+	// Width in pixels of the narrowest bar
+	ModuleWidth       int
+	// Height in pixels of the bars, not including any human readable text
+	Height            int
+	ShowText          bool
+	Encoded           barcode.Barcode `json:"-"`
+	HumanReadableText string `json:"-"`
+	TextFace          font.Face `json:"-"`
+}
+
+// A 2D code, i.e. a QR code or Data Matrix code
+type MatrixCode struct {
+	Id              int `json:"-"`
+	Symbology       Symbology
+	Data            string
+	FilledData      string `json:"-"`
+	X, Y            int
+	// Width and height in pixels of each module (square) of the code
+	ModuleSize      int
+	// Width in modules of the blank margin around the code
+	QuietZone       int
+	// Only used by QR codes
+	ErrorCorrection ErrorCorrectionLevel
+	Encoded         barcode.Barcode `json:"-"`
 }
 
+// Revisions only keep which font was used, and the rest is read from the font
+// table when they're loaded
 type Font struct {
-	Id          int
+	Id          int `json:"-"`
 	Uuid        uuid.UUID
 	Name        string
-	BuiltinName string
-	FontData    []byte
+	// Read from the font file when it's uploaded
+	Family      string
+	Style       string
+	BuiltinName string `json:"-"`
+	FontData    []byte `json:"-"`
 }
 
-const deviceWidth = 48 * 8
+// Renders a template to fit the print head of the given printer profile
+func RenderTemplate(t *Template, params map[string]string, profile printer.Profile) (image.Image, error) {
+	return RenderCopy(t, params, profile, 1, 1)
+}
 
-func RenderTemplate(t *Template, params map[string]string) (image.Image, error) {
+// Renders one of several copies of a template, for templates which print the
+// copy index
+func RenderCopy(t *Template, params map[string]string, profile printer.Profile, copy, copies int) (image.Image, error) {
 	if err := loadFontsForTemplate(t); err != nil {
 		return nil, fmt.Errorf("Couldn't load fonts for template:\n%w", err)
 	}
 	if err := loadImagesForTemplate(t); err != nil {
 		return nil, fmt.Errorf("Couldn't load images for template:\n%w", err)
 	}
-	if err := insertParamsIntoTemplateChildText(t, params); err != nil {
+	if err := insertParamsIntoTemplateChildren(t, params, copy, copies); err != nil {
 		return nil, fmt.Errorf("Couldn't insert params into template:\n%w", err)
 	}
+	if err := fitTextsForTemplate(t); err != nil {
+		return nil, fmt.Errorf("Couldn't fit text into template:\n%w", err)
+	}
+	if err := encodeBarcodesForTemplate(t); err != nil {
+		return nil, fmt.Errorf("Couldn't encode barcodes for template:\n%w", err)
+	}
+	if err := encodeMatrixCodesForTemplate(t); err != nil {
+		return nil, fmt.Errorf("Couldn't encode matrix codes for template:\n%w", err)
+	}
 
 	var width, height int
 	var err error
-	if width, height, err = measureAndCheckBounds(t); err != nil {
+	if width, height, err = measureAndCheckBounds(t, profile.DotsPerLine); err != nil {
 		return nil, fmt.Errorf("Template children failed boundary check:\n%w", err)
 	}
 
@@ -85,10 +199,22 @@ func RenderTemplate(t *Template, params map[string]string) (image.Image, error)
 		measureAndDrawChildImage(&childImage, img)
 	}
 
+	for _, childShape := range t.Shapes {
+		measureAndDrawChildShape(&childShape, img)
+	}
+
 	for _, childText := range t.Texts {
 		measureAndDrawChildText(&childText, img)
 	}
 
+	for _, childBarcode := range t.Barcodes {
+		measureAndDrawChildBarcode(&childBarcode, img)
+	}
+
+	for _, childMatrixCode := range t.MatrixCodes {
+		measureAndDrawChildMatrixCode(&childMatrixCode, img)
+	}
+
 	if t.Landscape {
 		return rotate90(img), nil
 	} else {
@@ -112,26 +238,133 @@ func rotate90(img image.Image) image.Image {
 	return newImg
 }
 
-func insertParamsIntoTemplateChildText(t *Template, params map[string]string) error {
-	for _, tp := range t.Parameters {
-		if _, exists := params[tp.Name]; !exists {
-			return fmt.Errorf("No value for parameter %v", tp.Name)
+// Every problem with the values given for a template's parameters
+type ParameterErrors []*ParameterError
+
+func (e ParameterErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Checks the values given for a template's parameters, and formats them ready
+// to be inserted into the template, filling in defaults for any optional ones
+// left out. Returns every problem found, rather than just the first.
+func ResolveParams(t *Template, params map[string]string) (map[string]string, ParameterErrors) {
+	resolved := make(map[string]string, len(t.Parameters))
+	var errs ParameterErrors
+	for i := range t.Parameters {
+		tp := &t.Parameters[i]
+		value, given := params[tp.Name]
+		v, err := tp.Resolve(value, given)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
+		resolved[tp.Name] = v
+	}
+	return resolved, errs
+}
+
+func insertParamsIntoTemplateChildren(t *Template, values map[string]string, copy, copies int) error {
+	params, errs := ResolveParams(t, values)
+	if len(errs) > 0 {
+		return errs
 	}
+	scope := &Scope{Params: params, Now: time.Now(), Copy: copy, Copies: copies}
 
+	var err error
 	for i := 0; i < len(t.Texts); i++ {
-		t.Texts[i].FilledText = insertParamsIntoString(t.Texts[i].Text, t, params)
+		if t.Texts[i].FilledText, err = evaluate(t.Texts[i].Text, t, scope); err != nil {
+			return fmt.Errorf("Couldn't fill in text %v:\n%w", i, err)
+		}
+	}
+
+	for i := 0; i < len(t.Barcodes); i++ {
+		if t.Barcodes[i].FilledData, err = evaluate(t.Barcodes[i].Data, t, scope); err != nil {
+			return fmt.Errorf("Couldn't fill in barcode %v:\n%w", i, err)
+		}
+	}
+
+	for i := 0; i < len(t.MatrixCodes); i++ {
+		if t.MatrixCodes[i].FilledData, err = evaluate(t.MatrixCodes[i].Data, t, scope); err != nil {
+			return fmt.Errorf("Couldn't fill in matrix code %v:\n%w", i, err)
+		}
 	}
 
 	return nil
 }
 
-func insertParamsIntoString(s string, t *Template, params map[string]string) string {
-	sReplaced := s
+// Fills in the expressions in a template's text or barcode content. Content
+// saved before there were expressions can have braces which don't parse, or
+// refer to parameters whose names can't be used in expressions, so that's
+// filled in the way it was then, with each {name} replaced by the value.
+func evaluate(s string, t *Template, scope *Scope) (string, error) {
+	e, err := ParseExpression(s)
+	if err != nil {
+		for _, p := range t.Parameters {
+			s = strings.ReplaceAll(s, fmt.Sprintf("{%v}", p.Name), scope.Params[p.Name])
+		}
+		return s, nil
+	}
+	return e.Evaluate(scope)
+}
+
+// The text and barcode content in a template which can contain expressions
+func (t *Template) expressions() []string {
+	exprs := []string{}
+	for _, text := range t.Texts {
+		exprs = append(exprs, text.Text)
+	}
+	for _, b := range t.Barcodes {
+		exprs = append(exprs, b.Data)
+	}
+	for _, c := range t.MatrixCodes {
+		exprs = append(exprs, c.Data)
+	}
+	return exprs
+}
+
+// Checks the expressions in a template's text and barcodes can be parsed, and
+// only refer to its parameters and built-ins.
+func (t *Template) ValidateExpressions() error {
+	names := map[string]bool{builtinNow: true, builtinCopy: true, builtinCopies: true}
+	for _, p := range t.Parameters {
+		names[p.Name] = true
+	}
+	for _, s := range t.expressions() {
+		e, err := ParseExpression(s)
+		if err != nil {
+			return fmt.Errorf("Couldn't parse %q:\n%w", s, err)
+		}
+		for _, v := range e.Variables() {
+			if !names[v] {
+				return fmt.Errorf("%q refers to %v, which isn't a parameter", s, v)
+			}
+		}
+	}
+	return nil
+}
 
-	for _, tp := range t.Parameters {
-		key := fmt.Sprintf("{%v}", tp.Name)
-		sReplaced = strings.ReplaceAll(sReplaced, key, params[tp.Name])
+// Whether the template prints the copy index, in which case each copy has
+// to be rendered separately
+func (t *Template) UsesCopyIndex() bool {
+	isParam := map[string]bool{}
+	for _, p := range t.Parameters {
+		isParam[p.Name] = true
+	}
+	for _, s := range t.expressions() {
+		e, err := ParseExpression(s)
+		if err != nil {
+			continue
+		}
+		for _, v := range e.Variables() {
+			if (v == builtinCopy || v == builtinCopies) && !isParam[v] {
+				return true
+			}
+		}
 	}
-	return sReplaced
+	return false
 }
@@ -1,44 +1,106 @@
 package main
 
 import (
+	"database/sql"
 	_ "embed"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 
 	"tomgalvin.uk/phogoprint/api"
+	"tomgalvin.uk/phogoprint/internal/cli"
+	"tomgalvin.uk/phogoprint/internal/config"
+	"tomgalvin.uk/phogoprint/internal/ipp"
+	"tomgalvin.uk/phogoprint/internal/job"
+	"tomgalvin.uk/phogoprint/internal/raw"
 	"tomgalvin.uk/phogoprint/internal/server"
 	"tomgalvin.uk/phogoprint/internal/printer"
+	"tomgalvin.uk/phogoprint/internal/template"
 )
 
 func main() {
+	if cli.IsCommand(os.Args[1:]) {
+		// commands take the config file and environment, but have flags of
+		// their own
+		cfg, err := config.Load(nil, os.LookupEnv, os.Stderr)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		level, _ := cfg.Level()
+		slog.SetLogLoggerLevel(level)
+		openDatabase := func() *sql.DB { return OpenDatabase(cfg.Database) }
+		os.Exit(cli.NewCli(os.Stdout, os.Stderr, openDatabase, cfg.Printer).Run(os.Args[1:]))
+	}
+
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	level, _ := cfg.Level()
+	slog.SetLogLoggerLevel(level)
+
 	fmt.Println("Hello, Phogoprint!")
 
-	r := NewRepository()
-	defer r.Close()
+	db := OpenDatabase(cfg.Database)
+	defer db.Close()
+	r := &template.TemplateRepository{Db: db}
+
+	logger := slog.Default()
 
-	var conn *printer.BluetoothConnection
-	conn, err := printer.FromBluetoothName("T02")
-	if err != nil {
-		slog.Error("Couldn't find printer", "err", err)
+	var conn printer.Connection
+	var supervisor *printer.Supervisor
+	if cfg.Printer.Simulate {
+		conn = printer.NewSimulatedConnection(cfg.Printer.SimulatorOptions())
+	} else {
+		bt, err := cfg.Printer.Bluetooth()
+		if err != nil {
+			slog.Error("Couldn't find printer", "err", err)
+			return
+		}
+		supervisor = printer.NewSupervisor(logger.With("src", "printer"), bt, printer.DefaultSupervisorOptions())
+		conn = supervisor
+	}
+
+	jobs := job.NewQueue(logger.With("src", "jobs"), &job.JobRepository{Db: db}, conn)
+	if supervisor != nil {
+		// carry on with any jobs which were waiting for the printer
+		supervisor.OnConnect(jobs.Resume)
+		supervisor.Start()
+	}
+	if err := jobs.Start(); err != nil {
+		slog.Error("Couldn't start job queue", "err", err)
 		return
 	}
 
+	if cfg.RawPort != 0 {
+		rl := raw.NewListener(logger.With("src", "raw"), jobs)
+		go func() {
+			if err := rl.ListenAndServe(cfg.RawAddr()); err != nil {
+				slog.Error("Raw print listener stopped", "err", err)
+			}
+		}()
+	}
+
 	mux := http.NewServeMux()
-	logger := slog.Default()
-	si := server.NewServer(logger.With("src", "server"), conn, r)
+	si := server.NewServer(logger.With("src", "server"), conn, r, jobs)
 	sh := api.NewStrictHandler(si, nil)
 	h := http.StripPrefix("/api", api.Handler(sh))
 
 
 	mux.Handle("/api/", h)
+	mux.Handle("/ipp/print", ipp.NewServer(logger.With("src", "ipp"), conn, jobs))
 
-	mux.Handle("/", http.FileServer(http.Dir("resources/web")))
+	mux.Handle("/", http.FileServer(http.Dir(cfg.StaticDir)))
 
-	port := "8080"
-	fmt.Printf("Starting server on port %s...\n", port)
-	server := http.Server{Addr:":"+port,Handler:mux}
+	fmt.Printf("Starting server on port %d...\n", cfg.Port)
+	server := http.Server{Addr:cfg.Addr(),Handler:mux}
 	if err := server.ListenAndServe(); err != nil {
 		fmt.Printf("Error starting server: %v\n", err)
 		os.Exit(1)
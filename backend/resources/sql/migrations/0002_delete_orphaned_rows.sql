@@ -0,0 +1,6 @@
+-- foreign keys weren't turned on before there were migrations, so parameters
+-- and elements of templates which were deleted could be left behind. They're
+-- removed before foreign keys are relied on.
+DELETE FROM template_parameter WHERE template_id NOT IN (SELECT id FROM template);
+DELETE FROM template_image WHERE template_id NOT IN (SELECT id FROM template);
+DELETE FROM template_text WHERE template_id NOT IN (SELECT id FROM template);
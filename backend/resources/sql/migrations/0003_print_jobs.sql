@@ -0,0 +1,25 @@
+-- default print options for jobs printed from a template
+ALTER TABLE template ADD COLUMN intensity INT NOT NULL DEFAULT 1;
+ALTER TABLE template ADD COLUMN feed_lines INT NOT NULL DEFAULT 4;
+ALTER TABLE template ADD COLUMN justify INT NOT NULL DEFAULT 1;
+ALTER TABLE template ADD COLUMN copies INT NOT NULL DEFAULT 1;
+
+CREATE TABLE print_job(
+  id INTEGER PRIMARY KEY,
+  uuid TEXT NOT NULL UNIQUE CHECK (LENGTH(uuid) = 36),
+  status TEXT NOT NULL,
+  reason TEXT,
+  template_id INT,
+  image BLOB NOT NULL,
+  -- jobs printed together from rows of a CSV file or JSON array share a batch
+  batch_uuid TEXT,
+  intensity INT NOT NULL,
+  feed_lines INT NOT NULL,
+  justify INT NOT NULL,
+  copies INT NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  started_at TIMESTAMP,
+  finished_at TIMESTAMP,
+  -- jobs are kept when their template is deleted
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE SET NULL
+);
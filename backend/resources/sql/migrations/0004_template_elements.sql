@@ -0,0 +1,41 @@
+CREATE TABLE template_barcode(
+  id INTEGER PRIMARY KEY,
+  template_id INT NOT NULL,
+  symbology TEXT NOT NULL,
+  data TEXT NOT NULL,
+  x INT NOT NULL,
+  y INT NOT NULL,
+  module_width INT NOT NULL,
+  height INT NOT NULL,
+  show_text INT NOT NULL, -- boolean
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE CASCADE
+);
+
+CREATE TABLE template_matrix_code(
+  id INTEGER PRIMARY KEY,
+  template_id INT NOT NULL,
+  symbology TEXT NOT NULL,
+  data TEXT NOT NULL,
+  x INT NOT NULL,
+  y INT NOT NULL,
+  module_size INT NOT NULL,
+  quiet_zone INT NOT NULL,
+  error_correction TEXT NOT NULL,
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE CASCADE
+);
+
+CREATE TABLE template_shape(
+  id INTEGER PRIMARY KEY,
+  template_id INT NOT NULL,
+  kind TEXT NOT NULL,
+  x INT NOT NULL,
+  y INT NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  stroke_width INT NOT NULL,
+  fill INT NOT NULL, -- boolean
+  corner_radius INT NOT NULL DEFAULT 0,
+  -- JSON array of dash and gap lengths, empty for a solid outline
+  dash TEXT NOT NULL DEFAULT '[]',
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE CASCADE
+);
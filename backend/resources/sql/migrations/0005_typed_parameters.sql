@@ -0,0 +1,10 @@
+ALTER TABLE template_parameter ADD COLUMN type TEXT NOT NULL DEFAULT 'STRING';
+ALTER TABLE template_parameter ADD COLUMN pattern TEXT NOT NULL DEFAULT '';
+ALTER TABLE template_parameter ADD COLUMN min TEXT NOT NULL DEFAULT '';
+ALTER TABLE template_parameter ADD COLUMN max TEXT NOT NULL DEFAULT '';
+ALTER TABLE template_parameter ADD COLUMN required INT NOT NULL DEFAULT 0;
+ALTER TABLE template_parameter ADD COLUMN default_value TEXT NOT NULL DEFAULT '';
+-- JSON array of the values an enum parameter can take
+ALTER TABLE template_parameter ADD COLUMN choices TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE template_parameter ADD COLUMN padding INT NOT NULL DEFAULT 0;
+ALTER TABLE template_parameter ADD COLUMN date_layout TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE template_image ADD COLUMN dither_algorithm TEXT NOT NULL DEFAULT 'FLOYD_STEINBERG';
+ALTER TABLE template_image ADD COLUMN gamma REAL NOT NULL DEFAULT 0.5;
+ALTER TABLE template_image ADD COLUMN brightness REAL NOT NULL DEFAULT 0;
+ALTER TABLE template_image ADD COLUMN contrast REAL NOT NULL DEFAULT 0;
+ALTER TABLE template_image ADD COLUMN invert INT NOT NULL DEFAULT 0; -- boolean
@@ -0,0 +1,6 @@
+ALTER TABLE template_text ADD COLUMN align TEXT NOT NULL DEFAULT 'LEFT';
+ALTER TABLE template_text ADD COLUMN vertical_align TEXT NOT NULL DEFAULT 'TOP';
+ALTER TABLE template_text ADD COLUMN line_height REAL NOT NULL DEFAULT 1;
+ALTER TABLE template_text ADD COLUMN max_lines INT NOT NULL DEFAULT 0;
+ALTER TABLE template_text ADD COLUMN auto_fit INT NOT NULL DEFAULT 0;
+ALTER TABLE template_text ADD COLUMN min_font_size INT NOT NULL DEFAULT 6;
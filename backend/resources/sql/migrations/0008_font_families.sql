@@ -0,0 +1,5 @@
+ALTER TABLE font ADD COLUMN family TEXT NOT NULL DEFAULT '';
+ALTER TABLE font ADD COLUMN style TEXT NOT NULL DEFAULT '';
+
+UPDATE font SET family = 'Go', style = 'Regular' WHERE builtin_name = 'goregular';
+UPDATE font SET family = 'Go Mono', style = 'Regular' WHERE builtin_name = 'gomono';
@@ -0,0 +1,83 @@
+-- deleting a template deletes its parameters and elements too, and fonts
+-- can't be deleted while text uses them. Foreign keys can't be changed in
+-- place, so the tables are rebuilt.
+CREATE TABLE template_parameter_new(
+  id INTEGER PRIMARY KEY,
+  template_id INT NOT NULL,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL DEFAULT 'STRING',
+  max_length INT NOT NULL,
+  pattern TEXT NOT NULL DEFAULT '',
+  min TEXT NOT NULL DEFAULT '',
+  max TEXT NOT NULL DEFAULT '',
+  required INT NOT NULL DEFAULT 0,
+  default_value TEXT NOT NULL DEFAULT '',
+  -- JSON array of the values an enum parameter can take
+  choices TEXT NOT NULL DEFAULT '[]',
+  padding INT NOT NULL DEFAULT 0,
+  date_layout TEXT NOT NULL DEFAULT '',
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE CASCADE
+);
+
+INSERT INTO template_parameter_new(id, template_id, name, type, max_length, pattern, min, max,
+  required, default_value, choices, padding, date_layout)
+SELECT id, template_id, name, type, max_length, pattern, min, max,
+  required, default_value, choices, padding, date_layout
+FROM template_parameter;
+
+DROP TABLE template_parameter;
+ALTER TABLE template_parameter_new RENAME TO template_parameter;
+
+CREATE TABLE template_image_new(
+  id INTEGER PRIMARY KEY,
+  template_id INT NOT NULL,
+  image BLOB NOT NULL,
+  x INT NOT NULL,
+  y INT NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  dither_algorithm TEXT NOT NULL DEFAULT 'FLOYD_STEINBERG',
+  gamma REAL NOT NULL DEFAULT 0.5,
+  brightness REAL NOT NULL DEFAULT 0,
+  contrast REAL NOT NULL DEFAULT 0,
+  invert INT NOT NULL DEFAULT 0, -- boolean
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE CASCADE
+);
+
+INSERT INTO template_image_new(id, template_id, image, x, y, width, height,
+  dither_algorithm, gamma, brightness, contrast, invert)
+SELECT id, template_id, image, x, y, width, height,
+  dither_algorithm, gamma, brightness, contrast, invert
+FROM template_image;
+
+DROP TABLE template_image;
+ALTER TABLE template_image_new RENAME TO template_image;
+
+CREATE TABLE template_text_new(
+  id INTEGER PRIMARY KEY,
+  template_id INT NOT NULL,
+  text TEXT NOT NULL,
+  x INT NOT NULL,
+  y INT NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  font_id INT NOT NULL,
+  font_size INT NOT NULL,
+  align TEXT NOT NULL DEFAULT 'LEFT',
+  vertical_align TEXT NOT NULL DEFAULT 'TOP',
+  line_height REAL NOT NULL DEFAULT 1,
+  max_lines INT NOT NULL DEFAULT 0,
+  auto_fit INT NOT NULL DEFAULT 0,
+  min_font_size INT NOT NULL DEFAULT 6,
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE CASCADE,
+  FOREIGN KEY (font_id) REFERENCES font(id)
+);
+
+INSERT INTO template_text_new(id, template_id, text, x, y, width, height, font_id, font_size,
+  align, vertical_align, line_height, max_lines, auto_fit, min_font_size)
+SELECT id, template_id, text, x, y, width, height, font_id, font_size,
+  align, vertical_align, line_height, max_lines, auto_fit, min_font_size
+FROM template_text;
+
+DROP TABLE template_text;
+ALTER TABLE template_text_new RENAME TO template_text;
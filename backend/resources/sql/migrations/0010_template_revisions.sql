@@ -0,0 +1,21 @@
+-- every save of a template is kept, so earlier layouts can be looked at and
+-- restored
+CREATE TABLE template_revision(
+  id INTEGER PRIMARY KEY,
+  template_id INT NOT NULL,
+  revision INT NOT NULL,
+  author TEXT NOT NULL DEFAULT '',
+  note TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL,
+  -- JSON of the whole template as it was saved
+  content TEXT NOT NULL,
+  UNIQUE (template_id, revision),
+  FOREIGN KEY (template_id) REFERENCES template(id) ON DELETE CASCADE
+);
+
+-- templates saved before revisions were kept are at revision 0, which can't
+-- be restored
+ALTER TABLE template ADD COLUMN revision INT NOT NULL DEFAULT 0;
+
+-- the revision of the template which was printed
+ALTER TABLE print_job ADD COLUMN template_revision INT;
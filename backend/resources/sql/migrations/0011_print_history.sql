@@ -0,0 +1,35 @@
+-- JSON object of the parameter values the template was printed with
+ALTER TABLE print_job ADD COLUMN parameters TEXT;
+-- the print history entry being reprinted, if any
+ALTER TABLE print_job ADD COLUMN reprint_of TEXT;
+-- jobs can hold a bitmap which has already been packed, e.g. to reprint a
+-- label exactly as it was, in which case image is the packed data rather than
+-- a PNG and these are set
+ALTER TABLE print_job ADD COLUMN bitmap_width INT;
+ALTER TABLE print_job ADD COLUMN bitmap_height INT;
+
+-- every label sent to the printer, kept as the packed bitmap which was
+-- printed so it can be printed again exactly. Templates are referred to by
+-- UUID rather than a foreign key, so the history of deleted templates is kept
+-- as it was.
+CREATE TABLE print_history(
+  id INTEGER PRIMARY KEY,
+  uuid TEXT NOT NULL UNIQUE CHECK (LENGTH(uuid) = 36),
+  job_uuid TEXT NOT NULL,
+  -- NULL for images printed directly
+  template_uuid TEXT,
+  template_revision INT,
+  parameters TEXT,
+  reprint_of TEXT,
+  intensity INT NOT NULL,
+  feed_lines INT NOT NULL,
+  justify INT NOT NULL,
+  copies INT NOT NULL,
+  -- DONE, FAILED or CANCELLED, like the job's status
+  outcome TEXT NOT NULL,
+  reason TEXT,
+  printed_at TIMESTAMP NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  bitmap BLOB NOT NULL
+);
@@ -2,29 +2,45 @@ package main
 
 import (
 	"database/sql"
-	_ "embed"
+	"embed"
 	"fmt"
+	"io/fs"
+	"log/slog"
 
-	"tomgalvin.uk/phogoprint/internal/template"
-
-	_ "github.com/ncruces/go-sqlite3/driver"
-	_ "github.com/ncruces/go-sqlite3/embed"
+	"tomgalvin.uk/phogoprint/internal/database"
 )
 
-//go:embed resources/sql/schema.sql
-var schema string
+//go:embed resources/sql/migrations/*.sql
+var migrationFiles embed.FS
 
-func NewRepository() *template.TemplateRepository {
+// Opens the database at a path, migrating it to the latest version
+func OpenDatabase(path string) *sql.DB {
 	var db *sql.DB
 	var err error
 
-	if db, err = sql.Open("sqlite3", "file:app.db"); err != nil {
+	// foreign keys are off by default in SQLite, and are needed for deleting
+	// a template to delete its children too. Jobs can be written by the API
+	// and the raw print listener at once, so writers wait for each other
+	// rather than failing straight away.
+	if db, err = sql.Open("sqlite3", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"); err != nil {
 		panic(fmt.Errorf("Couldn't open database:\n%w", err))
 	}
-	if _, err := db.Exec(schema); err != nil {
-		panic(fmt.Errorf("Couldn't initialise database:\n%w", err))
+
+	dir, err := fs.Sub(migrationFiles, "resources/sql/migrations")
+	if err != nil {
+		panic(err)
+	}
+	migrations, err := database.ReadMigrations(dir)
+	if err != nil {
+		panic(fmt.Errorf("Couldn't read migrations:\n%w", err))
+	}
+	from, to, err := database.Migrate(db, migrations)
+	if err != nil {
+		panic(fmt.Errorf("Couldn't migrate database:\n%w", err))
+	}
+	if from != to {
+		slog.Info("Migrated database", "from", from, "to", to)
 	}
-	r := template.TemplateRepository{Db: db}
 
-	return &r
+	return db
 }